@@ -1,5 +1,7 @@
 package benchmark
 
+import "github.com/skycoin/skycoin/src/cipher"
+
 type StaticStruct struct {
 	A byte
 	B uint64
@@ -19,3 +21,10 @@ type BenchmarkStruct struct {
 	ByteSlice          []uint8
 	StringMaxLen       string `enc:",maxlen=4"`
 }
+
+type NumericStruct struct {
+	Uint64s []uint64
+	Int32s  [64]int32
+	Floats  []float64
+	Hashes  []cipher.SHA256
+}
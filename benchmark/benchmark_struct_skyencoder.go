@@ -3,67 +3,30 @@
 package benchmark
 
 import (
+	"encoding/binary"
 	"errors"
 	"math"
 
 	"github.com/skycoin/skycoin/src/cipher/encoder"
+	"github.com/skycoin/skyencoder/runtime"
 )
 
 // EncodeSizeBenchmarkStruct computes the size of an encoded object of type BenchmarkStruct
 func EncodeSizeBenchmarkStruct(obj *BenchmarkStruct) uint64 {
-	i0 := uint64(0)
-
-	// obj.Int64
-	i0 += 8
-
-	// obj.String
-	i0 += 4 + uint64(len(obj.String))
-
-	// obj.StringSlice
-	i0 += 4
+	i := uint64(0)
+	i += 58
+	i += uint64(len(obj.String))
+	i += uint64(len(obj.StringSlice)) * 4
 	for _, x1 := range obj.StringSlice {
-		i1 := uint64(0)
-
-		// x1
-		i1 += 4 + uint64(len(x1))
-
-		i0 += i1
+		i += uint64(len(x1))
 	}
-
-	// obj.StaticStructArray
-	{
-		i1 := uint64(0)
-
-		// x1.A
-		i1++
-
-		// x1.B
-		i1 += 8
-
-		i0 += 3 * i1
-	}
-
-	// obj.DynamicStructSlice
-	i0 += 4
+	i += uint64(len(obj.DynamicStructSlice)) * 4
 	for _, x1 := range obj.DynamicStructSlice {
-		i1 := uint64(0)
-
-		// x1.C
-		i1 += 4 + uint64(len(x1.C))
-
-		i0 += i1
+		i += uint64(len(x1.C))
 	}
-
-	// obj.ByteArray
-	i0 += 3
-
-	// obj.ByteSlice
-	i0 += 4 + uint64(len(obj.ByteSlice))
-
-	// obj.StringMaxLen
-	i0 += 4 + uint64(len(obj.StringMaxLen))
-
-	return i0
+	i += uint64(len(obj.ByteSlice))
+	i += uint64(len(obj.StringMaxLen))
+	return i
 }
 
 // EncodeBenchmarkStruct encodes an object of type BenchmarkStruct to a buffer allocated to the exact size
@@ -125,11 +88,13 @@ func EncodeBenchmarkStructToBuffer(buf []byte, obj *BenchmarkStruct) error {
 	// obj.StaticStructArray
 	for _, x := range obj.StaticStructArray {
 
-		// x.A
-		e.Uint8(x.A)
-
-		// x.B
-		e.Uint64(x.B)
+		// x.A, x.B
+		{
+			b := e.Buffer[:9]
+			b[0] = x.A
+			binary.LittleEndian.PutUint64(b[1:9], x.B)
+			e.Buffer = e.Buffer[9:]
+		}
 
 	}
 
@@ -258,22 +223,15 @@ func DecodeBenchmarkStruct(buf []byte, obj *BenchmarkStruct) (uint64, error) {
 	{
 		// obj.StaticStructArray
 		for z1 := range obj.StaticStructArray {
-			{
-				// obj.StaticStructArray[z1].A
-				i, err := d.Uint8()
-				if err != nil {
-					return 0, err
-				}
-				obj.StaticStructArray[z1].A = i
-			}
 
+			// obj.StaticStructArray[z1].A, obj.StaticStructArray[z1].B
 			{
-				// obj.StaticStructArray[z1].B
-				i, err := d.Uint64()
-				if err != nil {
-					return 0, err
+				if len(d.Buffer) < 9 {
+					return 0, encoder.ErrBufferUnderflow
 				}
-				obj.StaticStructArray[z1].B = i
+				obj.StaticStructArray[z1].A = d.Buffer[0]
+				obj.StaticStructArray[z1].B = binary.LittleEndian.Uint64(d.Buffer[1:9])
+				d.Buffer = d.Buffer[9:]
 			}
 
 		}
@@ -382,3 +340,18 @@ func DecodeBenchmarkStructExact(buf []byte, obj *BenchmarkStruct) error {
 
 	return nil
 }
+
+// EncodeBenchmarkStructPooled encodes an object of type BenchmarkStruct to a buffer from a pool of buffers,
+// sized to the exact size required to encode the object.
+// Call Release on the buffer when its bytes are no longer used, to return it to the pool.
+func EncodeBenchmarkStructPooled(obj *BenchmarkStruct) (*runtime.Buffer, error) {
+	n := EncodeSizeBenchmarkStruct(obj)
+	buf := runtime.GetBuffer(n)
+
+	if err := EncodeBenchmarkStructToBuffer(buf.Bytes(), obj); err != nil {
+		buf.Release()
+		return nil, err
+	}
+
+	return buf, nil
+}
@@ -2,6 +2,7 @@ package benchmark
 
 import (
 	"bytes"
+	"math"
 	"reflect"
 	"testing"
 
@@ -112,6 +113,18 @@ func BenchmarkEncode(b *testing.B) {
 	}
 }
 
+func BenchmarkEncodePooled(b *testing.B) {
+	bs := newBenchmarkStruct()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		buf, _ := EncodeBenchmarkStructPooled(bs)
+		buf.Release()
+	}
+}
+
 func BenchmarkCipherEncode(b *testing.B) {
 	bs := newBenchmarkStruct()
 
@@ -321,6 +334,18 @@ func BenchmarkEncodeSignedBlock(b *testing.B) {
 	}
 }
 
+func BenchmarkEncodePooledSignedBlock(b *testing.B) {
+	bs := newSignedBlock()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		buf, _ := EncodeSignedBlockPooled(bs)
+		buf.Release()
+	}
+}
+
 func BenchmarkCipherEncodeSignedBlock(b *testing.B) {
 	bs := newSignedBlock()
 
@@ -349,6 +374,19 @@ func BenchmarkDecodeSignedBlock(b *testing.B) {
 	}
 }
 
+func BenchmarkDecodeReuseSignedBlock(b *testing.B) {
+	bs := newSignedBlock()
+	data := encoder.Serialize(bs)
+	var bs1 coin.SignedBlock
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		DecodeSignedBlockReuse(data, &bs1)
+	}
+}
+
 func BenchmarkCipherDecodeSignedBlock(b *testing.B) {
 	bs := newSignedBlock()
 	data := encoder.Serialize(bs)
@@ -361,3 +399,277 @@ func BenchmarkCipherDecodeSignedBlock(b *testing.B) {
 		encoder.DeserializeRaw(data2, &bs1)
 	}
 }
+
+func BenchmarkHashSignedBlock(b *testing.B) {
+	bs := newSignedBlock()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		HashSignedBlock(bs)
+	}
+}
+
+func BenchmarkEncodeHashSignedBlock(b *testing.B) {
+	bs := newSignedBlock()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		data, _ := EncodeSignedBlock(bs)
+		cipher.SumSHA256(data)
+	}
+}
+
+func BenchmarkCipherHashSignedBlock(b *testing.B) {
+	bs := newSignedBlock()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		cipher.SumSHA256(encoder.Serialize(bs))
+	}
+}
+
+func BenchmarkPeekSignedBlockBlockHeadBkSeq(b *testing.B) {
+	bs := newSignedBlock()
+	data := encoder.Serialize(bs)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		PeekSignedBlockBlockHeadBkSeq(data)
+	}
+}
+
+func BenchmarkDecodeSignedBlockBlockHeadBkSeq(b *testing.B) {
+	bs := newSignedBlock()
+	data := encoder.Serialize(bs)
+	var bs1 coin.SignedBlock
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		DecodeSignedBlock(data, &bs1)
+		_ = bs1.Block.Head.BkSeq
+	}
+}
+
+func BenchmarkValidateSignedBlock(b *testing.B) {
+	bs := newSignedBlock()
+	data := encoder.Serialize(bs)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		ValidateSignedBlock(data)
+	}
+}
+
+func newNumericStruct() *NumericStruct {
+	ns := &NumericStruct{
+		Uint64s: make([]uint64, 64),
+		Floats:  make([]float64, 64),
+		Hashes:  make([]cipher.SHA256, 16),
+	}
+
+	for i := range ns.Uint64s {
+		ns.Uint64s[i] = uint64(i) * 0x0102030405060708
+		ns.Int32s[i] = -int32(i)
+		ns.Floats[i] = float64(i) * 1.5
+	}
+	for i := range ns.Hashes {
+		ns.Hashes[i][0] = byte(i)
+	}
+
+	return ns
+}
+
+// encodeNumericStructPerElement encodes a NumericStruct with an encoder method call per element,
+// like the generated code does for slices which are not written in bulk
+func encodeNumericStructPerElement(buf []byte, obj *NumericStruct) {
+	e := &encoder.Encoder{
+		Buffer: buf[:],
+	}
+
+	e.Uint32(uint32(len(obj.Uint64s)))
+	for _, x := range obj.Uint64s {
+		e.Uint64(x)
+	}
+
+	for _, x := range obj.Int32s {
+		e.Int32(x)
+	}
+
+	e.Uint32(uint32(len(obj.Floats)))
+	for _, x := range obj.Floats {
+		e.Uint64(math.Float64bits(x))
+	}
+
+	e.Uint32(uint32(len(obj.Hashes)))
+	for _, x := range obj.Hashes {
+		e.CopyBytes(x[:])
+	}
+}
+
+func TestEncodeNumericStructPerElement(t *testing.T) {
+	ns := newNumericStruct()
+	buf := make([]byte, EncodeSizeNumericStruct(ns))
+	encodeNumericStructPerElement(buf, ns)
+
+	if !bytes.Equal(buf, encoder.Serialize(ns)) {
+		t.Fatal("encodeNumericStructPerElement() != encoder.Serialize()")
+	}
+}
+
+func BenchmarkEncodeNumericStructToBuffer(b *testing.B) {
+	ns := newNumericStruct()
+	buf := make([]byte, EncodeSizeNumericStruct(ns))
+
+	b.SetBytes(int64(len(buf)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		EncodeNumericStructToBuffer(buf, ns)
+	}
+}
+
+func BenchmarkEncodeNumericStructPerElement(b *testing.B) {
+	ns := newNumericStruct()
+	buf := make([]byte, EncodeSizeNumericStruct(ns))
+
+	b.SetBytes(int64(len(buf)))
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		encodeNumericStructPerElement(buf, ns)
+	}
+}
+
+// decodeBlockHeaderPerField decodes a BlockHeader with a decoder method call and a buffer length check per field,
+// like the generated code did before adjacent fixed size fields were decoded with a single length check
+func decodeBlockHeaderPerField(buf []byte, obj *coin.BlockHeader) error {
+	d := &encoder.Decoder{
+		Buffer: buf[:],
+	}
+
+	var err error
+	if obj.Version, err = d.Uint32(); err != nil {
+		return err
+	}
+	if obj.Time, err = d.Uint64(); err != nil {
+		return err
+	}
+	if obj.BkSeq, err = d.Uint64(); err != nil {
+		return err
+	}
+	if obj.Fee, err = d.Uint64(); err != nil {
+		return err
+	}
+
+	for _, h := range []*cipher.SHA256{&obj.PrevHash, &obj.BodyHash, &obj.UxHash} {
+		if len(d.Buffer) < len(h) {
+			return encoder.ErrBufferUnderflow
+		}
+		copy(h[:], d.Buffer[:len(h)])
+		d.Buffer = d.Buffer[len(h):]
+	}
+
+	return nil
+}
+
+// encodeBlockHeaderPerField encodes a BlockHeader with an encoder method call per field
+func encodeBlockHeaderPerField(buf []byte, obj *coin.BlockHeader) {
+	e := &encoder.Encoder{
+		Buffer: buf[:],
+	}
+
+	e.Uint32(obj.Version)
+	e.Uint64(obj.Time)
+	e.Uint64(obj.BkSeq)
+	e.Uint64(obj.Fee)
+	e.CopyBytes(obj.PrevHash[:])
+	e.CopyBytes(obj.BodyHash[:])
+	e.CopyBytes(obj.UxHash[:])
+}
+
+func newBlockHeader() *coin.BlockHeader {
+	return &coin.BlockHeader{
+		Version:  1,
+		Time:     1545000000,
+		BkSeq:    12,
+		Fee:      4200,
+		PrevHash: cipher.SHA256{1},
+		BodyHash: cipher.SHA256{2},
+		UxHash:   cipher.SHA256{3},
+	}
+}
+
+func TestBlockHeaderPerField(t *testing.T) {
+	bh := newBlockHeader()
+	buf := make([]byte, EncodeSizeBlockHeader(bh))
+	encodeBlockHeaderPerField(buf, bh)
+
+	if !bytes.Equal(buf, encoder.Serialize(bh)) {
+		t.Fatal("encodeBlockHeaderPerField() != encoder.Serialize()")
+	}
+
+	var bh1 coin.BlockHeader
+	if err := decodeBlockHeaderPerField(buf, &bh1); err != nil {
+		t.Fatal(err)
+	}
+
+	if bh1 != *bh {
+		t.Fatal("decodeBlockHeaderPerField() != encoder.Serialize()")
+	}
+}
+
+func BenchmarkEncodeBlockHeaderToBuffer(b *testing.B) {
+	bh := newBlockHeader()
+	buf := make([]byte, EncodeSizeBlockHeader(bh))
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		EncodeBlockHeaderToBuffer(buf, bh)
+	}
+}
+
+func BenchmarkEncodeBlockHeaderPerField(b *testing.B) {
+	bh := newBlockHeader()
+	buf := make([]byte, EncodeSizeBlockHeader(bh))
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		encodeBlockHeaderPerField(buf, bh)
+	}
+}
+
+func BenchmarkDecodeBlockHeader(b *testing.B) {
+	data := encoder.Serialize(newBlockHeader())
+	var bh coin.BlockHeader
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		DecodeBlockHeader(data, &bh)
+	}
+}
+
+func BenchmarkDecodeBlockHeaderPerField(b *testing.B) {
+	data := encoder.Serialize(newBlockHeader())
+	var bh coin.BlockHeader
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		decodeBlockHeaderPerField(data, &bh)
+	}
+}
@@ -3,102 +3,33 @@
 package benchmark
 
 import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"hash"
 	"math"
+	"strconv"
+	"strings"
 
 	"github.com/skycoin/skycoin/src/cipher"
 	"github.com/skycoin/skycoin/src/cipher/encoder"
 	"github.com/skycoin/skycoin/src/coin"
+	"github.com/skycoin/skyencoder/runtime"
 )
 
 // EncodeSizeSignedBlock computes the size of an encoded object of type SignedBlock
 func EncodeSizeSignedBlock(obj *coin.SignedBlock) uint64 {
-	i0 := uint64(0)
-
-	// obj.Block.Head.Version
-	i0 += 4
-
-	// obj.Block.Head.Time
-	i0 += 8
-
-	// obj.Block.Head.BkSeq
-	i0 += 8
-
-	// obj.Block.Head.Fee
-	i0 += 8
-
-	// obj.Block.Head.PrevHash
-	i0 += 32
-
-	// obj.Block.Head.BodyHash
-	i0 += 32
-
-	// obj.Block.Head.UxHash
-	i0 += 32
-
-	// obj.Block.Body.Transactions
-	i0 += 4
+	i := uint64(0)
+	i += 193
+	i += uint64(len(obj.Block.Body.Transactions)) * 49
 	for _, x1 := range obj.Block.Body.Transactions {
-		i1 := uint64(0)
-
-		// x1.Length
-		i1 += 4
-
-		// x1.Type
-		i1++
-
-		// x1.InnerHash
-		i1 += 32
-
-		// x1.Sigs
-		i1 += 4
-		{
-			i2 := uint64(0)
-
-			// x2
-			i2 += 65
-
-			i1 += uint64(len(x1.Sigs)) * i2
-		}
-
-		// x1.In
-		i1 += 4
-		{
-			i2 := uint64(0)
-
-			// x2
-			i2 += 32
-
-			i1 += uint64(len(x1.In)) * i2
-		}
-
-		// x1.Out
-		i1 += 4
-		{
-			i2 := uint64(0)
-
-			// x2.Address.Version
-			i2++
-
-			// x2.Address.Key
-			i2 += 20
-
-			// x2.Coins
-			i2 += 8
-
-			// x2.Hours
-			i2 += 8
-
-			i1 += uint64(len(x1.Out)) * i2
-		}
-
-		i0 += i1
+		i += uint64(len(x1.Sigs)) * 65
+		i += uint64(len(x1.In)) * 32
+		i += uint64(len(x1.Out)) * 37
 	}
-
-	// obj.Sig
-	i0 += 65
-
-	return i0
+	return i
 }
 
 // EncodeSignedBlock encodes an object of type SignedBlock to a buffer allocated to the exact size
@@ -125,26 +56,18 @@ func EncodeSignedBlockToBuffer(buf []byte, obj *coin.SignedBlock) error {
 		Buffer: buf[:],
 	}
 
-	// obj.Block.Head.Version
-	e.Uint32(obj.Block.Head.Version)
-
-	// obj.Block.Head.Time
-	e.Uint64(obj.Block.Head.Time)
-
-	// obj.Block.Head.BkSeq
-	e.Uint64(obj.Block.Head.BkSeq)
-
-	// obj.Block.Head.Fee
-	e.Uint64(obj.Block.Head.Fee)
-
-	// obj.Block.Head.PrevHash
-	e.CopyBytes(obj.Block.Head.PrevHash[:])
-
-	// obj.Block.Head.BodyHash
-	e.CopyBytes(obj.Block.Head.BodyHash[:])
-
-	// obj.Block.Head.UxHash
-	e.CopyBytes(obj.Block.Head.UxHash[:])
+	// obj.Block.Head.Version, obj.Block.Head.Time, obj.Block.Head.BkSeq, obj.Block.Head.Fee, obj.Block.Head.PrevHash, obj.Block.Head.BodyHash, obj.Block.Head.UxHash
+	{
+		b := e.Buffer[:124]
+		binary.LittleEndian.PutUint32(b[0:4], obj.Block.Head.Version)
+		binary.LittleEndian.PutUint64(b[4:12], obj.Block.Head.Time)
+		binary.LittleEndian.PutUint64(b[12:20], obj.Block.Head.BkSeq)
+		binary.LittleEndian.PutUint64(b[20:28], obj.Block.Head.Fee)
+		copy(b[28:60], obj.Block.Head.PrevHash[:])
+		copy(b[60:92], obj.Block.Head.BodyHash[:])
+		copy(b[92:124], obj.Block.Head.UxHash[:])
+		e.Buffer = e.Buffer[124:]
+	}
 
 	// obj.Block.Body.Transactions maxlen check
 	if len(obj.Block.Body.Transactions) > 65535 {
@@ -162,14 +85,14 @@ func EncodeSignedBlockToBuffer(buf []byte, obj *coin.SignedBlock) error {
 	// obj.Block.Body.Transactions
 	for _, x := range obj.Block.Body.Transactions {
 
-		// x.Length
-		e.Uint32(x.Length)
-
-		// x.Type
-		e.Uint8(x.Type)
-
-		// x.InnerHash
-		e.CopyBytes(x.InnerHash[:])
+		// x.Length, x.Type, x.InnerHash
+		{
+			b := e.Buffer[:37]
+			binary.LittleEndian.PutUint32(b[0:4], x.Length)
+			b[4] = x.Type
+			copy(b[5:37], x.InnerHash[:])
+			e.Buffer = e.Buffer[37:]
+		}
 
 		// x.Sigs maxlen check
 		if len(x.Sigs) > 65535 {
@@ -185,11 +108,12 @@ func EncodeSignedBlockToBuffer(buf []byte, obj *coin.SignedBlock) error {
 		e.Uint32(uint32(len(x.Sigs)))
 
 		// x.Sigs
-		for _, x := range x.Sigs {
-
-			// x
-			e.CopyBytes(x[:])
-
+		{
+			b := e.Buffer[:65*len(x.Sigs)]
+			for i := range x.Sigs {
+				copy(b[65*i:], x.Sigs[i][:])
+			}
+			e.Buffer = e.Buffer[len(b):]
 		}
 
 		// x.In maxlen check
@@ -206,11 +130,12 @@ func EncodeSignedBlockToBuffer(buf []byte, obj *coin.SignedBlock) error {
 		e.Uint32(uint32(len(x.In)))
 
 		// x.In
-		for _, x := range x.In {
-
-			// x
-			e.CopyBytes(x[:])
-
+		{
+			b := e.Buffer[:32*len(x.In)]
+			for i := range x.In {
+				copy(b[32*i:], x.In[i][:])
+			}
+			e.Buffer = e.Buffer[len(b):]
 		}
 
 		// x.Out maxlen check
@@ -229,17 +154,15 @@ func EncodeSignedBlockToBuffer(buf []byte, obj *coin.SignedBlock) error {
 		// x.Out
 		for _, x := range x.Out {
 
-			// x.Address.Version
-			e.Uint8(x.Address.Version)
-
-			// x.Address.Key
-			e.CopyBytes(x.Address.Key[:])
-
-			// x.Coins
-			e.Uint64(x.Coins)
-
-			// x.Hours
-			e.Uint64(x.Hours)
+			// x.Address.Version, x.Address.Key, x.Coins, x.Hours
+			{
+				b := e.Buffer[:37]
+				b[0] = x.Address.Version
+				copy(b[1:21], x.Address.Key[:])
+				binary.LittleEndian.PutUint64(b[21:29], x.Coins)
+				binary.LittleEndian.PutUint64(b[29:37], x.Hours)
+				e.Buffer = e.Buffer[37:]
+			}
 
 		}
 
@@ -259,67 +182,19 @@ func DecodeSignedBlock(buf []byte, obj *coin.SignedBlock) (uint64, error) {
 		Buffer: buf[:],
 	}
 
+	// obj.Block.Head.Version, obj.Block.Head.Time, obj.Block.Head.BkSeq, obj.Block.Head.Fee, obj.Block.Head.PrevHash, obj.Block.Head.BodyHash, obj.Block.Head.UxHash
 	{
-		// obj.Block.Head.Version
-		i, err := d.Uint32()
-		if err != nil {
-			return 0, err
-		}
-		obj.Block.Head.Version = i
-	}
-
-	{
-		// obj.Block.Head.Time
-		i, err := d.Uint64()
-		if err != nil {
-			return 0, err
-		}
-		obj.Block.Head.Time = i
-	}
-
-	{
-		// obj.Block.Head.BkSeq
-		i, err := d.Uint64()
-		if err != nil {
-			return 0, err
-		}
-		obj.Block.Head.BkSeq = i
-	}
-
-	{
-		// obj.Block.Head.Fee
-		i, err := d.Uint64()
-		if err != nil {
-			return 0, err
-		}
-		obj.Block.Head.Fee = i
-	}
-
-	{
-		// obj.Block.Head.PrevHash
-		if len(d.Buffer) < len(obj.Block.Head.PrevHash) {
-			return 0, encoder.ErrBufferUnderflow
-		}
-		copy(obj.Block.Head.PrevHash[:], d.Buffer[:len(obj.Block.Head.PrevHash)])
-		d.Buffer = d.Buffer[len(obj.Block.Head.PrevHash):]
-	}
-
-	{
-		// obj.Block.Head.BodyHash
-		if len(d.Buffer) < len(obj.Block.Head.BodyHash) {
+		if len(d.Buffer) < 124 {
 			return 0, encoder.ErrBufferUnderflow
 		}
-		copy(obj.Block.Head.BodyHash[:], d.Buffer[:len(obj.Block.Head.BodyHash)])
-		d.Buffer = d.Buffer[len(obj.Block.Head.BodyHash):]
-	}
-
-	{
-		// obj.Block.Head.UxHash
-		if len(d.Buffer) < len(obj.Block.Head.UxHash) {
-			return 0, encoder.ErrBufferUnderflow
-		}
-		copy(obj.Block.Head.UxHash[:], d.Buffer[:len(obj.Block.Head.UxHash)])
-		d.Buffer = d.Buffer[len(obj.Block.Head.UxHash):]
+		obj.Block.Head.Version = binary.LittleEndian.Uint32(d.Buffer[0:4])
+		obj.Block.Head.Time = binary.LittleEndian.Uint64(d.Buffer[4:12])
+		obj.Block.Head.BkSeq = binary.LittleEndian.Uint64(d.Buffer[12:20])
+		obj.Block.Head.Fee = binary.LittleEndian.Uint64(d.Buffer[20:28])
+		copy(obj.Block.Head.PrevHash[:], d.Buffer[28:60])
+		copy(obj.Block.Head.BodyHash[:], d.Buffer[60:92])
+		copy(obj.Block.Head.UxHash[:], d.Buffer[92:124])
+		d.Buffer = d.Buffer[124:]
 	}
 
 	{
@@ -343,31 +218,16 @@ func DecodeSignedBlock(buf []byte, obj *coin.SignedBlock) (uint64, error) {
 			obj.Block.Body.Transactions = make([]coin.Transaction, length)
 
 			for z3 := range obj.Block.Body.Transactions {
-				{
-					// obj.Block.Body.Transactions[z3].Length
-					i, err := d.Uint32()
-					if err != nil {
-						return 0, err
-					}
-					obj.Block.Body.Transactions[z3].Length = i
-				}
-
-				{
-					// obj.Block.Body.Transactions[z3].Type
-					i, err := d.Uint8()
-					if err != nil {
-						return 0, err
-					}
-					obj.Block.Body.Transactions[z3].Type = i
-				}
 
+				// obj.Block.Body.Transactions[z3].Length, obj.Block.Body.Transactions[z3].Type, obj.Block.Body.Transactions[z3].InnerHash
 				{
-					// obj.Block.Body.Transactions[z3].InnerHash
-					if len(d.Buffer) < len(obj.Block.Body.Transactions[z3].InnerHash) {
+					if len(d.Buffer) < 37 {
 						return 0, encoder.ErrBufferUnderflow
 					}
-					copy(obj.Block.Body.Transactions[z3].InnerHash[:], d.Buffer[:len(obj.Block.Body.Transactions[z3].InnerHash)])
-					d.Buffer = d.Buffer[len(obj.Block.Body.Transactions[z3].InnerHash):]
+					obj.Block.Body.Transactions[z3].Length = binary.LittleEndian.Uint32(d.Buffer[0:4])
+					obj.Block.Body.Transactions[z3].Type = d.Buffer[4]
+					copy(obj.Block.Body.Transactions[z3].InnerHash[:], d.Buffer[5:37])
+					d.Buffer = d.Buffer[37:]
 				}
 
 				{
@@ -459,40 +319,17 @@ func DecodeSignedBlock(buf []byte, obj *coin.SignedBlock) (uint64, error) {
 						obj.Block.Body.Transactions[z3].Out = make([]coin.TransactionOutput, length)
 
 						for z5 := range obj.Block.Body.Transactions[z3].Out {
-							{
-								// obj.Block.Body.Transactions[z3].Out[z5].Address.Version
-								i, err := d.Uint8()
-								if err != nil {
-									return 0, err
-								}
-								obj.Block.Body.Transactions[z3].Out[z5].Address.Version = i
-							}
 
+							// obj.Block.Body.Transactions[z3].Out[z5].Address.Version, obj.Block.Body.Transactions[z3].Out[z5].Address.Key, obj.Block.Body.Transactions[z3].Out[z5].Coins, obj.Block.Body.Transactions[z3].Out[z5].Hours
 							{
-								// obj.Block.Body.Transactions[z3].Out[z5].Address.Key
-								if len(d.Buffer) < len(obj.Block.Body.Transactions[z3].Out[z5].Address.Key) {
+								if len(d.Buffer) < 37 {
 									return 0, encoder.ErrBufferUnderflow
 								}
-								copy(obj.Block.Body.Transactions[z3].Out[z5].Address.Key[:], d.Buffer[:len(obj.Block.Body.Transactions[z3].Out[z5].Address.Key)])
-								d.Buffer = d.Buffer[len(obj.Block.Body.Transactions[z3].Out[z5].Address.Key):]
-							}
-
-							{
-								// obj.Block.Body.Transactions[z3].Out[z5].Coins
-								i, err := d.Uint64()
-								if err != nil {
-									return 0, err
-								}
-								obj.Block.Body.Transactions[z3].Out[z5].Coins = i
-							}
-
-							{
-								// obj.Block.Body.Transactions[z3].Out[z5].Hours
-								i, err := d.Uint64()
-								if err != nil {
-									return 0, err
-								}
-								obj.Block.Body.Transactions[z3].Out[z5].Hours = i
+								obj.Block.Body.Transactions[z3].Out[z5].Address.Version = d.Buffer[0]
+								copy(obj.Block.Body.Transactions[z3].Out[z5].Address.Key[:], d.Buffer[1:21])
+								obj.Block.Body.Transactions[z3].Out[z5].Coins = binary.LittleEndian.Uint64(d.Buffer[21:29])
+								obj.Block.Body.Transactions[z3].Out[z5].Hours = binary.LittleEndian.Uint64(d.Buffer[29:37])
+								d.Buffer = d.Buffer[37:]
 							}
 
 						}
@@ -526,3 +363,1279 @@ func DecodeSignedBlockExact(buf []byte, obj *coin.SignedBlock) error {
 
 	return nil
 }
+
+// EncodeSignedBlockPooled encodes an object of type SignedBlock to a buffer from a pool of buffers,
+// sized to the exact size required to encode the object.
+// Call Release on the buffer when its bytes are no longer used, to return it to the pool.
+func EncodeSignedBlockPooled(obj *coin.SignedBlock) (*runtime.Buffer, error) {
+	n := EncodeSizeSignedBlock(obj)
+	buf := runtime.GetBuffer(n)
+
+	if err := EncodeSignedBlockToBuffer(buf.Bytes(), obj); err != nil {
+		buf.Release()
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+// HashSignedBlock computes the SHA256 hash of the encoding of an object of type SignedBlock, excluding fields tagged with nohash.
+// The encoding is streamed to the hash, without allocating the encoded bytes.
+// Panics if the object can't be encoded, like encoder.Serialize.
+func HashSignedBlock(obj *coin.SignedBlock) cipher.SHA256 {
+	h := sha256.New()
+	if err := HashSignedBlockToHash(h, obj); err != nil {
+		panic(err)
+	}
+
+	var sum cipher.SHA256
+	h.Sum(sum[:0])
+	return sum
+}
+
+// HashSignedBlockToHash writes the encoding of an object of type SignedBlock to a hash.Hash, excluding fields tagged with nohash.
+// If the object can't be encoded, returns an error, and the encoding may have been partially written to the hash.
+func HashSignedBlockToHash(h hash.Hash, obj *coin.SignedBlock) error {
+	e := runtime.NewHashEncoder(h)
+
+	// obj.Block.Head.Version
+	e.Uint32(obj.Block.Head.Version)
+
+	// obj.Block.Head.Time
+	e.Uint64(obj.Block.Head.Time)
+
+	// obj.Block.Head.BkSeq
+	e.Uint64(obj.Block.Head.BkSeq)
+
+	// obj.Block.Head.Fee
+	e.Uint64(obj.Block.Head.Fee)
+
+	// obj.Block.Head.PrevHash
+	e.CopyBytes(obj.Block.Head.PrevHash[:])
+
+	// obj.Block.Head.BodyHash
+	e.CopyBytes(obj.Block.Head.BodyHash[:])
+
+	// obj.Block.Head.UxHash
+	e.CopyBytes(obj.Block.Head.UxHash[:])
+
+	// obj.Block.Body.Transactions maxlen check
+	if len(obj.Block.Body.Transactions) > 65535 {
+		return encoder.ErrMaxLenExceeded
+	}
+
+	// obj.Block.Body.Transactions length check
+	if uint64(len(obj.Block.Body.Transactions)) > math.MaxUint32 {
+		return errors.New("obj.Block.Body.Transactions length exceeds math.MaxUint32")
+	}
+
+	// obj.Block.Body.Transactions length
+	e.Uint32(uint32(len(obj.Block.Body.Transactions)))
+
+	// obj.Block.Body.Transactions
+	for _, x := range obj.Block.Body.Transactions {
+
+		// x.Length
+		e.Uint32(x.Length)
+
+		// x.Type
+		e.Uint8(x.Type)
+
+		// x.InnerHash
+		e.CopyBytes(x.InnerHash[:])
+
+		// x.Sigs maxlen check
+		if len(x.Sigs) > 65535 {
+			return encoder.ErrMaxLenExceeded
+		}
+
+		// x.Sigs length check
+		if uint64(len(x.Sigs)) > math.MaxUint32 {
+			return errors.New("x.Sigs length exceeds math.MaxUint32")
+		}
+
+		// x.Sigs length
+		e.Uint32(uint32(len(x.Sigs)))
+
+		// x.Sigs
+		for _, x := range x.Sigs {
+
+			// x
+			e.CopyBytes(x[:])
+
+		}
+
+		// x.In maxlen check
+		if len(x.In) > 65535 {
+			return encoder.ErrMaxLenExceeded
+		}
+
+		// x.In length check
+		if uint64(len(x.In)) > math.MaxUint32 {
+			return errors.New("x.In length exceeds math.MaxUint32")
+		}
+
+		// x.In length
+		e.Uint32(uint32(len(x.In)))
+
+		// x.In
+		for _, x := range x.In {
+
+			// x
+			e.CopyBytes(x[:])
+
+		}
+
+		// x.Out maxlen check
+		if len(x.Out) > 65535 {
+			return encoder.ErrMaxLenExceeded
+		}
+
+		// x.Out length check
+		if uint64(len(x.Out)) > math.MaxUint32 {
+			return errors.New("x.Out length exceeds math.MaxUint32")
+		}
+
+		// x.Out length
+		e.Uint32(uint32(len(x.Out)))
+
+		// x.Out
+		for _, x := range x.Out {
+
+			// x.Address.Version
+			e.Uint8(x.Address.Version)
+
+			// x.Address.Key
+			e.CopyBytes(x.Address.Key[:])
+
+			// x.Coins
+			e.Uint64(x.Coins)
+
+			// x.Hours
+			e.Uint64(x.Hours)
+
+		}
+
+	}
+
+	// obj.Sig
+	e.CopyBytes(obj.Sig[:])
+
+	e.Flush()
+
+	return nil
+}
+
+// DecodeSignedBlockReuse decodes an object of type SignedBlock from a buffer into an existing object.
+// Slices are resliced when their capacity is large enough, maps are cleared and refilled,
+// strings are kept when unchanged and omitted omitempty fields are reset to empty,
+// so that decoding repeatedly into the same object does not allocate in the steady state.
+// Returns the number of bytes used from the buffer to decode the object.
+// If the buffer not long enough to decode the object, returns encoder.ErrBufferUnderflow.
+func DecodeSignedBlockReuse(buf []byte, obj *coin.SignedBlock) (uint64, error) {
+	d := &encoder.Decoder{
+		Buffer: buf[:],
+	}
+
+	// obj.Block.Head.Version, obj.Block.Head.Time, obj.Block.Head.BkSeq, obj.Block.Head.Fee, obj.Block.Head.PrevHash, obj.Block.Head.BodyHash, obj.Block.Head.UxHash
+	{
+		if len(d.Buffer) < 124 {
+			return 0, encoder.ErrBufferUnderflow
+		}
+		obj.Block.Head.Version = binary.LittleEndian.Uint32(d.Buffer[0:4])
+		obj.Block.Head.Time = binary.LittleEndian.Uint64(d.Buffer[4:12])
+		obj.Block.Head.BkSeq = binary.LittleEndian.Uint64(d.Buffer[12:20])
+		obj.Block.Head.Fee = binary.LittleEndian.Uint64(d.Buffer[20:28])
+		copy(obj.Block.Head.PrevHash[:], d.Buffer[28:60])
+		copy(obj.Block.Head.BodyHash[:], d.Buffer[60:92])
+		copy(obj.Block.Head.UxHash[:], d.Buffer[92:124])
+		d.Buffer = d.Buffer[124:]
+	}
+
+	{
+		// obj.Block.Body.Transactions
+
+		ul, err := d.Uint32()
+		if err != nil {
+			return 0, err
+		}
+
+		length := int(ul)
+		if length < 0 || length > len(d.Buffer) {
+			return 0, encoder.ErrBufferUnderflow
+		}
+
+		if length > 65535 {
+			return 0, encoder.ErrMaxLenExceeded
+		}
+
+		if cap(obj.Block.Body.Transactions) >= length {
+			obj.Block.Body.Transactions = obj.Block.Body.Transactions[:length]
+		} else {
+			obj.Block.Body.Transactions = make([]coin.Transaction, length)
+		}
+
+		for z3 := range obj.Block.Body.Transactions {
+
+			// obj.Block.Body.Transactions[z3].Length, obj.Block.Body.Transactions[z3].Type, obj.Block.Body.Transactions[z3].InnerHash
+			{
+				if len(d.Buffer) < 37 {
+					return 0, encoder.ErrBufferUnderflow
+				}
+				obj.Block.Body.Transactions[z3].Length = binary.LittleEndian.Uint32(d.Buffer[0:4])
+				obj.Block.Body.Transactions[z3].Type = d.Buffer[4]
+				copy(obj.Block.Body.Transactions[z3].InnerHash[:], d.Buffer[5:37])
+				d.Buffer = d.Buffer[37:]
+			}
+
+			{
+				// obj.Block.Body.Transactions[z3].Sigs
+
+				ul, err := d.Uint32()
+				if err != nil {
+					return 0, err
+				}
+
+				length := int(ul)
+				if length < 0 || length > len(d.Buffer) {
+					return 0, encoder.ErrBufferUnderflow
+				}
+
+				if length > 65535 {
+					return 0, encoder.ErrMaxLenExceeded
+				}
+
+				if cap(obj.Block.Body.Transactions[z3].Sigs) >= length {
+					obj.Block.Body.Transactions[z3].Sigs = obj.Block.Body.Transactions[z3].Sigs[:length]
+				} else {
+					obj.Block.Body.Transactions[z3].Sigs = make([]cipher.Sig, length)
+				}
+
+				for z5 := range obj.Block.Body.Transactions[z3].Sigs {
+					{
+						// obj.Block.Body.Transactions[z3].Sigs[z5]
+						if len(d.Buffer) < len(obj.Block.Body.Transactions[z3].Sigs[z5]) {
+							return 0, encoder.ErrBufferUnderflow
+						}
+						copy(obj.Block.Body.Transactions[z3].Sigs[z5][:], d.Buffer[:len(obj.Block.Body.Transactions[z3].Sigs[z5])])
+						d.Buffer = d.Buffer[len(obj.Block.Body.Transactions[z3].Sigs[z5]):]
+					}
+
+				}
+			}
+
+			{
+				// obj.Block.Body.Transactions[z3].In
+
+				ul, err := d.Uint32()
+				if err != nil {
+					return 0, err
+				}
+
+				length := int(ul)
+				if length < 0 || length > len(d.Buffer) {
+					return 0, encoder.ErrBufferUnderflow
+				}
+
+				if length > 65535 {
+					return 0, encoder.ErrMaxLenExceeded
+				}
+
+				if cap(obj.Block.Body.Transactions[z3].In) >= length {
+					obj.Block.Body.Transactions[z3].In = obj.Block.Body.Transactions[z3].In[:length]
+				} else {
+					obj.Block.Body.Transactions[z3].In = make([]cipher.SHA256, length)
+				}
+
+				for z5 := range obj.Block.Body.Transactions[z3].In {
+					{
+						// obj.Block.Body.Transactions[z3].In[z5]
+						if len(d.Buffer) < len(obj.Block.Body.Transactions[z3].In[z5]) {
+							return 0, encoder.ErrBufferUnderflow
+						}
+						copy(obj.Block.Body.Transactions[z3].In[z5][:], d.Buffer[:len(obj.Block.Body.Transactions[z3].In[z5])])
+						d.Buffer = d.Buffer[len(obj.Block.Body.Transactions[z3].In[z5]):]
+					}
+
+				}
+			}
+
+			{
+				// obj.Block.Body.Transactions[z3].Out
+
+				ul, err := d.Uint32()
+				if err != nil {
+					return 0, err
+				}
+
+				length := int(ul)
+				if length < 0 || length > len(d.Buffer) {
+					return 0, encoder.ErrBufferUnderflow
+				}
+
+				if length > 65535 {
+					return 0, encoder.ErrMaxLenExceeded
+				}
+
+				if cap(obj.Block.Body.Transactions[z3].Out) >= length {
+					obj.Block.Body.Transactions[z3].Out = obj.Block.Body.Transactions[z3].Out[:length]
+				} else {
+					obj.Block.Body.Transactions[z3].Out = make([]coin.TransactionOutput, length)
+				}
+
+				for z5 := range obj.Block.Body.Transactions[z3].Out {
+
+					// obj.Block.Body.Transactions[z3].Out[z5].Address.Version, obj.Block.Body.Transactions[z3].Out[z5].Address.Key, obj.Block.Body.Transactions[z3].Out[z5].Coins, obj.Block.Body.Transactions[z3].Out[z5].Hours
+					{
+						if len(d.Buffer) < 37 {
+							return 0, encoder.ErrBufferUnderflow
+						}
+						obj.Block.Body.Transactions[z3].Out[z5].Address.Version = d.Buffer[0]
+						copy(obj.Block.Body.Transactions[z3].Out[z5].Address.Key[:], d.Buffer[1:21])
+						obj.Block.Body.Transactions[z3].Out[z5].Coins = binary.LittleEndian.Uint64(d.Buffer[21:29])
+						obj.Block.Body.Transactions[z3].Out[z5].Hours = binary.LittleEndian.Uint64(d.Buffer[29:37])
+						d.Buffer = d.Buffer[37:]
+					}
+
+				}
+			}
+		}
+	}
+
+	{
+		// obj.Sig
+		if len(d.Buffer) < len(obj.Sig) {
+			return 0, encoder.ErrBufferUnderflow
+		}
+		copy(obj.Sig[:], d.Buffer[:len(obj.Sig)])
+		d.Buffer = d.Buffer[len(obj.Sig):]
+	}
+
+	return uint64(len(buf) - len(d.Buffer)), nil
+}
+
+// DecodeSignedBlockReuseExact decodes an object of type SignedBlock from a buffer into an existing object,
+// like DecodeSignedBlockReuse.
+// If the buffer not long enough to decode the object, returns encoder.ErrBufferUnderflow.
+// If the buffer is longer than required to decode the object, returns encoder.ErrRemainingBytes.
+func DecodeSignedBlockReuseExact(buf []byte, obj *coin.SignedBlock) error {
+	if n, err := DecodeSignedBlockReuse(buf, obj); err != nil {
+		return err
+	} else if n != uint64(len(buf)) {
+		return encoder.ErrRemainingBytes
+	}
+
+	return nil
+}
+
+// ValidateSignedBlock checks that a buffer starts with a valid encoding of an object of type coin.SignedBlock,
+// with the same checks as DecodeSignedBlock, but without decoding the object.
+// Returns the number of bytes used from the buffer by the encoded object.
+// If the buffer not long enough to decode the object, returns encoder.ErrBufferUnderflow.
+func ValidateSignedBlock(buf []byte) (uint64, error) {
+	d := &encoder.Decoder{
+		Buffer: buf[:],
+	}
+
+	{
+		// skip obj.Block.Head
+		if len(d.Buffer) < 124 {
+			return 0, encoder.ErrBufferUnderflow
+		}
+		d.Buffer = d.Buffer[124:]
+	}
+
+	{
+		// skip obj.Block.Body.Transactions
+
+		ul, err := d.Uint32()
+		if err != nil {
+			return 0, err
+		}
+
+		length := int(ul)
+		if length < 0 || length > len(d.Buffer) {
+			return 0, encoder.ErrBufferUnderflow
+		}
+
+		if length > 65535 {
+			return 0, encoder.ErrMaxLenExceeded
+		}
+
+		for z3 := 0; z3 < length; z3++ {
+			{
+				// skip obj.Block.Body.Transactions[z3].Length
+				if len(d.Buffer) < 4 {
+					return 0, encoder.ErrBufferUnderflow
+				}
+				d.Buffer = d.Buffer[4:]
+			}
+
+			{
+				// skip obj.Block.Body.Transactions[z3].Type
+				if len(d.Buffer) < 1 {
+					return 0, encoder.ErrBufferUnderflow
+				}
+				d.Buffer = d.Buffer[1:]
+			}
+
+			{
+				// skip obj.Block.Body.Transactions[z3].InnerHash
+				if len(d.Buffer) < 32 {
+					return 0, encoder.ErrBufferUnderflow
+				}
+				d.Buffer = d.Buffer[32:]
+			}
+
+			{
+				// skip obj.Block.Body.Transactions[z3].Sigs
+
+				ul, err := d.Uint32()
+				if err != nil {
+					return 0, err
+				}
+
+				length := int(ul)
+				if length < 0 || length > len(d.Buffer) {
+					return 0, encoder.ErrBufferUnderflow
+				}
+
+				if length > 65535 {
+					return 0, encoder.ErrMaxLenExceeded
+				}
+
+				if uint64(length)*65 > uint64(len(d.Buffer)) {
+					return 0, encoder.ErrBufferUnderflow
+				}
+				d.Buffer = d.Buffer[uint64(length)*65:]
+			}
+
+			{
+				// skip obj.Block.Body.Transactions[z3].In
+
+				ul, err := d.Uint32()
+				if err != nil {
+					return 0, err
+				}
+
+				length := int(ul)
+				if length < 0 || length > len(d.Buffer) {
+					return 0, encoder.ErrBufferUnderflow
+				}
+
+				if length > 65535 {
+					return 0, encoder.ErrMaxLenExceeded
+				}
+
+				if uint64(length)*32 > uint64(len(d.Buffer)) {
+					return 0, encoder.ErrBufferUnderflow
+				}
+				d.Buffer = d.Buffer[uint64(length)*32:]
+			}
+
+			{
+				// skip obj.Block.Body.Transactions[z3].Out
+
+				ul, err := d.Uint32()
+				if err != nil {
+					return 0, err
+				}
+
+				length := int(ul)
+				if length < 0 || length > len(d.Buffer) {
+					return 0, encoder.ErrBufferUnderflow
+				}
+
+				if length > 65535 {
+					return 0, encoder.ErrMaxLenExceeded
+				}
+
+				if uint64(length)*37 > uint64(len(d.Buffer)) {
+					return 0, encoder.ErrBufferUnderflow
+				}
+				d.Buffer = d.Buffer[uint64(length)*37:]
+			}
+		}
+	}
+
+	{
+		// skip obj.Sig
+		if len(d.Buffer) < 65 {
+			return 0, encoder.ErrBufferUnderflow
+		}
+		d.Buffer = d.Buffer[65:]
+	}
+
+	return uint64(len(buf) - len(d.Buffer)), nil
+}
+
+// ValidateSignedBlockExact checks that a buffer is a valid encoding of an object of type coin.SignedBlock,
+// with the same checks as DecodeSignedBlockExact, but without decoding the object.
+// If the buffer not long enough to decode the object, returns encoder.ErrBufferUnderflow.
+// If the buffer is longer than required to decode the object, returns encoder.ErrRemainingBytes.
+func ValidateSignedBlockExact(buf []byte) error {
+	if n, err := ValidateSignedBlock(buf); err != nil {
+		return err
+	} else if n != uint64(len(buf)) {
+		return encoder.ErrRemainingBytes
+	}
+
+	return nil
+}
+
+// PeekSignedBlockBlockHead decodes the field Block.Head of an encoded object of type coin.SignedBlock,
+// skipping over the fields preceding it without decoding them.
+// If the buffer not long enough to decode the field, returns encoder.ErrBufferUnderflow.
+func PeekSignedBlockBlockHead(buf []byte) (coin.BlockHeader, error) {
+	var obj coin.BlockHeader
+
+	// The decoding code returns (0, err) on error, like in DecodeSignedBlock
+	_, err := func() (uint64, error) {
+		d := &encoder.Decoder{
+			Buffer: buf[:],
+		}
+
+		// obj.Version, obj.Time, obj.BkSeq, obj.Fee, obj.PrevHash, obj.BodyHash, obj.UxHash
+		{
+			if len(d.Buffer) < 124 {
+				return 0, encoder.ErrBufferUnderflow
+			}
+			obj.Version = binary.LittleEndian.Uint32(d.Buffer[0:4])
+			obj.Time = binary.LittleEndian.Uint64(d.Buffer[4:12])
+			obj.BkSeq = binary.LittleEndian.Uint64(d.Buffer[12:20])
+			obj.Fee = binary.LittleEndian.Uint64(d.Buffer[20:28])
+			copy(obj.PrevHash[:], d.Buffer[28:60])
+			copy(obj.BodyHash[:], d.Buffer[60:92])
+			copy(obj.UxHash[:], d.Buffer[92:124])
+			d.Buffer = d.Buffer[124:]
+		}
+
+		return 0, nil
+	}()
+
+	if err != nil {
+		var zero coin.BlockHeader
+		return zero, err
+	}
+
+	return obj, nil
+}
+
+// PeekSignedBlockBlockHeadVersion decodes the field Block.Head.Version of an encoded object of type coin.SignedBlock,
+// skipping over the fields preceding it without decoding them.
+// If the buffer not long enough to decode the field, returns encoder.ErrBufferUnderflow.
+func PeekSignedBlockBlockHeadVersion(buf []byte) (uint32, error) {
+	var obj uint32
+
+	// The decoding code returns (0, err) on error, like in DecodeSignedBlock
+	_, err := func() (uint64, error) {
+		d := &encoder.Decoder{
+			Buffer: buf[:],
+		}
+
+		{
+			// obj
+			i, err := d.Uint32()
+			if err != nil {
+				return 0, err
+			}
+			obj = i
+		}
+
+		return 0, nil
+	}()
+
+	if err != nil {
+		var zero uint32
+		return zero, err
+	}
+
+	return obj, nil
+}
+
+// PeekSignedBlockBlockHeadTime decodes the field Block.Head.Time of an encoded object of type coin.SignedBlock,
+// skipping over the fields preceding it without decoding them.
+// If the buffer not long enough to decode the field, returns encoder.ErrBufferUnderflow.
+func PeekSignedBlockBlockHeadTime(buf []byte) (uint64, error) {
+	var obj uint64
+
+	// The decoding code returns (0, err) on error, like in DecodeSignedBlock
+	_, err := func() (uint64, error) {
+		d := &encoder.Decoder{
+			Buffer: buf[:],
+		}
+
+		{
+			// skip obj.Block.Head.Version
+			if len(d.Buffer) < 4 {
+				return 0, encoder.ErrBufferUnderflow
+			}
+			d.Buffer = d.Buffer[4:]
+		}
+
+		{
+			// obj
+			i, err := d.Uint64()
+			if err != nil {
+				return 0, err
+			}
+			obj = i
+		}
+
+		return 0, nil
+	}()
+
+	if err != nil {
+		var zero uint64
+		return zero, err
+	}
+
+	return obj, nil
+}
+
+// PeekSignedBlockBlockHeadBkSeq decodes the field Block.Head.BkSeq of an encoded object of type coin.SignedBlock,
+// skipping over the fields preceding it without decoding them.
+// If the buffer not long enough to decode the field, returns encoder.ErrBufferUnderflow.
+func PeekSignedBlockBlockHeadBkSeq(buf []byte) (uint64, error) {
+	var obj uint64
+
+	// The decoding code returns (0, err) on error, like in DecodeSignedBlock
+	_, err := func() (uint64, error) {
+		d := &encoder.Decoder{
+			Buffer: buf[:],
+		}
+
+		{
+			// skip obj.Block.Head.Version
+			if len(d.Buffer) < 4 {
+				return 0, encoder.ErrBufferUnderflow
+			}
+			d.Buffer = d.Buffer[4:]
+		}
+
+		{
+			// skip obj.Block.Head.Time
+			if len(d.Buffer) < 8 {
+				return 0, encoder.ErrBufferUnderflow
+			}
+			d.Buffer = d.Buffer[8:]
+		}
+
+		{
+			// obj
+			i, err := d.Uint64()
+			if err != nil {
+				return 0, err
+			}
+			obj = i
+		}
+
+		return 0, nil
+	}()
+
+	if err != nil {
+		var zero uint64
+		return zero, err
+	}
+
+	return obj, nil
+}
+
+// PeekSignedBlockBlockHeadFee decodes the field Block.Head.Fee of an encoded object of type coin.SignedBlock,
+// skipping over the fields preceding it without decoding them.
+// If the buffer not long enough to decode the field, returns encoder.ErrBufferUnderflow.
+func PeekSignedBlockBlockHeadFee(buf []byte) (uint64, error) {
+	var obj uint64
+
+	// The decoding code returns (0, err) on error, like in DecodeSignedBlock
+	_, err := func() (uint64, error) {
+		d := &encoder.Decoder{
+			Buffer: buf[:],
+		}
+
+		{
+			// skip obj.Block.Head.Version
+			if len(d.Buffer) < 4 {
+				return 0, encoder.ErrBufferUnderflow
+			}
+			d.Buffer = d.Buffer[4:]
+		}
+
+		{
+			// skip obj.Block.Head.Time
+			if len(d.Buffer) < 8 {
+				return 0, encoder.ErrBufferUnderflow
+			}
+			d.Buffer = d.Buffer[8:]
+		}
+
+		{
+			// skip obj.Block.Head.BkSeq
+			if len(d.Buffer) < 8 {
+				return 0, encoder.ErrBufferUnderflow
+			}
+			d.Buffer = d.Buffer[8:]
+		}
+
+		{
+			// obj
+			i, err := d.Uint64()
+			if err != nil {
+				return 0, err
+			}
+			obj = i
+		}
+
+		return 0, nil
+	}()
+
+	if err != nil {
+		var zero uint64
+		return zero, err
+	}
+
+	return obj, nil
+}
+
+// PeekSignedBlockBlockHeadPrevHash decodes the field Block.Head.PrevHash of an encoded object of type coin.SignedBlock,
+// skipping over the fields preceding it without decoding them.
+// If the buffer not long enough to decode the field, returns encoder.ErrBufferUnderflow.
+func PeekSignedBlockBlockHeadPrevHash(buf []byte) (cipher.SHA256, error) {
+	var obj cipher.SHA256
+
+	// The decoding code returns (0, err) on error, like in DecodeSignedBlock
+	_, err := func() (uint64, error) {
+		d := &encoder.Decoder{
+			Buffer: buf[:],
+		}
+
+		{
+			// skip obj.Block.Head.Version
+			if len(d.Buffer) < 4 {
+				return 0, encoder.ErrBufferUnderflow
+			}
+			d.Buffer = d.Buffer[4:]
+		}
+
+		{
+			// skip obj.Block.Head.Time
+			if len(d.Buffer) < 8 {
+				return 0, encoder.ErrBufferUnderflow
+			}
+			d.Buffer = d.Buffer[8:]
+		}
+
+		{
+			// skip obj.Block.Head.BkSeq
+			if len(d.Buffer) < 8 {
+				return 0, encoder.ErrBufferUnderflow
+			}
+			d.Buffer = d.Buffer[8:]
+		}
+
+		{
+			// skip obj.Block.Head.Fee
+			if len(d.Buffer) < 8 {
+				return 0, encoder.ErrBufferUnderflow
+			}
+			d.Buffer = d.Buffer[8:]
+		}
+
+		{
+			// obj
+			if len(d.Buffer) < len(obj) {
+				return 0, encoder.ErrBufferUnderflow
+			}
+			copy(obj[:], d.Buffer[:len(obj)])
+			d.Buffer = d.Buffer[len(obj):]
+		}
+
+		return 0, nil
+	}()
+
+	if err != nil {
+		var zero cipher.SHA256
+		return zero, err
+	}
+
+	return obj, nil
+}
+
+// PeekSignedBlockBlockHeadBodyHash decodes the field Block.Head.BodyHash of an encoded object of type coin.SignedBlock,
+// skipping over the fields preceding it without decoding them.
+// If the buffer not long enough to decode the field, returns encoder.ErrBufferUnderflow.
+func PeekSignedBlockBlockHeadBodyHash(buf []byte) (cipher.SHA256, error) {
+	var obj cipher.SHA256
+
+	// The decoding code returns (0, err) on error, like in DecodeSignedBlock
+	_, err := func() (uint64, error) {
+		d := &encoder.Decoder{
+			Buffer: buf[:],
+		}
+
+		{
+			// skip obj.Block.Head.Version
+			if len(d.Buffer) < 4 {
+				return 0, encoder.ErrBufferUnderflow
+			}
+			d.Buffer = d.Buffer[4:]
+		}
+
+		{
+			// skip obj.Block.Head.Time
+			if len(d.Buffer) < 8 {
+				return 0, encoder.ErrBufferUnderflow
+			}
+			d.Buffer = d.Buffer[8:]
+		}
+
+		{
+			// skip obj.Block.Head.BkSeq
+			if len(d.Buffer) < 8 {
+				return 0, encoder.ErrBufferUnderflow
+			}
+			d.Buffer = d.Buffer[8:]
+		}
+
+		{
+			// skip obj.Block.Head.Fee
+			if len(d.Buffer) < 8 {
+				return 0, encoder.ErrBufferUnderflow
+			}
+			d.Buffer = d.Buffer[8:]
+		}
+
+		{
+			// skip obj.Block.Head.PrevHash
+			if len(d.Buffer) < 32 {
+				return 0, encoder.ErrBufferUnderflow
+			}
+			d.Buffer = d.Buffer[32:]
+		}
+
+		{
+			// obj
+			if len(d.Buffer) < len(obj) {
+				return 0, encoder.ErrBufferUnderflow
+			}
+			copy(obj[:], d.Buffer[:len(obj)])
+			d.Buffer = d.Buffer[len(obj):]
+		}
+
+		return 0, nil
+	}()
+
+	if err != nil {
+		var zero cipher.SHA256
+		return zero, err
+	}
+
+	return obj, nil
+}
+
+// PeekSignedBlockBlockHeadUxHash decodes the field Block.Head.UxHash of an encoded object of type coin.SignedBlock,
+// skipping over the fields preceding it without decoding them.
+// If the buffer not long enough to decode the field, returns encoder.ErrBufferUnderflow.
+func PeekSignedBlockBlockHeadUxHash(buf []byte) (cipher.SHA256, error) {
+	var obj cipher.SHA256
+
+	// The decoding code returns (0, err) on error, like in DecodeSignedBlock
+	_, err := func() (uint64, error) {
+		d := &encoder.Decoder{
+			Buffer: buf[:],
+		}
+
+		{
+			// skip obj.Block.Head.Version
+			if len(d.Buffer) < 4 {
+				return 0, encoder.ErrBufferUnderflow
+			}
+			d.Buffer = d.Buffer[4:]
+		}
+
+		{
+			// skip obj.Block.Head.Time
+			if len(d.Buffer) < 8 {
+				return 0, encoder.ErrBufferUnderflow
+			}
+			d.Buffer = d.Buffer[8:]
+		}
+
+		{
+			// skip obj.Block.Head.BkSeq
+			if len(d.Buffer) < 8 {
+				return 0, encoder.ErrBufferUnderflow
+			}
+			d.Buffer = d.Buffer[8:]
+		}
+
+		{
+			// skip obj.Block.Head.Fee
+			if len(d.Buffer) < 8 {
+				return 0, encoder.ErrBufferUnderflow
+			}
+			d.Buffer = d.Buffer[8:]
+		}
+
+		{
+			// skip obj.Block.Head.PrevHash
+			if len(d.Buffer) < 32 {
+				return 0, encoder.ErrBufferUnderflow
+			}
+			d.Buffer = d.Buffer[32:]
+		}
+
+		{
+			// skip obj.Block.Head.BodyHash
+			if len(d.Buffer) < 32 {
+				return 0, encoder.ErrBufferUnderflow
+			}
+			d.Buffer = d.Buffer[32:]
+		}
+
+		{
+			// obj
+			if len(d.Buffer) < len(obj) {
+				return 0, encoder.ErrBufferUnderflow
+			}
+			copy(obj[:], d.Buffer[:len(obj)])
+			d.Buffer = d.Buffer[len(obj):]
+		}
+
+		return 0, nil
+	}()
+
+	if err != nil {
+		var zero cipher.SHA256
+		return zero, err
+	}
+
+	return obj, nil
+}
+
+// PeekSignedBlockSig decodes the field Sig of an encoded object of type coin.SignedBlock,
+// skipping over the fields preceding it without decoding them.
+// If the buffer not long enough to decode the field, returns encoder.ErrBufferUnderflow.
+func PeekSignedBlockSig(buf []byte) (cipher.Sig, error) {
+	var obj cipher.Sig
+
+	// The decoding code returns (0, err) on error, like in DecodeSignedBlock
+	_, err := func() (uint64, error) {
+		d := &encoder.Decoder{
+			Buffer: buf[:],
+		}
+
+		{
+			// skip obj.Block.Head
+			if len(d.Buffer) < 124 {
+				return 0, encoder.ErrBufferUnderflow
+			}
+			d.Buffer = d.Buffer[124:]
+		}
+
+		{
+			// skip obj.Block.Body.Transactions
+
+			ul, err := d.Uint32()
+			if err != nil {
+				return 0, err
+			}
+
+			length := int(ul)
+			if length < 0 || length > len(d.Buffer) {
+				return 0, encoder.ErrBufferUnderflow
+			}
+
+			if length > 65535 {
+				return 0, encoder.ErrMaxLenExceeded
+			}
+
+			for z2 := 0; z2 < length; z2++ {
+				{
+					// skip obj.Block.Body.Transactions[z2].Length
+					if len(d.Buffer) < 4 {
+						return 0, encoder.ErrBufferUnderflow
+					}
+					d.Buffer = d.Buffer[4:]
+				}
+
+				{
+					// skip obj.Block.Body.Transactions[z2].Type
+					if len(d.Buffer) < 1 {
+						return 0, encoder.ErrBufferUnderflow
+					}
+					d.Buffer = d.Buffer[1:]
+				}
+
+				{
+					// skip obj.Block.Body.Transactions[z2].InnerHash
+					if len(d.Buffer) < 32 {
+						return 0, encoder.ErrBufferUnderflow
+					}
+					d.Buffer = d.Buffer[32:]
+				}
+
+				{
+					// skip obj.Block.Body.Transactions[z2].Sigs
+
+					ul, err := d.Uint32()
+					if err != nil {
+						return 0, err
+					}
+
+					length := int(ul)
+					if length < 0 || length > len(d.Buffer) {
+						return 0, encoder.ErrBufferUnderflow
+					}
+
+					if length > 65535 {
+						return 0, encoder.ErrMaxLenExceeded
+					}
+
+					if uint64(length)*65 > uint64(len(d.Buffer)) {
+						return 0, encoder.ErrBufferUnderflow
+					}
+					d.Buffer = d.Buffer[uint64(length)*65:]
+				}
+
+				{
+					// skip obj.Block.Body.Transactions[z2].In
+
+					ul, err := d.Uint32()
+					if err != nil {
+						return 0, err
+					}
+
+					length := int(ul)
+					if length < 0 || length > len(d.Buffer) {
+						return 0, encoder.ErrBufferUnderflow
+					}
+
+					if length > 65535 {
+						return 0, encoder.ErrMaxLenExceeded
+					}
+
+					if uint64(length)*32 > uint64(len(d.Buffer)) {
+						return 0, encoder.ErrBufferUnderflow
+					}
+					d.Buffer = d.Buffer[uint64(length)*32:]
+				}
+
+				{
+					// skip obj.Block.Body.Transactions[z2].Out
+
+					ul, err := d.Uint32()
+					if err != nil {
+						return 0, err
+					}
+
+					length := int(ul)
+					if length < 0 || length > len(d.Buffer) {
+						return 0, encoder.ErrBufferUnderflow
+					}
+
+					if length > 65535 {
+						return 0, encoder.ErrMaxLenExceeded
+					}
+
+					if uint64(length)*37 > uint64(len(d.Buffer)) {
+						return 0, encoder.ErrBufferUnderflow
+					}
+					d.Buffer = d.Buffer[uint64(length)*37:]
+				}
+			}
+		}
+
+		{
+			// obj
+			if len(d.Buffer) < len(obj) {
+				return 0, encoder.ErrBufferUnderflow
+			}
+			copy(obj[:], d.Buffer[:len(obj)])
+			d.Buffer = d.Buffer[len(obj):]
+		}
+
+		return 0, nil
+	}()
+
+	if err != nil {
+		var zero cipher.Sig
+		return zero, err
+	}
+
+	return obj, nil
+}
+
+// FormatSignedBlock formats an object of type SignedBlock for debugging, printing its fields in encoded order.
+// Byte arrays and byte slices are printed in hex, and strings, slices and maps are printed with their length.
+// Map entries are printed in iteration order, like they are encoded.
+func FormatSignedBlock(obj *coin.SignedBlock) string {
+	var w strings.Builder
+
+	w.WriteString("SignedBlock")
+
+	w.WriteString("{Block:")
+
+	w.WriteString("{Head:")
+
+	w.WriteString("{Version:")
+
+	// obj.Block.Head.Version
+	w.WriteString(strconv.FormatUint(uint64(obj.Block.Head.Version), 10))
+
+	w.WriteString(" Time:")
+
+	// obj.Block.Head.Time
+	w.WriteString(strconv.FormatUint(uint64(obj.Block.Head.Time), 10))
+
+	w.WriteString(" BkSeq:")
+
+	// obj.Block.Head.BkSeq
+	w.WriteString(strconv.FormatUint(uint64(obj.Block.Head.BkSeq), 10))
+
+	w.WriteString(" Fee:")
+
+	// obj.Block.Head.Fee
+	w.WriteString(strconv.FormatUint(uint64(obj.Block.Head.Fee), 10))
+
+	w.WriteString(" PrevHash:")
+
+	// obj.Block.Head.PrevHash
+	w.WriteString(hex.EncodeToString(obj.Block.Head.PrevHash[:]))
+
+	w.WriteString(" BodyHash:")
+
+	// obj.Block.Head.BodyHash
+	w.WriteString(hex.EncodeToString(obj.Block.Head.BodyHash[:]))
+
+	w.WriteString(" UxHash:")
+
+	// obj.Block.Head.UxHash
+	w.WriteString(hex.EncodeToString(obj.Block.Head.UxHash[:]))
+
+	w.WriteString("}")
+
+	w.WriteString(" Body:")
+
+	w.WriteString("{Transactions:")
+
+	// obj.Block.Body.Transactions length
+	fmt.Fprintf(&w, "(len=%d)", len(obj.Block.Body.Transactions))
+
+	// obj.Block.Body.Transactions
+	w.WriteString("[")
+	for i, x := range obj.Block.Body.Transactions {
+		if i != 0 {
+			w.WriteString(" ")
+		}
+
+		w.WriteString("{Length:")
+
+		// x.Length
+		w.WriteString(strconv.FormatUint(uint64(x.Length), 10))
+
+		w.WriteString(" Type:")
+
+		// x.Type
+		w.WriteString(strconv.FormatUint(uint64(x.Type), 10))
+
+		w.WriteString(" InnerHash:")
+
+		// x.InnerHash
+		w.WriteString(hex.EncodeToString(x.InnerHash[:]))
+
+		w.WriteString(" Sigs:")
+
+		// x.Sigs length
+		fmt.Fprintf(&w, "(len=%d)", len(x.Sigs))
+
+		// x.Sigs
+		w.WriteString("[")
+		for i, x := range x.Sigs {
+			if i != 0 {
+				w.WriteString(" ")
+			}
+
+			// x
+			w.WriteString(hex.EncodeToString(x[:]))
+
+		}
+		w.WriteString("]")
+
+		w.WriteString(" In:")
+
+		// x.In length
+		fmt.Fprintf(&w, "(len=%d)", len(x.In))
+
+		// x.In
+		w.WriteString("[")
+		for i, x := range x.In {
+			if i != 0 {
+				w.WriteString(" ")
+			}
+
+			// x
+			w.WriteString(hex.EncodeToString(x[:]))
+
+		}
+		w.WriteString("]")
+
+		w.WriteString(" Out:")
+
+		// x.Out length
+		fmt.Fprintf(&w, "(len=%d)", len(x.Out))
+
+		// x.Out
+		w.WriteString("[")
+		for i, x := range x.Out {
+			if i != 0 {
+				w.WriteString(" ")
+			}
+
+			w.WriteString("{Address:")
+
+			w.WriteString("{Version:")
+
+			// x.Address.Version
+			w.WriteString(strconv.FormatUint(uint64(x.Address.Version), 10))
+
+			w.WriteString(" Key:")
+
+			// x.Address.Key
+			w.WriteString(hex.EncodeToString(x.Address.Key[:]))
+
+			w.WriteString("}")
+
+			w.WriteString(" Coins:")
+
+			// x.Coins
+			w.WriteString(strconv.FormatUint(uint64(x.Coins), 10))
+
+			w.WriteString(" Hours:")
+
+			// x.Hours
+			w.WriteString(strconv.FormatUint(uint64(x.Hours), 10))
+
+			w.WriteString("}")
+
+		}
+		w.WriteString("]")
+
+		w.WriteString("}")
+
+	}
+	w.WriteString("]")
+
+	w.WriteString("}")
+
+	w.WriteString("}")
+
+	w.WriteString(" Sig:")
+
+	// obj.Sig
+	w.WriteString(hex.EncodeToString(obj.Sig[:]))
+
+	w.WriteString("}")
+
+	return w.String()
+}
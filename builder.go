@@ -1,9 +1,15 @@
 package skyencoder
 
 import (
+	"bytes"
+	"encoding/hex"
 	"errors"
 	"fmt"
+	"go/ast"
 	"go/build"
+	"go/format"
+	"go/parser"
+	"go/token"
 	"go/types"
 	"os"
 	"path/filepath"
@@ -12,12 +18,29 @@ import (
 	"strings"
 
 	"github.com/fatih/structtag"
+	"golang.org/x/tools/go/ast/astutil"
 	"golang.org/x/tools/go/loader"
 	"golang.org/x/tools/imports"
+
+	"github.com/skycoin/skyencoder/runtime"
 )
 
 const debug = false
 
+// runtimeImportPath is the import path of the package with support code for the generated code
+const runtimeImportPath = "github.com/skycoin/skyencoder/runtime"
+
+// standaloneNames are the names of the encoder package used by generated code, which the runtime package also declares
+var standaloneNames = []string{
+	"Encoder",
+	"Decoder",
+	"ErrBufferUnderflow",
+	"ErrRemainingBytes",
+	"ErrMaxLenExceeded",
+	"ErrMapDuplicateKeys",
+	"ErrInvalidBool",
+}
+
 func debugPrintln(args ...interface{}) {
 	if debug {
 		fmt.Println(args...)
@@ -68,6 +91,9 @@ func FindDiskPathOfImport(importPath string) (string, error) {
 	return "", nil
 }
 
+// directivePrefix is the prefix of comment directives in a struct type's doc comment, e.g. "//skyencoder:byteorder big"
+const directivePrefix = "//skyencoder:"
+
 // LoadProgram loads a program from args (which is a package or a set of files in a package) and build tags
 func LoadProgram(args, buildTags []string) (*loader.Program, error) {
 	buildContext := build.Default
@@ -77,7 +103,7 @@ func LoadProgram(args, buildTags []string) (*loader.Program, error) {
 	// so that a package that doesn't compile can still have a type declaration extracted
 	cfg := loader.Config{
 		Build:      &buildContext,
-		ParserMode: 0,
+		ParserMode: parser.ParseComments, // needed for //skyencoder: directives
 		TypeChecker: types.Config{
 			IgnoreFuncBodies:         true, // ignore functions
 			FakeImportC:              true, // ignore import "C"
@@ -113,6 +139,8 @@ type StructInfo struct {
 	Type     *types.Struct
 	Package  *types.Package
 	Exported bool
+	// Directives are the "//skyencoder:" comment lines of the type declaration, with the prefix removed
+	Directives []string
 }
 
 // FindStructInfoInProgram finds a matching type by name from a `*loader.Program`.
@@ -125,10 +153,11 @@ func FindStructInfoInProgram(p *loader.Program, name string) (*StructInfo, error
 		}
 		if s != nil {
 			return &StructInfo{
-				Name:     name,
-				Type:     s,
-				Package:  pk.Pkg,
-				Exported: exported,
+				Name:       name,
+				Type:       s,
+				Package:    pk.Pkg,
+				Exported:   exported,
+				Directives: findDirectives(pk, name),
 			}, nil
 		}
 	}
@@ -141,10 +170,11 @@ func FindStructInfoInProgram(p *loader.Program, name string) (*StructInfo, error
 		}
 		if s != nil {
 			return &StructInfo{
-				Name:     name,
-				Type:     s,
-				Package:  pk.Pkg,
-				Exported: exported,
+				Name:       name,
+				Type:       s,
+				Package:    pk.Pkg,
+				Exported:   exported,
+				Directives: findDirectives(pk, name),
 			}, nil
 		}
 	}
@@ -175,6 +205,78 @@ func findStructInPackage(p *loader.PackageInfo, name string) (*types.Struct, boo
 	}
 }
 
+// findDirectives returns the "//skyencoder:" directives in the doc comment of a type declaration
+func findDirectives(p *loader.PackageInfo, name string) []string {
+	obj := p.Pkg.Scope().Lookup(name)
+	if obj == nil {
+		return nil
+	}
+
+	for _, f := range p.Files {
+		for _, decl := range f.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+
+			for _, spec := range gd.Specs {
+				ts, ok := spec.(*ast.TypeSpec)
+				if !ok || ts.Name.Pos() != obj.Pos() {
+					continue
+				}
+
+				// A lone type declaration has its doc comment attached to the declaration, not the spec
+				doc := ts.Doc
+				if doc == nil && len(gd.Specs) == 1 {
+					doc = gd.Doc
+				}
+
+				if doc == nil {
+					return nil
+				}
+
+				var directives []string
+				for _, c := range doc.List {
+					if strings.HasPrefix(c.Text, directivePrefix) {
+						directives = append(directives, strings.TrimSpace(c.Text[len(directivePrefix):]))
+					}
+				}
+
+				return directives
+			}
+		}
+	}
+
+	return nil
+}
+
+// BuildOptions selects the optional functions generated by BuildStructEncoder and tested by BuildStructEncoderTest
+type BuildOptions struct {
+	// Hash generates HashX(obj) cipher.SHA256 and HashXToHash(h, obj), which stream the encoding of the object
+	// to a hash, excluding fields tagged with enc:",nohash"
+	Hash bool
+	// Peek generates PeekX<Field>(buf) functions, which decode a fixed size field of an encoded object
+	// without decoding the fields preceding it
+	Peek bool
+	// Validate generates ValidateX(buf) and ValidateXExact(buf), which check that a buffer is a valid encoding
+	// of an object without decoding it
+	Validate bool
+	// Reuse generates DecodeXReuse(buf, obj) and DecodeXReuseExact(buf, obj), which decode into the existing
+	// slices and maps of an object, to avoid allocating when decoding repeatedly into the same object
+	Reuse bool
+	// Pooled generates EncodeXPooled(obj), which encodes an object to a runtime.Buffer from a sync.Pool
+	Pooled bool
+	// Framed generates WriteFramedX(w, obj) and ReadFramedX(r, obj, maxFrame), which write and read an object
+	// as a frame of a stream, prefixed with its uint32 length
+	Framed bool
+	// Standalone generates code which uses the Encoder, Decoder and errors of the runtime package,
+	// instead of those of github.com/skycoin/skycoin/src/cipher/encoder
+	Standalone bool
+	// DebugFormat generates FormatX(obj) string, which formats an object for debugging with its fields in encoded order,
+	// and a GoString method calling it if the code is generated in the type's package
+	DebugFormat bool
+}
+
 // BuildStructEncoder builds formatted source code for encoding/decoding a type.
 // If `destPackage` is empty, assumes the generated code will be in the same package as the type.
 // Otherwise, the generated code will have this package in the package name declaration, and reference the type as an external type.
@@ -182,7 +284,7 @@ func findStructInPackage(p *loader.PackageInfo, name string) (*types.Struct, boo
 // being from this filename for the purpose of resolving the necessary import paths.
 // If not using `destPackage`, `fmtFilename` should be an arbitrary filename in the same path as the file which contains the type.
 // If using `destPackage`, `fmtFilename` should be an arbitrary filename in the path where the file is to be saved.
-func BuildStructEncoder(s *StructInfo, destPackage, fmtFilename string, exported bool) ([]byte, error) {
+func BuildStructEncoder(s *StructInfo, destPackage, fmtFilename string, exported bool, opts BuildOptions) ([]byte, error) {
 	debugPrintln("Package path:", s.Package.Path())
 	encodeSizeSrc, err := buildEncodeSize(s, destPackage != "", exported)
 	if err != nil {
@@ -208,14 +310,112 @@ func BuildStructEncoder(s *StructInfo, destPackage, fmtFilename string, exported
 
 	src := append(encodeSizeSrc, append(encodeSrc, decodeSrc...)...)
 
+	if opts.Pooled {
+		pkgName := ""
+		if destPackage != "" {
+			pkgName = s.Package.Name()
+		}
+
+		src = append(src, wrapEncodePooledFunc(s.Name, pkgName, exported)...)
+	}
+
+	if opts.Framed {
+		pkgName := ""
+		if destPackage != "" {
+			pkgName = s.Package.Name()
+		}
+
+		src = append(src, wrapFramedFuncs(s.Name, pkgName, exported)...)
+	}
+
+	version, err := structVersion(s.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if version != 0 {
+		encodeSizeVersionSrc, err := buildEncodeSizeVersion(s, destPackage != "", exported)
+		if err != nil {
+			return nil, fmt.Errorf("buildEncodeSizeVersion failed: %v", err)
+		}
+
+		encodeVersionSrc, err := buildEncodeVersion(s, destPackage != "", exported)
+		if err != nil {
+			return nil, fmt.Errorf("buildEncodeVersion failed: %v", err)
+		}
+
+		decodeVersionSrc, err := buildDecodeVersion(s, internalPackage, destPackage != "", exported)
+		if err != nil {
+			return nil, fmt.Errorf("buildDecodeVersion failed: %v", err)
+		}
+
+		src = append(src, append(encodeSizeVersionSrc, append(encodeVersionSrc, decodeVersionSrc...)...)...)
+	}
+
+	if opts.Hash {
+		hashSrc, err := buildHash(s, destPackage != "", exported)
+		if err != nil {
+			return nil, fmt.Errorf("buildHash failed: %v", err)
+		}
+
+		src = append(src, hashSrc...)
+	}
+
+	if opts.Reuse {
+		decodeReuseSrc, err := buildDecodeReuse(s, internalPackage, destPackage != "", exported)
+		if err != nil {
+			return nil, fmt.Errorf("buildDecodeReuse failed: %v", err)
+		}
+
+		src = append(src, decodeReuseSrc...)
+	}
+
+	if opts.Validate {
+		validateSrc, err := buildValidate(s, destPackage != "", exported)
+		if err != nil {
+			return nil, fmt.Errorf("buildValidate failed: %v", err)
+		}
+
+		src = append(src, validateSrc...)
+	}
+
+	if opts.Peek {
+		peekSrc, err := buildPeek(s, internalPackage, destPackage != "", exported)
+		if err != nil {
+			return nil, fmt.Errorf("buildPeek failed: %v", err)
+		}
+
+		src = append(src, peekSrc...)
+	}
+
+	if opts.DebugFormat {
+		formatSrc, err := buildFormat(s, destPackage != "", exported)
+		if err != nil {
+			return nil, fmt.Errorf("buildFormat failed: %v", err)
+		}
+
+		src = append(src, formatSrc...)
+	}
+
 	pkgName := destPackage
 	if pkgName == "" {
 		pkgName = s.Package.Name()
 	}
 
 	pkgHeader := fmt.Sprintf("// Code generated by github.com/skycoin/skyencoder. DO NOT EDIT.\n\npackage %s\n\n", pkgName)
+
+	// The runtime package's name is ambiguous with the standard library's, so it is imported explicitly.
+	// imports.Process removes the import if the generated code does not use it.
+	pkgHeader += fmt.Sprintf("import %q\n\n", runtimeImportPath)
 	src = append([]byte(pkgHeader), src...)
 
+	if opts.Standalone {
+		src, err = rewriteStandalone(fmtFilename, src)
+		if err != nil {
+			return nil, fmt.Errorf("rewriteStandalone failed: %v", err)
+		}
+	}
+
 	// Format with imports
 	fmtSrc, err := imports.Process(fmtFilename, src, &imports.Options{
 		Fragment:  false,
@@ -232,7 +432,203 @@ func BuildStructEncoder(s *StructInfo, destPackage, fmtFilename string, exported
 }
 
 // BuildStructEncoderTest builds the _test.go file that tests the code generated by BuildStructEncoder
-func BuildStructEncoderTest(s *StructInfo, destPackage, fmtFilename string, exported bool) ([]byte, error) {
+func BuildStructEncoderTest(s *StructInfo, destPackage, fmtFilename string, exported bool, opts BuildOptions) ([]byte, error) {
+	p := structPackage(s, destPackage != "")
+
+	pkgName := ""
+	if destPackage != "" {
+		pkgName = s.Package.Name()
+	} else {
+		destPackage = s.Package.Name()
+	}
+
+	structOptions, err := parseDirectives(s.Directives)
+	if err != nil {
+		return nil, err
+	}
+
+	// The values of the members of unions are checked along with the struct
+	uts, err := unionTypes(s.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	checkedTypes := []types.Type{s.Type}
+	unionNames := make([]string, len(uts))
+	unionMemberNames := make([][]string, len(uts))
+	for i, ut := range uts {
+		members, err := findUnion(ut, structOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		unionNames[i] = typeNameOf(ut, p)
+		for _, m := range members {
+			checkedTypes = append(checkedTypes, m.t)
+			unionMemberNames[i] = append(unionMemberNames[i], unionMemberName(m, p))
+		}
+	}
+
+	hm, err := anyType(checkedTypes, hasMap)
+	if err != nil {
+		return nil, err
+	}
+
+	hasFixedLength, err := anyType(checkedTypes, func(t types.Type) (bool, error) {
+		return hasFieldOption(t, func(o *Options) bool {
+			return o.Length != 0
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	hasConst, err := anyType(checkedTypes, func(t types.Type) (bool, error) {
+		return hasFieldOption(t, func(o *Options) bool {
+			return o.Const != ""
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The reflect-based encoder only encodes in little-endian byte order
+	hasBigEndian := structOptions != nil && structOptions.BigEndian
+	if !hasBigEndian {
+		hasBigEndian, err = anyType(checkedTypes, func(t types.Type) (bool, error) {
+			return hasFieldOption(t, func(o *Options) bool {
+				return o.BigEndian
+			})
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	rts, err := recursiveTypes(s.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	ordered, err := anyType(checkedTypes, func(t types.Type) (bool, error) {
+		return hasFieldOption(t, func(o *Options) bool {
+			return o.Order != 0
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	hasChecksum := structOptions != nil && structOptions.Checksum != ""
+
+	// The reflect-based encoder does not encode interface types or checksums.
+	// Objects with ordered fields are encoded by it through a copy of a type built with the reordered fields,
+	// which can't be built for a recursive type.
+	reflectCompatible := !hasFixedLength && !hasBigEndian && !hasChecksum && len(uts) == 0 && (!ordered || len(rts) == 0)
+
+	src := buildTest(s.Name, pkgName, destPackage, hm, exported, reflectCompatible, hasFixedLength, hasConst, len(rts) != 0, ordered, unionNames, unionMemberNames)
+
+	version, err := structVersion(s.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if version != 0 {
+		src += buildTestVersion(s.Name, pkgName, version, hm, exported)
+	}
+
+	if opts.Hash {
+		noHashFields, err := noHashFieldNames(s.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		var hashFields []hashTestField
+		if len(noHashFields) != 0 {
+			hashFields, err = buildTestHashFields(s, p, pkgName, structOptions)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		src += buildTestHash(s.Name, pkgName, noHashFields, hashFields, exported, hasChecksum)
+	}
+
+	if opts.Reuse {
+		src += buildTestDecodeReuse(s.Name, pkgName, exported)
+	}
+
+	if opts.Pooled {
+		src += buildTestEncodePooled(s.Name, pkgName, hm, exported)
+	}
+
+	if opts.Framed {
+		src += buildTestFramed(s.Name, pkgName, hm, exported)
+	}
+
+	if opts.Validate {
+		src += buildTestValidate(s.Name, pkgName, exported)
+	}
+
+	if opts.DebugFormat {
+		// The labels of ordered fields are checked to be formatted in encoded order
+		var labels []string
+		if ordered {
+			labels, err = formatFieldLabels(s.Type)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		src += buildTestFormat(s.Name, pkgName, hm, exported, labels)
+	}
+
+	if opts.Peek {
+		options, err := parseDirectives(s.Directives)
+		if err != nil {
+			return nil, err
+		}
+
+		fields, _, _, err := findPeekFields(s.Type, "obj", nil, nil, nil, options)
+		if err != nil {
+			return nil, err
+		}
+
+		fieldPaths := make([]string, len(fields))
+		for i, f := range fields {
+			fieldPaths[i] = strings.Join(f.path, ".")
+		}
+
+		src += buildTestPeek(s.Name, pkgName, fieldPaths, exported)
+	}
+
+	if opts.Standalone {
+		standaloneSrc, err := rewriteStandalone(fmtFilename, []byte(src))
+		if err != nil {
+			return nil, fmt.Errorf("rewriteStandalone failed: %v", err)
+		}
+		src = string(standaloneSrc)
+	}
+
+	// Format with imports
+	fmtSrc, err := imports.Process(fmtFilename, []byte(src), &imports.Options{
+		Fragment:  false,
+		Comments:  true,
+		TabIndent: true,
+		TabWidth:  8,
+	})
+	if err != nil {
+		debugPrintln(string(src))
+		return nil, fmt.Errorf("imports.Process failed: %v", err)
+	}
+
+	return fmtSrc, nil
+}
+
+// BuildStructVectorsTest builds a test that decodes and re-encodes the golden test vectors of a struct,
+// written by BuildStructVectors to vectorsFilename, a slash-separated path relative to the test's package.
+// The test fails if a vector is not re-encoded to the same bytes.
+func BuildStructVectorsTest(s *StructInfo, destPackage, fmtFilename, vectorsFilename string, exported bool) ([]byte, error) {
 	pkgName := ""
 	if destPackage != "" {
 		pkgName = s.Package.Name()
@@ -245,7 +641,7 @@ func BuildStructEncoderTest(s *StructInfo, destPackage, fmtFilename string, expo
 		return nil, err
 	}
 
-	src := buildTest(s.Name, pkgName, destPackage, hm, exported)
+	src := buildTestVectors(s.Name, pkgName, destPackage, vectorsFilename, hm, exported)
 
 	// Format with imports
 	fmtSrc, err := imports.Process(fmtFilename, []byte(src), &imports.Options{
@@ -263,21 +659,58 @@ func BuildStructEncoderTest(s *StructInfo, destPackage, fmtFilename string, expo
 }
 
 func buildEncodeSize(s *StructInfo, externalPackage, exported bool) ([]byte, error) {
-	section, _, err := buildCodeSectionEncodeSize(s.Type, "obj", "i", 0, nil)
+	options, err := parseDirectives(s.Directives)
+	if err != nil {
+		return nil, err
+	}
+
+	p := structPackage(s, externalPackage)
+	steps, err := buildSizeSteps(s.Type, p, ast.NewIdent("obj"), 0, options)
 	if err != nil {
 		return nil, err
 	}
 
+	if c, err := findChecksum(s.Type, options); err != nil {
+		return nil, err
+	} else if c != nil {
+		steps = append(steps, sizeFixed{size: runtime.ChecksumSize})
+	}
+
 	pkgName := ""
 	if externalPackage {
 		pkgName = s.Package.Name()
 	}
 
-	return wrapEncodeSizeFunc(s.Name, pkgName, "i0", section, exported), nil
+	funcs, err := buildSizeFuncs([]types.Type{s.Type}, p, options)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapEncodeSizeFunc(s.Name, pkgName, funcs, steps, exported)
 }
 
 func buildEncode(s *StructInfo, externalPackage, exported bool) ([]byte, error) {
-	section, err := buildCodeSectionEncode(s.Type, "obj", true, true, nil)
+	options, err := parseDirectives(s.Directives)
+	if err != nil {
+		return nil, err
+	}
+
+	p := structPackage(s, externalPackage)
+
+	section, err := buildCodeSectionEncode(s.Type, p, "obj", true, true, true, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if c, err := findChecksum(s.Type, options); err != nil {
+		return nil, err
+	} else if c != nil {
+		section += buildEncodeChecksum(c.funcName)
+	}
+
+	funcs, err := buildRecursiveFuncs([]types.Type{s.Type}, p, "encode", true, false, "error", "return nil", func(t types.Type, varName string) (string, error) {
+		return buildCodeSectionEncode(t, p, varName, false, false, true, options)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -287,11 +720,29 @@ func buildEncode(s *StructInfo, externalPackage, exported bool) ([]byte, error)
 		pkgName = s.Package.Name()
 	}
 
-	return wrapEncodeFunc(s.Name, pkgName, section, exported), nil
+	return wrapEncodeFunc(s.Name, pkgName, funcs+section, exported), nil
 }
 
 func buildDecode(s *StructInfo, p *types.Package, externalPackage, exported bool) ([]byte, error) {
-	section, err := buildCodeSectionDecode(s.Type, p, "obj", true, s.Name, 0, nil)
+	options, err := parseDirectives(s.Directives)
+	if err != nil {
+		return nil, err
+	}
+
+	section, err := buildCodeSectionDecode(s.Type, p, "obj", true, s.Name, 0, false, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if c, err := findChecksum(s.Type, options); err != nil {
+		return nil, err
+	} else if c != nil {
+		section += buildDecodeChecksum(c.funcName)
+	}
+
+	funcs, err := buildRecursiveFuncs([]types.Type{s.Type}, p, "decode", true, true, "(uint64, error)", "return 0, nil", func(t types.Type, varName string) (string, error) {
+		return buildCodeSectionDecode(t, p, varName, false, "", 0, false, options)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -301,31 +752,1203 @@ func buildDecode(s *StructInfo, p *types.Package, externalPackage, exported bool
 		pkgName = s.Package.Name()
 	}
 
-	return wrapDecodeFunc(s.Name, pkgName, section, exported), nil
+	return wrapDecodeFunc(s.Name, pkgName, funcs+section, exported), nil
 }
 
-func buildCodeSectionEncode(t types.Type, varName string, castType, isTopLevel bool, options *Options) (string, error) {
-	// castType applies to basic int types; if true, an additional cast will be made in the generated code.
-	// This is to convert types like "type Foo int8" back to int8
+func buildDecodeReuse(s *StructInfo, p *types.Package, externalPackage, exported bool) ([]byte, error) {
+	options, err := parseDirectives(s.Directives)
+	if err != nil {
+		return nil, err
+	}
 
-	debugPrintf("buildCodeSectionEncode type=%T varName=%s castType=%v options=%+v\n", t, varName, castType, options)
+	section, err := buildCodeSectionDecode(s.Type, p, "obj", true, s.Name, 0, true, options)
+	if err != nil {
+		return nil, err
+	}
 
-	if options != nil {
-		if options.OmitEmpty && !omitEmptyIsValid(t) {
-			return "", errors.New("omitempty is only valid for array, slice, map and string")
-		}
+	if c, err := findChecksum(s.Type, options); err != nil {
+		return nil, err
+	} else if c != nil {
+		section += buildDecodeChecksum(c.funcName)
 	}
 
-	switch x := t.(type) {
-	case *types.Named:
-		return buildCodeSectionEncode(x.Underlying(), varName, true, false, options)
+	funcs, err := buildRecursiveFuncs([]types.Type{s.Type}, p, "decode", true, true, "(uint64, error)", "return 0, nil", func(t types.Type, varName string) (string, error) {
+		return buildCodeSectionDecode(t, p, varName, false, "", 0, true, options)
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	case *types.Basic:
-		switch x.Kind() {
-		case types.Bool:
-			return buildEncodeBool(varName, castType, options), nil
-		case types.Int8:
-			return buildEncodeInt8(varName, castType, options), nil
+	pkgName := ""
+	if externalPackage {
+		pkgName = s.Package.Name()
+	}
+
+	return wrapDecodeReuseFunc(s.Name, pkgName, funcs+section, exported), nil
+}
+
+func buildEncodeSizeVersion(s *StructInfo, externalPackage, exported bool) ([]byte, error) {
+	structOptions, err := parseDirectives(s.Directives)
+	if err != nil {
+		return nil, err
+	}
+
+	p := structPackage(s, externalPackage)
+	var steps []sizeStep
+	order, err := encodedFields(s.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, i := range order {
+		f := s.Type.Field(i)
+
+		if !f.Exported() {
+			continue
+		}
+
+		ignore, options, err := parseTag(s.Type.Tag(i))
+		if err != nil {
+			return nil, err
+		}
+
+		if ignore {
+			continue
+		}
+
+		fieldSteps, err := buildSizeSteps(f.Type(), p, &ast.SelectorExpr{
+			X:   ast.NewIdent("obj"),
+			Sel: ast.NewIdent(f.Name()),
+		}, 0, inheritOptions(structOptions, options))
+		if err != nil {
+			return nil, err
+		}
+
+		if options != nil && options.Since != 0 {
+			fieldSteps = sizeSince(fieldSteps, options.Since)
+		}
+
+		steps = append(steps, fieldSteps...)
+	}
+
+	if c, err := findChecksum(s.Type, structOptions); err != nil {
+		return nil, err
+	} else if c != nil {
+		steps = append(steps, sizeFixed{size: runtime.ChecksumSize})
+	}
+
+	pkgName := ""
+	if externalPackage {
+		pkgName = s.Package.Name()
+	}
+
+	funcs, err := buildSizeFuncs([]types.Type{s.Type}, p, structOptions)
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapEncodeSizeVersionFunc(s.Name, pkgName, funcs, steps, exported)
+}
+
+func buildEncodeVersion(s *StructInfo, externalPackage, exported bool) ([]byte, error) {
+	structOptions, err := parseDirectives(s.Directives)
+	if err != nil {
+		return nil, err
+	}
+
+	p := structPackage(s, externalPackage)
+
+	sections := make([]string, s.Type.NumFields())
+	order, err := encodedFields(s.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	for j, i := range order {
+		f := s.Type.Field(i)
+
+		if !f.Exported() {
+			continue
+		}
+
+		ignore, options, err := parseTag(s.Type.Tag(i))
+		if err != nil {
+			return nil, err
+		}
+
+		if ignore {
+			continue
+		}
+
+		options = inheritOptions(structOptions, options)
+
+		nextVarName := fmt.Sprintf("obj.%s", f.Name())
+		section, err := buildCodeSectionEncode(f.Type(), p, nextVarName, false, false, true, options)
+		if err != nil {
+			return nil, err
+		}
+
+		if options != nil && options.Since != 0 {
+			section = wrapVersionCheck(section, options.Since)
+		}
+
+		sections[j] = section
+	}
+
+	pkgName := ""
+	if externalPackage {
+		pkgName = s.Package.Name()
+	}
+
+	funcs, err := buildRecursiveFuncs([]types.Type{s.Type}, p, "encode", true, false, "error", "return nil", func(t types.Type, varName string) (string, error) {
+		return buildCodeSectionEncode(t, p, varName, false, false, true, structOptions)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if c, err := findChecksum(s.Type, structOptions); err != nil {
+		return nil, err
+	} else if c != nil {
+		sections = append(sections, buildEncodeChecksum(c.funcName))
+	}
+
+	return wrapEncodeVersionFunc(s.Name, pkgName, funcs+strings.Join(sections, "\n\n"), exported), nil
+}
+
+func buildDecodeVersion(s *StructInfo, p *types.Package, externalPackage, exported bool) ([]byte, error) {
+	structOptions, err := parseDirectives(s.Directives)
+	if err != nil {
+		return nil, err
+	}
+
+	sections := make([]string, s.Type.NumFields())
+	order, err := encodedFields(s.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	for j, i := range order {
+		f := s.Type.Field(i)
+
+		if !f.Exported() {
+			continue
+		}
+
+		ignore, options, err := parseTag(s.Type.Tag(i))
+		if err != nil {
+			return nil, err
+		}
+
+		if ignore {
+			continue
+		}
+
+		options = inheritOptions(structOptions, options)
+
+		nextVarName := fmt.Sprintf("obj.%s", f.Name())
+		section, err := buildCodeSectionDecode(f.Type(), p, nextVarName, false, "", 1, false, options)
+		if err != nil {
+			return nil, err
+		}
+
+		if options != nil && options.Since != 0 {
+			section = wrapDecodeVersionCheck(nextVarName, typeNameOf(f.Type(), p), section, options.Since)
+		}
+
+		sections[j] = section
+	}
+
+	pkgName := ""
+	if externalPackage {
+		pkgName = s.Package.Name()
+	}
+
+	funcs, err := buildRecursiveFuncs([]types.Type{s.Type}, p, "decode", true, true, "(uint64, error)", "return 0, nil", func(t types.Type, varName string) (string, error) {
+		return buildCodeSectionDecode(t, p, varName, false, "", 0, false, structOptions)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if c, err := findChecksum(s.Type, structOptions); err != nil {
+		return nil, err
+	} else if c != nil {
+		sections = append(sections, buildDecodeChecksum(c.funcName))
+	}
+
+	return wrapDecodeVersionFunc(s.Name, pkgName, funcs+strings.Join(sections, "\n\n"), exported), nil
+}
+
+func buildHash(s *StructInfo, externalPackage, exported bool) ([]byte, error) {
+	structOptions, err := parseDirectives(s.Directives)
+	if err != nil {
+		return nil, err
+	}
+
+	p := structPackage(s, externalPackage)
+
+	sections := make([]string, s.Type.NumFields())
+	var hashedTypes []types.Type
+	order, err := encodedFields(s.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	for j, i := range order {
+		f := s.Type.Field(i)
+
+		if !f.Exported() {
+			continue
+		}
+
+		ignore, options, err := parseTag(s.Type.Tag(i))
+		if err != nil {
+			return nil, err
+		}
+
+		if ignore || (options != nil && options.NoHash) {
+			continue
+		}
+
+		hashedTypes = append(hashedTypes, f.Type())
+
+		options = inheritOptions(structOptions, options)
+
+		memberTypes, err := unionMemberTypes(options, f.Type())
+		if err != nil {
+			return nil, err
+		}
+
+		// Map iteration order is random, so a map's encoding can't be hashed
+		if hm, err := anyType(append([]types.Type{f.Type()}, memberTypes...), hasMap); err != nil {
+			return nil, err
+		} else if hm {
+			return nil, fmt.Errorf("Field %s contains a map, which can't be hashed (tag it with nohash)", f.Name())
+		}
+
+		nextVarName := fmt.Sprintf("obj.%s", f.Name())
+		section, err := buildCodeSectionEncode(f.Type(), p, nextVarName, false, false, false, options)
+		if err != nil {
+			return nil, err
+		}
+
+		sections[j] = section
+	}
+
+	pkgName := ""
+	if externalPackage {
+		pkgName = s.Package.Name()
+	}
+
+	funcs, err := buildRecursiveFuncs(hashedTypes, p, "encode", true, false, "error", "return nil", func(t types.Type, varName string) (string, error) {
+		return buildCodeSectionEncode(t, p, varName, false, false, false, structOptions)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return wrapHashFunc(s.Name, pkgName, funcs+strings.Join(sections, "\n\n"), exported), nil
+}
+
+func buildPeek(s *StructInfo, p *types.Package, externalPackage, exported bool) ([]byte, error) {
+	options, err := parseDirectives(s.Directives)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, _, _, err := findPeekFields(s.Type, "obj", nil, nil, nil, options)
+	if err != nil {
+		return nil, err
+	}
+
+	pkgName := ""
+	if externalPackage {
+		pkgName = s.Package.Name()
+	}
+
+	var src []byte
+	funcNames := make(map[string]string, len(fields))
+	for _, f := range fields {
+		// Field paths are concatenated into function names, e.g. A.BC and AB.C would have the same name
+		fieldPath := strings.Join(f.path, ".")
+		funcName := strings.Join(f.path, "")
+		if other, ok := funcNames[funcName]; ok {
+			return nil, fmt.Errorf("Fields %s and %s have the same peek function name", other, fieldPath)
+		}
+		funcNames[funcName] = fieldPath
+
+		section, err := buildCodeSectionDecode(f.t, p, "obj", false, "", 0, false, f.options)
+		if err != nil {
+			return nil, err
+		}
+
+		funcs, err := buildRecursiveFuncs(f.skipTypes, p, "skip", false, true, "(uint64, error)", "return 0, nil", func(t types.Type, varName string) (string, error) {
+			return buildCodeSectionSkip(t, varName, 0, false, options)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		skipSection := funcs + strings.Join(f.skipSections, "\n\n")
+
+		src = append(src, wrapPeekFunc(s.Name, pkgName, fieldPath, typeNameOf(f.t, p), skipSection, section, exported)...)
+	}
+
+	return src, nil
+}
+
+// peekField is a field of a struct, or of a struct nested in it, which can be decoded without allocating
+type peekField struct {
+	path    []string
+	t       types.Type
+	options *Options
+	// skipSections skip the fields preceding the field
+	skipSections []string
+	// skipTypes are the types of the fields preceding the field
+	skipTypes []types.Type
+}
+
+// findPeekFields returns the fields of a struct and of the structs nested in it which can be decoded without allocating,
+// and the code sections which skip each field of the struct, with the types of the fields.
+// skipSections are the code sections which skip the fields preceding the struct, of types skipTypes.
+func findPeekFields(t *types.Struct, varName string, path, skipSections []string, skipTypes []types.Type, options *Options) ([]peekField, []string, []types.Type, error) {
+	var fields []peekField
+	parentOptions := options
+	order, err := encodedFields(t)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	for _, i := range order {
+		f := t.Field(i)
+
+		if !f.Exported() {
+			continue
+		}
+
+		ignore, options, err := parseTag(t.Tag(i))
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		if ignore {
+			continue
+		}
+
+		options = inheritOptions(parentOptions, options)
+
+		fieldPath := append(path[:len(path):len(path)], f.Name())
+		fieldSkipSections := skipSections[:len(skipSections):len(skipSections)]
+		fieldSkipTypes := skipTypes[:len(skipTypes):len(skipTypes)]
+		nextVarName := fmt.Sprintf("%s.%s", varName, f.Name())
+
+		size, fixed, err := fixedEncodedSize(f.Type(), options)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		if fixed && size != 0 && isPeekable(f.Type()) {
+			fields = append(fields, peekField{
+				path:         fieldPath,
+				t:            f.Type(),
+				options:      options,
+				skipSections: fieldSkipSections,
+				skipTypes:    fieldSkipTypes,
+			})
+		}
+
+		// Fields of nested structs are peeked too
+		if st, ok := f.Type().Underlying().(*types.Struct); ok {
+			nestedFields, _, _, err := findPeekFields(st, nextVarName, fieldPath, fieldSkipSections, fieldSkipTypes, options)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			fields = append(fields, nestedFields...)
+		}
+
+		section, err := buildCodeSectionSkip(f.Type(), nextVarName, 0, false, options)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		skipSections = append(fieldSkipSections, section)
+		skipTypes = append(fieldSkipTypes, f.Type())
+	}
+
+	return fields, skipSections, skipTypes, nil
+}
+
+// isPeekable returns true if a type has a fixed encoded size and can be decoded without allocating
+func isPeekable(t types.Type) bool {
+	switch x := t.(type) {
+	case *types.Named:
+		return isPeekable(x.Underlying())
+	case *types.Basic:
+		return x.Kind() != types.String
+	case *types.Array:
+		return isPeekable(x.Elem())
+	case *types.Struct:
+		for i := 0; i < x.NumFields(); i++ {
+			f := x.Field(i)
+			if !f.Exported() {
+				continue
+			}
+
+			ignore, _, err := parseTag(x.Tag(i))
+			if err != nil {
+				return false
+			}
+
+			if !ignore && !isPeekable(f.Type()) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// hasCheckedValue returns true if a type contains a bool or a field with a const option,
+// whose encoded bytes are checked when decoded
+func hasCheckedValue(t types.Type) (bool, error) {
+	if hasBasicKind(t, types.Bool) {
+		return true, nil
+	}
+
+	return hasFieldOption(t, func(o *Options) bool {
+		return o.Const != ""
+	})
+}
+
+// fixedEncodedSize returns the encoded size of a type and true, if the type always has the same encoded size
+func fixedEncodedSize(t types.Type, options *Options) (uint64, bool, error) {
+	switch x := t.(type) {
+	case *types.Named:
+		if isRecursive(x) || isUnion(x) {
+			return 0, false, nil
+		}
+		return fixedEncodedSize(x.Underlying(), options)
+
+	case *types.Basic:
+		switch x.Kind() {
+		case types.Bool, types.Int8, types.Uint8:
+			return 1, true, nil
+		case types.Int16, types.Uint16:
+			return 2, true, nil
+		case types.Int32, types.Uint32, types.Float32:
+			return 4, true, nil
+		case types.Int64, types.Uint64, types.Float64:
+			return 8, true, nil
+		case types.String:
+			if options != nil && options.Length > 0 {
+				return options.Length, true, nil
+			}
+			return 0, false, nil
+		default:
+			return 0, false, fmt.Errorf("Unhandled *types.Basic type %s", x.Name())
+		}
+
+	case *types.Array:
+		n, fixed, err := fixedEncodedSize(x.Elem(), inheritOptions(options, nil))
+		if err != nil || !fixed {
+			return 0, fixed, err
+		}
+		return uint64(x.Len()) * n, true, nil
+
+	case *types.Slice:
+		if options == nil || options.Length == 0 {
+			return 0, false, nil
+		}
+
+		n, fixed, err := fixedEncodedSize(x.Elem(), inheritOptions(options, nil))
+		if err != nil || !fixed {
+			return 0, fixed, err
+		}
+		return options.Length * n, true, nil
+
+	case *types.Map:
+		return 0, false, nil
+
+	case *types.Struct:
+		size := uint64(0)
+		parentOptions := options
+		for i := 0; i < x.NumFields(); i++ {
+			f := x.Field(i)
+			if !f.Exported() {
+				continue
+			}
+
+			ignore, options, err := parseTag(x.Tag(i))
+			if err != nil {
+				return 0, false, err
+			}
+
+			if ignore {
+				continue
+			}
+
+			if options != nil && options.OmitEmpty {
+				return 0, false, nil
+			}
+
+			n, fixed, err := fixedEncodedSize(f.Type(), inheritOptions(parentOptions, options))
+			if err != nil || !fixed {
+				return 0, fixed, err
+			}
+			size += n
+		}
+		return size, true, nil
+
+	default:
+		return 0, false, fmt.Errorf("Unhandled type %T", x)
+	}
+}
+
+// buildCodeSectionSkip returns the code section which skips over an encoded value without decoding it.
+// If validate is true, the section also performs every check that the decoding code performs.
+func buildCodeSectionSkip(t types.Type, varName string, depth int, validate bool, options *Options) (string, error) {
+	if validate && options != nil && options.Const != "" {
+		encoded, _, err := constValue(t, nil, options)
+		if err != nil {
+			return "", err
+		}
+
+		return buildDecodeConst(varName, "", byteLiterals(encoded), len(encoded)), nil
+	}
+
+	// Encoded bools and constants must be validated, so they can't be skipped as part of a fixed size value
+	if size, fixed, err := fixedEncodedSize(t, options); err != nil {
+		return "", err
+	} else if fixed {
+		checked := false
+		if validate {
+			if checked, err = hasCheckedValue(t); err != nil {
+				return "", err
+			}
+		}
+
+		if !checked {
+			return buildSkipFixed(varName, size), nil
+		}
+	}
+
+	elemCounterName := fmt.Sprintf("z%d", depth)
+	elemVarName := fmt.Sprintf("%s[%s]", varName, elemCounterName)
+
+	switch x := t.(type) {
+	case *types.Named:
+		if isRecursive(x) {
+			return buildSkipRecursive(varName, recursiveFuncName("skip", x), options), nil
+		}
+		if isUnion(x) {
+			members, err := findUnion(x, options)
+			if err != nil {
+				return "", err
+			}
+
+			sections := make([]string, len(members))
+			for i, m := range members {
+				section, err := buildCodeSectionSkip(m.t, varName, depth, validate, inheritOptions(options, nil))
+				if err != nil {
+					return "", err
+				}
+
+				sections[i] = section
+			}
+
+			return buildSkipUnion(varName, members, sections), nil
+		}
+		return buildCodeSectionSkip(x.Underlying(), varName, depth, validate, options)
+
+	case *types.Basic:
+		switch x.Kind() {
+		case types.Bool:
+			return buildSkipBool(varName), nil
+		case types.String:
+			return buildSkipBytes(varName, options), nil
+		default:
+			return "", fmt.Errorf("Unhandled *types.Basic type %s for var %s", x.Name(), varName)
+		}
+
+	case *types.Array:
+		elemSection, err := buildCodeSectionSkip(x.Elem(), elemVarName, depth+1, validate, inheritOptions(options, nil))
+		if err != nil {
+			return "", err
+		}
+
+		return buildSkipArray(varName, elemCounterName, elemSection, x.Len()), nil
+
+	case *types.Slice:
+		if isByte(x.Elem()) {
+			return buildSkipBytes(varName, options), nil
+		}
+
+		elemSection, err := buildCodeSectionSkip(x.Elem(), elemVarName, depth+1, validate, inheritOptions(options, nil))
+		if err != nil {
+			return "", err
+		}
+
+		if options != nil && options.Length > 0 {
+			return buildSkipArray(varName, elemCounterName, elemSection, int64(options.Length)), nil
+		}
+
+		elemSize, fixed, err := fixedEncodedSize(x.Elem(), inheritOptions(options, nil))
+		if err != nil {
+			return "", err
+		}
+		if !fixed {
+			elemSize = 0
+		} else if validate {
+			checked, err := hasCheckedValue(x.Elem())
+			if err != nil {
+				return "", err
+			}
+			if checked {
+				elemSize = 0
+			}
+		}
+
+		return buildSkipSlice(varName, elemCounterName, elemSection, elemSize, options), nil
+
+	case *types.Map:
+		keySection, err := buildCodeSectionSkip(x.Key(), fmt.Sprintf("%s key", varName), depth+1, validate, inheritOptions(options, nil))
+		if err != nil {
+			return "", err
+		}
+
+		elemSection, err := buildCodeSectionSkip(x.Elem(), fmt.Sprintf("%s value", varName), depth+1, validate, inheritOptions(options, nil))
+		if err != nil {
+			return "", err
+		}
+
+		if !validate {
+			return buildSkipMap(varName, elemCounterName, keySection, elemSection, options), nil
+		}
+
+		keySetType, setKey, err := encodedKeySet(x.Key(), "key", inheritOptions(options, nil))
+		if err != nil {
+			return "", fmt.Errorf("%v (var=%q)", err, varName)
+		}
+
+		return buildValidateMap(varName, elemCounterName, keySection, elemSection, keySetType, setKey, options), nil
+
+	case *types.Struct:
+		sections := make([]string, x.NumFields())
+		parentOptions := options
+		order, err := encodedFields(x)
+		if err != nil {
+			return "", err
+		}
+
+		for j, i := range order {
+			f := x.Field(i)
+
+			if !f.Exported() {
+				continue
+			}
+
+			ignore, options, err := parseTag(x.Tag(i))
+			if err != nil {
+				return "", err
+			}
+
+			if ignore {
+				continue
+			}
+
+			nextVarName := fmt.Sprintf("%s.%s", varName, f.Name())
+			section, err := buildCodeSectionSkip(f.Type(), nextVarName, depth+1, validate, inheritOptions(parentOptions, options))
+			if err != nil {
+				return "", err
+			}
+
+			sections[j] = section
+		}
+
+		return strings.Join(sections, "\n\n"), nil
+
+	default:
+		return "", fmt.Errorf("Unhandled type %T for var %s", x, varName)
+	}
+}
+
+// encodedKeySet returns the type of a set of the encoded keys of a map, and an expression converting an encoded key
+// to an element of the set, so that elements are equal if and only if Go considers the decoded keys equal.
+// Encoded keys are equal if and only if the decoded keys are equal, except for floats, where 0 == -0 and NaN != NaN,
+// so float keys are decoded to floats, which the set compares like Go map keys.
+func encodedKeySet(t types.Type, key string, options *Options) (string, string, error) {
+	if x, ok := t.Underlying().(*types.Basic); ok {
+		byteOrder := "LittleEndian"
+		if options != nil && options.BigEndian {
+			byteOrder = "BigEndian"
+		}
+
+		switch x.Kind() {
+		case types.Float32:
+			return "map[float32]struct{}", fmt.Sprintf("math.Float32frombits(binary.%s.Uint32(%s))", byteOrder, key), nil
+		case types.Float64:
+			return "map[float64]struct{}", fmt.Sprintf("math.Float64frombits(binary.%s.Uint64(%s))", byteOrder, key), nil
+		}
+	}
+
+	if hasBasicKind(t, types.Float32, types.Float64) {
+		return "", "", errors.New("Validating map keys which contain a float in an array or struct is not supported")
+	}
+
+	return "map[string]struct{}", fmt.Sprintf("string(%s)", key), nil
+}
+
+// hasBasicKind returns true if a type is or contains a basic type of one of the kinds
+func hasBasicKind(t types.Type, kinds ...types.BasicKind) bool {
+	switch x := t.(type) {
+	case *types.Named:
+		return hasBasicKind(x.Underlying(), kinds...)
+	case *types.Basic:
+		for _, k := range kinds {
+			if x.Kind() == k {
+				return true
+			}
+		}
+		return false
+	case *types.Array:
+		return hasBasicKind(x.Elem(), kinds...)
+	case *types.Slice:
+		return hasBasicKind(x.Elem(), kinds...)
+	case *types.Map:
+		return hasBasicKind(x.Key(), kinds...) || hasBasicKind(x.Elem(), kinds...)
+	case *types.Struct:
+		for i := 0; i < x.NumFields(); i++ {
+			f := x.Field(i)
+			if !f.Exported() {
+				continue
+			}
+
+			ignore, _, err := parseTag(x.Tag(i))
+			if err == nil && !ignore && hasBasicKind(f.Type(), kinds...) {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+func buildValidate(s *StructInfo, externalPackage, exported bool) ([]byte, error) {
+	options, err := parseDirectives(s.Directives)
+	if err != nil {
+		return nil, err
+	}
+
+	p := structPackage(s, externalPackage)
+
+	section, err := buildCodeSectionSkip(s.Type, "obj", 0, true, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if c, err := findChecksum(s.Type, options); err != nil {
+		return nil, err
+	} else if c != nil {
+		section += buildDecodeChecksum(c.funcName)
+	}
+
+	funcs, err := buildRecursiveFuncs([]types.Type{s.Type}, p, "skip", false, true, "(uint64, error)", "return 0, nil", func(t types.Type, varName string) (string, error) {
+		return buildCodeSectionSkip(t, varName, 0, true, options)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pkgName := ""
+	if externalPackage {
+		pkgName = s.Package.Name()
+	}
+
+	return wrapValidateFunc(s.Name, pkgName, funcs+section, exported), nil
+}
+
+func buildFormat(s *StructInfo, externalPackage, exported bool) ([]byte, error) {
+	options, err := parseDirectives(s.Directives)
+	if err != nil {
+		return nil, err
+	}
+
+	p := structPackage(s, externalPackage)
+
+	section, err := buildCodeSectionFormat(s.Type, p, "obj", options)
+	if err != nil {
+		return nil, err
+	}
+
+	funcs, err := buildRecursiveFuncs([]types.Type{s.Type}, p, "format", true, false, "", "", func(t types.Type, varName string) (string, error) {
+		return buildCodeSectionFormat(t, p, varName, options)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pkgName := ""
+	if externalPackage {
+		pkgName = s.Package.Name()
+	}
+
+	return wrapFormatFunc(s.Name, pkgName, funcs+section, exported), nil
+}
+
+// buildCodeSectionFormat returns the code section which formats a value for debugging.
+// It formats the same fields that buildCodeSectionEncode encodes, in the same order.
+func buildCodeSectionFormat(t types.Type, p *types.Package, varName string, options *Options) (string, error) {
+	switch x := t.(type) {
+	case *types.Named:
+		if isRecursive(x) {
+			return buildFormatRecursive(varName, recursiveFuncName("format", x)), nil
+		}
+		if isUnion(x) {
+			members, err := findUnion(x, options)
+			if err != nil {
+				return "", err
+			}
+
+			names := make([]string, len(members))
+			sections := make([]string, len(members))
+			for i, m := range members {
+				memberVarName := "x"
+				if m.pointer {
+					memberVarName = "(*x)"
+				}
+
+				section, err := buildCodeSectionFormat(m.t, p, memberVarName, inheritOptions(options, nil))
+				if err != nil {
+					return "", err
+				}
+
+				names[i] = unionMemberName(m, p)
+				sections[i] = section
+			}
+
+			return buildFormatUnion(varName, members, names, sections), nil
+		}
+		return buildCodeSectionFormat(x.Underlying(), p, varName, options)
+
+	case *types.Basic:
+		// Values are converted to their basic type, so that a String method of a named type is not used
+		switch x.Kind() {
+		case types.Bool:
+			return buildFormatBool(varName), nil
+		case types.Int8, types.Int16, types.Int32, types.Int64:
+			return buildFormatInt(varName), nil
+		case types.Uint8, types.Uint16, types.Uint32, types.Uint64:
+			return buildFormatUint(varName), nil
+		case types.Float32:
+			return buildFormatFloat(varName, 32), nil
+		case types.Float64:
+			return buildFormatFloat(varName, 64), nil
+		case types.String:
+			return buildFormatString(varName, options), nil
+		default:
+			return "", fmt.Errorf("Unhandled *types.Basic type %s for var %s", x.Name(), varName)
+		}
+
+	case *types.Array:
+		if isByte(x.Elem()) {
+			return buildFormatByteArray(varName), nil
+		}
+
+		elemSection, err := buildCodeSectionFormat(x.Elem(), p, "x", inheritOptions(options, nil))
+		if err != nil {
+			return "", err
+		}
+
+		return buildFormatArray(varName, "x", elemSection), nil
+
+	case *types.Slice:
+		if isByte(x.Elem()) {
+			return buildFormatByteSlice(varName, options), nil
+		}
+
+		elemSection, err := buildCodeSectionFormat(x.Elem(), p, "x", inheritOptions(options, nil))
+		if err != nil {
+			return "", err
+		}
+
+		return buildFormatSlice(varName, "x", elemSection, options), nil
+
+	case *types.Map:
+		keySection, err := buildCodeSectionFormat(x.Key(), p, "k", inheritOptions(options, nil))
+		if err != nil {
+			return "", err
+		}
+
+		elemSection, err := buildCodeSectionFormat(x.Elem(), p, "v", inheritOptions(options, nil))
+		if err != nil {
+			return "", err
+		}
+
+		return buildFormatMap(varName, "k", "v", keySection, elemSection, options), nil
+
+	case *types.Struct:
+		order, err := encodedFields(x)
+		if err != nil {
+			return "", err
+		}
+
+		var fieldNames, sections []string
+		parentOptions := options
+		for _, i := range order {
+			f := x.Field(i)
+
+			if !f.Exported() {
+				continue
+			}
+
+			ignore, options, err := parseTag(x.Tag(i))
+			if err != nil {
+				return "", err
+			}
+
+			if ignore {
+				continue
+			}
+
+			nextVarName := fmt.Sprintf("%s.%s", varName, f.Name())
+			section, err := buildCodeSectionFormat(f.Type(), p, nextVarName, inheritOptions(parentOptions, options))
+			if err != nil {
+				return "", err
+			}
+
+			fieldNames = append(fieldNames, f.Name())
+			sections = append(sections, section)
+		}
+
+		return buildFormatStruct(fieldNames, sections), nil
+
+	default:
+		return "", fmt.Errorf("Unhandled type %T for var %s", x, varName)
+	}
+}
+
+// formatFieldLabels returns the labels which FormatX writes before the encoded fields of a struct,
+// including those of its nested structs, in encoded order
+func formatFieldLabels(t *types.Struct) ([]string, error) {
+	order, err := encodedFields(t)
+	if err != nil {
+		return nil, err
+	}
+
+	var labels []string
+	for _, i := range order {
+		f := t.Field(i)
+		if !f.Exported() {
+			continue
+		}
+
+		ignore, _, err := parseTag(t.Tag(i))
+		if err != nil {
+			return nil, err
+		}
+
+		if ignore {
+			continue
+		}
+
+		label := " " + f.Name() + ":"
+		if len(labels) == 0 {
+			label = "{" + f.Name() + ":"
+		}
+		labels = append(labels, label)
+
+		if x, ok := f.Type().Underlying().(*types.Struct); ok {
+			nested, err := formatFieldLabels(x)
+			if err != nil {
+				return nil, err
+			}
+			labels = append(labels, nested...)
+		}
+	}
+
+	return labels, nil
+}
+
+// hashTestField is an encoded field of a struct, for the test of the struct's hash function
+type hashTestField struct {
+	name   string
+	hashed bool
+	// sizeFunc computes the encoded size of the field, named encodeSize<Struct><Field>ForHashTest
+	sizeFunc []byte
+}
+
+// buildTestHashFields returns the encoded fields of a struct in encoded order, with the functions computing
+// their encoded sizes, which the test of the hash function uses to cut the nohash fields out of the encoding
+func buildTestHashFields(s *StructInfo, p *types.Package, pkgName string, structOptions *Options) ([]hashTestField, error) {
+	var fields []hashTestField
+	order, err := encodedFields(s.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, i := range order {
+		f := s.Type.Field(i)
+
+		if !f.Exported() {
+			continue
+		}
+
+		ignore, options, err := parseTag(s.Type.Tag(i))
+		if err != nil {
+			return nil, err
+		}
+
+		if ignore {
+			continue
+		}
+
+		hashed := options == nil || !options.NoHash
+		options = inheritOptions(structOptions, options)
+
+		steps, err := buildSizeSteps(f.Type(), p, &ast.SelectorExpr{
+			X:   ast.NewIdent("obj"),
+			Sel: ast.NewIdent(f.Name()),
+		}, 0, options)
+		if err != nil {
+			return nil, err
+		}
+
+		funcs, err := buildSizeFuncs([]types.Type{f.Type()}, p, structOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		funcName := fmt.Sprintf("encodeSize%s%sForHashTest", strings.Title(s.Name), f.Name())
+		src, err := renderSizeFunc(funcName, []*ast.Field{objParam(s.Name, pkgName)}, funcs, steps)
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, hashTestField{
+			name:     f.Name(),
+			hashed:   hashed,
+			sizeFunc: src,
+		})
+	}
+
+	return fields, nil
+}
+
+// noHashFieldNames returns the names of the fields of a struct tagged with nohash
+func noHashFieldNames(t *types.Struct) ([]string, error) {
+	var names []string
+	for i := 0; i < t.NumFields(); i++ {
+		f := t.Field(i)
+		if !f.Exported() {
+			continue
+		}
+
+		ignore, options, err := parseTag(t.Tag(i))
+		if err != nil {
+			return nil, err
+		}
+
+		if !ignore && options != nil && options.NoHash {
+			names = append(names, f.Name())
+		}
+	}
+	return names, nil
+}
+
+// structVersion returns the highest "since" version of a struct's fields, or 0 if the struct is not versioned
+func structVersion(t *types.Struct) (uint64, error) {
+	version := uint64(0)
+	for i := 0; i < t.NumFields(); i++ {
+		f := t.Field(i)
+		if !f.Exported() {
+			continue
+		}
+
+		ignore, options, err := parseTag(t.Tag(i))
+		if err != nil {
+			return 0, err
+		}
+
+		if ignore || options == nil {
+			continue
+		}
+
+		if options.Since > version {
+			version = options.Since
+		}
+	}
+	return version, nil
+}
+
+func buildCodeSectionEncode(t types.Type, p *types.Package, varName string, castType, isTopLevel, bulk bool, options *Options) (string, error) {
+	// castType applies to basic int types; if true, an additional cast will be made in the generated code.
+	// This is to convert types like "type Foo int8" back to int8
+	// bulk writes numeric arrays and slices directly to e.Buffer, so it can't be used with runtime.HashEncoder
+
+	debugPrintf("buildCodeSectionEncode type=%T varName=%s castType=%v bulk=%v options=%+v\n", t, varName, castType, bulk, options)
+
+	if options != nil {
+		if options.OmitEmpty && !omitEmptyIsValid(t) {
+			return "", errors.New("omitempty is only valid for array, slice, map and string")
+		}
+		if options.Length != 0 && !lenIsValid(t) {
+			return "", errors.New("len is only valid for slice and string")
+		}
+		if options.Length != 0 && (options.MaxLength != 0 || options.OmitEmpty) {
+			return "", errors.New("len cannot be combined with maxlen or omitempty")
+		}
+	}
+
+	if options != nil && options.Const != "" {
+		encoded, value, err := constValue(t, p, options)
+		if err != nil {
+			return "", err
+		}
+
+		return buildEncodeConst(varName, constZero(t, p), value, byteLiterals(encoded)), nil
+	}
+
+	switch x := t.(type) {
+	case *types.Named:
+		if isRecursive(x) {
+			return buildEncodeRecursive(varName, recursiveFuncName("encode", x)), nil
+		}
+		if isUnion(x) {
+			members, err := findUnion(x, options)
+			if err != nil {
+				return "", err
+			}
+
+			names := make([]string, len(members))
+			sections := make([]string, len(members))
+			for i, m := range members {
+				memberVarName := "x"
+				if m.pointer {
+					memberVarName = "(*x)"
+				}
+
+				section, err := buildCodeSectionEncode(m.t, p, memberVarName, false, false, bulk, inheritOptions(options, nil))
+				if err != nil {
+					return "", err
+				}
+
+				names[i] = unionMemberName(m, p)
+				sections[i] = section
+			}
+
+			return buildEncodeUnion(varName, members, names, sections), nil
+		}
+		return buildCodeSectionEncode(x.Underlying(), p, varName, true, false, bulk, options)
+
+	case *types.Basic:
+		switch x.Kind() {
+		case types.Bool:
+			return buildEncodeBool(varName, castType, options), nil
+		case types.Int8:
+			return buildEncodeInt8(varName, castType, options), nil
 		case types.Int16:
 			return buildEncodeInt16(varName, castType, options), nil
 		case types.Int32:
@@ -357,7 +1980,13 @@ func buildCodeSectionEncode(t types.Type, varName string, castType, isTopLevel b
 			return buildEncodeByteArray(varName, options), nil
 		}
 
-		elemSection, err := buildCodeSectionEncode(elem, "x", false, false, nil)
+		if bulk {
+			if section, ok := encodeBulkSection(fmt.Sprintf("%s[:]", varName), elem, inheritOptions(options, nil)); ok {
+				return fmt.Sprintf("\n// %s\n%s", varName, section), nil
+			}
+		}
+
+		elemSection, err := buildCodeSectionEncode(elem, p, "x", false, false, bulk, inheritOptions(options, nil))
 		if err != nil {
 			return "", err
 		}
@@ -377,7 +2006,13 @@ func buildCodeSectionEncode(t types.Type, varName string, castType, isTopLevel b
 			return buildEncodeByteSlice(varName, options), nil
 		}
 
-		elemSection, err := buildCodeSectionEncode(elem, "x", false, false, nil)
+		if bulk {
+			if section, ok := encodeBulkSection(varName, elem, inheritOptions(options, nil)); ok {
+				return buildEncodeBulkSlice(varName, section, options), nil
+			}
+		}
+
+		elemSection, err := buildCodeSectionEncode(elem, p, "x", false, false, bulk, inheritOptions(options, nil))
 		if err != nil {
 			return "", err
 		}
@@ -385,12 +2020,12 @@ func buildCodeSectionEncode(t types.Type, varName string, castType, isTopLevel b
 		return buildEncodeSlice(varName, "x", elemSection, options), nil
 
 	case *types.Map:
-		keySection, err := buildCodeSectionEncode(x.Key(), "k", false, false, nil)
+		keySection, err := buildCodeSectionEncode(x.Key(), p, "k", false, false, bulk, inheritOptions(options, nil))
 		if err != nil {
 			return "", err
 		}
 
-		elemSection, err := buildCodeSectionEncode(x.Elem(), "v", false, false, nil)
+		elemSection, err := buildCodeSectionEncode(x.Elem(), p, "v", false, false, bulk, inheritOptions(options, nil))
 		if err != nil {
 			return "", err
 		}
@@ -399,7 +2034,15 @@ func buildCodeSectionEncode(t types.Type, varName string, castType, isTopLevel b
 
 	case *types.Struct:
 		sections := make([]string, x.NumFields())
-		for i := 0; i < x.NumFields(); i++ {
+		leaves := make([][]fixedLeaf, x.NumFields())
+		since := uint64(0)
+		parentOptions := options
+		order, err := encodedFields(x)
+		if err != nil {
+			return "", err
+		}
+
+		for j, i := range order {
 			f := x.Field(i)
 
 			if !f.Exported() {
@@ -415,11 +2058,13 @@ func buildCodeSectionEncode(t types.Type, varName string, castType, isTopLevel b
 				continue
 			}
 
+			options = inheritOptions(parentOptions, options)
+
 			// NOTES ON OMITEMPTY
 			// - Must be last field in struct
 			// - Only applies to arrays, slices, maps and string
 			if options != nil && options.OmitEmpty {
-				if i != x.NumFields()-1 {
+				if j != len(order)-1 {
 					return "", errors.New("omitempty option can only be used on the last field in a struct")
 				}
 				if !isTopLevel {
@@ -427,172 +2072,217 @@ func buildCodeSectionEncode(t types.Type, varName string, castType, isTopLevel b
 				}
 			}
 
+			// NOTES ON SINCE
+			// - Only applies to fields of a top-level struct
+			// - Versioned fields must be trailing, in non-decreasing version order
+			fieldSince := uint64(0)
+			if options != nil {
+				fieldSince = options.Since
+			}
+			if fieldSince != 0 && !isTopLevel {
+				return "", errors.New("since option can only be used on a top-level struct")
+			}
+			if fieldSince < since {
+				return "", errors.New("since option fields must be the trailing fields of a struct, in non-decreasing version order")
+			}
+			since = fieldSince
+
+			// NOTES ON NOHASH
+			// - Only applies to fields of a top-level struct
+			if options != nil && options.NoHash && !isTopLevel {
+				return "", errors.New("nohash option can only be used on a top-level struct")
+			}
+
 			nextVarName := fmt.Sprintf("%s.%s", varName, f.Name())
-			section, err := buildCodeSectionEncode(f.Type(), nextVarName, false, false, options)
+			section, err := buildCodeSectionEncode(f.Type(), p, nextVarName, false, false, bulk, options)
 			if err != nil {
 				return "", err
 			}
 
-			sections[i] = section
+			sections[j] = section
+			if bulk {
+				leaves[j] = fixedLeaves(f.Type(), nil, nextVarName, false, "", options)
+			}
 		}
 
-		return strings.Join(sections, "\n\n"), nil
+		return renderCodeSteps(optimizeRuns(structSteps(sections, leaves, func(leaves []fixedLeaf, section string) codeStep {
+			return encodeLeaves{leaves: leaves, section: section}
+		})))
 
 	default:
 		return "", fmt.Errorf("Unhandled type %T for var %s", x, varName)
 	}
 }
 
-// buildCodeSectionEncodeSize returns the code section and whether or not the section has dynamic sizing (requiring a runtime len() check)
-func buildCodeSectionEncodeSize(t types.Type, varName, baseCounterName string, depth int, options *Options) (string, bool, error) {
-	debugPrintf("buildCodeSectionEncodeSize type=%T varName=%s baseCounterName=%s depth=%d options=%+v\n", t, varName, baseCounterName, depth, options)
-
-	if options != nil {
-		if options.OmitEmpty && !omitEmptyIsValid(t) {
-			return "", false, errors.New("omitempty is only valid for array, slice, map and string")
-		}
-	}
-
-	counterName := fmt.Sprintf("%s%d", baseCounterName, depth)
-
-	switch x := t.(type) {
-	case *types.Named:
-		return buildCodeSectionEncodeSize(x.Underlying(), varName, baseCounterName, depth, options)
-
-	case *types.Basic:
-		switch x.Kind() {
-		case types.Bool:
-			return buildEncodeSizeBool(varName, counterName, options), false, nil
-		case types.Int8:
-			return buildEncodeSizeInt8(varName, counterName, options), false, nil
-		case types.Int16:
-			return buildEncodeSizeInt16(varName, counterName, options), false, nil
-		case types.Int32:
-			return buildEncodeSizeInt32(varName, counterName, options), false, nil
-		case types.Int64:
-			return buildEncodeSizeInt64(varName, counterName, options), false, nil
-		case types.Uint8:
-			return buildEncodeSizeUint8(varName, counterName, options), false, nil
-		case types.Uint16:
-			return buildEncodeSizeUint16(varName, counterName, options), false, nil
-		case types.Uint32:
-			return buildEncodeSizeUint32(varName, counterName, options), false, nil
-		case types.Uint64:
-			return buildEncodeSizeUint64(varName, counterName, options), false, nil
-		case types.Float32:
-			return buildEncodeSizeFloat32(varName, counterName, options), false, nil
-		case types.Float64:
-			return buildEncodeSizeFloat64(varName, counterName, options), false, nil
-		case types.String:
-			return buildEncodeSizeString(varName, counterName, options), true, nil
-		default:
-			return "", false, fmt.Errorf("Unhandled *types.Basic type %q for var %q", x.Name(), varName)
-		}
-
-	case *types.Array:
-		elem := x.Elem()
+// encodeBulkSection returns the code section which writes the elements of a numeric slice, or of a slice
+// of byte arrays, to the encoder's buffer at once, instead of with an encoder method call per element.
+// Returns false if the elements can't be written in bulk. The runtime functions take slices of the basic
+// numeric types, so the elements of a named numeric type are written one at a time.
+func encodeBulkSection(slice string, elem types.Type, options *Options) (string, bool) {
+	if x, ok := elem.Underlying().(*types.Array); ok && isByte(x.Elem()) {
+		return buildEncodeBulkByteArrays(slice, x.Len()), true
+	}
 
-		if isByte(elem) {
-			return buildEncodeSizeByteArray(varName, counterName, x.Len(), options), false, nil
-		}
+	x, ok := elem.(*types.Basic)
+	if !ok {
+		return "", false
+	}
 
-		nextCounterName := fmt.Sprintf("%s%d", baseCounterName, depth+1)
-		xVarName := fmt.Sprintf("x%d", depth+1)
-		elemSection, isDynamic, err := buildCodeSectionEncodeSize(elem, xVarName, baseCounterName, depth+1, nil)
-		if err != nil {
-			return "", false, err
-		}
+	var put string
+	var size int
+	switch x.Kind() {
+	case types.Int16:
+		put, size = "PutInt16s", 2
+	case types.Uint16:
+		put, size = "PutUint16s", 2
+	case types.Int32:
+		put, size = "PutInt32s", 4
+	case types.Uint32:
+		put, size = "PutUint32s", 4
+	case types.Int64:
+		put, size = "PutInt64s", 8
+	case types.Uint64:
+		put, size = "PutUint64s", 8
+	case types.Float32:
+		put, size = "PutFloat32s", 4
+	case types.Float64:
+		put, size = "PutFloat64s", 8
+	default:
+		return "", false
+	}
 
-		return buildEncodeSizeArray(varName, counterName, nextCounterName, xVarName, elemSection, x.Len(), isDynamic, options), isDynamic, nil
+	if options != nil && options.BigEndian {
+		put += "BigEndian"
+	}
 
-	case *types.Slice:
-		elem := x.Elem()
+	return buildEncodeBulk(slice, put, size), true
+}
 
-		if empty, err := isEmptyStruct(elem); err != nil {
-			return "", false, err
-		} else if empty {
-			return "", false, fmt.Errorf("A slice of an empty encoded struct is not allowed (var=%q)", varName)
-		}
+// fixedLeaf is a number or a byte array in a run of adjacent fixed size struct fields,
+// which are decoded and encoded with a single buffer length check
+type fixedLeaf struct {
+	varName   string
+	kind      types.BasicKind // types.Invalid for a byte array
+	size      int64
+	castType  bool
+	typeName  string
+	bigEndian bool
+}
 
-		if isByte(elem) {
-			return buildEncodeSizeByteSlice(varName, counterName, options), true, nil
-		}
+// fixedLeaves returns the numbers and byte arrays which make up a value of type t, in encoding order.
+// Returns nil if the value has a variable size, or contains a bool, which is validated when decoded.
+// Values with options other than the byte order are excluded too, so that their options are still
+// checked when building their own code section.
+func fixedLeaves(t types.Type, p *types.Package, varName string, castType bool, typeName string, options *Options) []fixedLeaf {
+	if options != nil && *options != (Options{BigEndian: options.BigEndian, MaxDepth: options.MaxDepth, Unions: options.Unions, Order: options.Order, Checksum: options.Checksum}) {
+		return nil
+	}
 
-		nextCounterName := fmt.Sprintf("%s%d", baseCounterName, depth+1)
-		xVarName := fmt.Sprintf("x%d", depth+1)
-		elemSection, isDynamic, err := buildCodeSectionEncodeSize(elem, xVarName, baseCounterName, depth+1, nil)
-		if err != nil {
-			return "", false, err
+	switch x := t.(type) {
+	case *types.Named:
+		return fixedLeaves(x.Underlying(), p, varName, true, typeNameOf(x, p), options)
+
+	case *types.Basic:
+		var size int64
+		switch x.Kind() {
+		case types.Int8, types.Uint8:
+			size = 1
+		case types.Int16, types.Uint16:
+			size = 2
+		case types.Int32, types.Uint32, types.Float32:
+			size = 4
+		case types.Int64, types.Uint64, types.Float64:
+			size = 8
+		default:
+			return nil
 		}
 
-		return buildEncodeSizeSlice(varName, counterName, nextCounterName, xVarName, elemSection, isDynamic, options), true, nil
+		if typeName == "" {
+			typeName = typeNameOf(x, p)
+		}
 
-	case *types.Map:
-		nextCounterName := fmt.Sprintf("%s%d", baseCounterName, depth+1)
-		kVarName := fmt.Sprintf("k%d", depth+1)
-		vVarName := fmt.Sprintf("v%d", depth+1)
+		return []fixedLeaf{{
+			varName:   varName,
+			kind:      x.Kind(),
+			size:      size,
+			castType:  castType,
+			typeName:  typeName,
+			bigEndian: options != nil && options.BigEndian,
+		}}
 
-		keySection, isDynamicKey, err := buildCodeSectionEncodeSize(x.Key(), kVarName, baseCounterName, depth+1, nil)
-		if err != nil {
-			return "", false, err
+	case *types.Array:
+		if !isByte(x.Elem()) {
+			return nil
 		}
 
-		elemSection, isDynamicElem, err := buildCodeSectionEncodeSize(x.Elem(), vVarName, baseCounterName, depth+1, nil)
+		return []fixedLeaf{{
+			varName: varName,
+			kind:    types.Invalid,
+			size:    x.Len(),
+		}}
+
+	case *types.Struct:
+		var leaves []fixedLeaf
+		order, err := encodedFields(x)
 		if err != nil {
-			return "", false, err
+			return nil
 		}
 
-		return buildEncodeSizeMap(varName, counterName, nextCounterName, kVarName, vVarName, keySection, elemSection, isDynamicKey, isDynamicElem, options), true, nil
-
-	case *types.Struct:
-		isDynamic := false
-		sections := make([]string, x.NumFields())
-		for i := 0; i < x.NumFields(); i++ {
+		for _, i := range order {
 			f := x.Field(i)
 
 			if !f.Exported() {
 				continue
 			}
 
-			ignore, options, err := parseTag(x.Tag(i))
+			ignore, fieldOptions, err := parseTag(x.Tag(i))
 			if err != nil {
-				return "", false, err
+				return nil
 			}
 
 			if ignore {
 				continue
 			}
 
-			// NOTES ON OMITEMPTY
-			// - Must be last field in struct
-			// - Only applies to arrays, slices, maps and string
-			if options != nil && options.OmitEmpty && i != x.NumFields()-1 {
-				return "", false, errors.New("omitempty option can only be used on the last field in a struct")
-			}
-
 			nextVarName := fmt.Sprintf("%s.%s", varName, f.Name())
-			section, sectionIsDynamic, err := buildCodeSectionEncodeSize(f.Type(), nextVarName, baseCounterName, depth, options)
-			if err != nil {
-				return "", false, err
-			}
-
-			if sectionIsDynamic {
-				isDynamic = true
+			fieldLeaves := fixedLeaves(f.Type(), p, nextVarName, false, "", inheritOptions(options, fieldOptions))
+			if fieldLeaves == nil {
+				return nil
 			}
 
-			sections[i] = section
+			leaves = append(leaves, fieldLeaves...)
 		}
 
-		return strings.Join(sections, "\n\n"), isDynamic, nil
+		return leaves
 
 	default:
-		return "", false, fmt.Errorf("Unhandled type %T for var %s", x, varName)
+		return nil
+	}
+}
+
+// structSteps returns the code steps of the sections of the fields of a struct.
+// The fields with fixed size leaves are leaf steps built by newLeafStep, which optimizeRuns merges into runs.
+func structSteps(sections []string, leaves [][]fixedLeaf, newLeafStep func(leaves []fixedLeaf, section string) codeStep) []codeStep {
+	var steps []codeStep
+	for i, section := range sections {
+		if section == "" {
+			continue
+		}
+
+		if leaves[i] == nil {
+			steps = append(steps, codeSection{src: section})
+		} else {
+			steps = append(steps, newLeafStep(leaves[i], section))
+		}
 	}
+
+	return steps
 }
 
-func buildCodeSectionDecode(t types.Type, p *types.Package, varName string, castType bool, typeName string, depth int, options *Options) (string, error) {
+func buildCodeSectionDecode(t types.Type, p *types.Package, varName string, castType bool, typeName string, depth int, reuse bool, options *Options) (string, error) {
 	// castType applies to basic int types; if true, an additional cast will be made in the generated code.
 	// This is to convert types like "type Foo int8" back to int8
+	// reuse decodes into the existing slices, maps and strings of the object, instead of allocating new ones
 
 	pkgName := ""
 	if p != nil {
@@ -606,9 +2296,42 @@ func buildCodeSectionDecode(t types.Type, p *types.Package, varName string, cast
 		}
 	}
 
+	if options != nil && options.Const != "" {
+		encoded, value, err := constValue(t, p, options)
+		if err != nil {
+			return "", err
+		}
+
+		return buildDecodeConst(varName, value, byteLiterals(encoded), len(encoded)), nil
+	}
+
 	switch x := t.(type) {
 	case *types.Named:
-		return buildCodeSectionDecode(x.Underlying(), p, varName, true, typeNameOf(x, p), depth, options)
+		if isRecursive(x) {
+			return buildDecodeRecursive(varName, recursiveFuncName("decode", x), options), nil
+		}
+		if isUnion(x) {
+			members, err := findUnion(x, options)
+			if err != nil {
+				return "", err
+			}
+
+			memberVarName := fmt.Sprintf("u%d", depth+1)
+			typeNames := make([]string, len(members))
+			sections := make([]string, len(members))
+			for i, m := range members {
+				section, err := buildCodeSectionDecode(m.t, p, memberVarName, false, "", depth+1, reuse, inheritOptions(options, nil))
+				if err != nil {
+					return "", err
+				}
+
+				typeNames[i] = typeNameOf(m.t, p)
+				sections[i] = section
+			}
+
+			return buildDecodeUnion(varName, memberVarName, members, typeNames, sections), nil
+		}
+		return buildCodeSectionDecode(x.Underlying(), p, varName, true, typeNameOf(x, p), depth, reuse, options)
 
 	case *types.Basic:
 		if typeName == "" {
@@ -641,7 +2364,7 @@ func buildCodeSectionDecode(t types.Type, p *types.Package, varName string, cast
 		case types.Float64:
 			return buildDecodeFloat64(varName, castType, typeName, options), nil
 		case types.String:
-			return buildDecodeString(varName, options), nil
+			return buildDecodeString(varName, reuse, options), nil
 		default:
 			return "", fmt.Errorf("Unhandled *types.Basic type %s for var %s", x.Name(), varName)
 		}
@@ -655,7 +2378,7 @@ func buildCodeSectionDecode(t types.Type, p *types.Package, varName string, cast
 
 		elemCounterName := fmt.Sprintf("z%d", depth)
 		elemVarName := fmt.Sprintf("%s[%s]", varName, elemCounterName)
-		elemSection, err := buildCodeSectionDecode(elem, p, elemVarName, false, "", depth+1, nil)
+		elemSection, err := buildCodeSectionDecode(elem, p, elemVarName, false, "", depth+1, reuse, inheritOptions(options, nil))
 		if err != nil {
 			return "", err
 		}
@@ -672,38 +2395,45 @@ func buildCodeSectionDecode(t types.Type, p *types.Package, varName string, cast
 		}
 
 		if isByte(elem) {
-			return buildDecodeByteSlice(varName, options), nil
+			return buildDecodeByteSlice(varName, reuse, options), nil
 		}
 
 		elemCounterName := fmt.Sprintf("z%d", depth)
 		elemVarName := fmt.Sprintf("%s[%s]", varName, elemCounterName)
-		elemSection, err := buildCodeSectionDecode(elem, p, elemVarName, false, "", depth+1, nil)
+		elemSection, err := buildCodeSectionDecode(elem, p, elemVarName, false, "", depth+1, reuse, inheritOptions(options, nil))
 		if err != nil {
 			return "", err
 		}
 
-		return buildDecodeSlice(varName, elemCounterName, elemVarName, elemSection, sliceTypeName(x, p), options), nil
+		return buildDecodeSlice(varName, elemCounterName, elemVarName, elemSection, sliceTypeName(x, p), reuse, options), nil
 
 	case *types.Map:
 		keyVarName := fmt.Sprintf("k%d", depth)
-		keySection, err := buildCodeSectionDecode(x.Key(), p, keyVarName, false, "", depth+1, nil)
+		keySection, err := buildCodeSectionDecode(x.Key(), p, keyVarName, false, "", depth+1, reuse, inheritOptions(options, nil))
 		if err != nil {
 			return "", err
 		}
 		keyType := typeNameOf(x.Key(), p)
 
 		elemVarName := fmt.Sprintf("v%d", depth)
-		elemSection, err := buildCodeSectionDecode(x.Elem(), p, elemVarName, false, "", depth+1, nil)
+		elemSection, err := buildCodeSectionDecode(x.Elem(), p, elemVarName, false, "", depth+1, reuse, inheritOptions(options, nil))
 		if err != nil {
 			return "", err
 		}
 		elemType := typeNameOf(x.Elem(), p)
 
-		return buildDecodeMap(varName, keyVarName, elemVarName, keyType, elemType, keySection, elemSection, mapTypeName(x, p), options), nil
+		return buildDecodeMap(varName, keyVarName, elemVarName, keyType, elemType, keySection, elemSection, mapTypeName(x, p), reuse, options), nil
 
 	case *types.Struct:
 		sections := make([]string, x.NumFields())
-		for i := 0; i < x.NumFields(); i++ {
+		leaves := make([][]fixedLeaf, x.NumFields())
+		parentOptions := options
+		order, err := encodedFields(x)
+		if err != nil {
+			return "", err
+		}
+
+		for j, i := range order {
 			f := x.Field(i)
 
 			if !f.Exported() {
@@ -719,16 +2449,42 @@ func buildCodeSectionDecode(t types.Type, p *types.Package, varName string, cast
 				continue
 			}
 
+			if options != nil && (options.MaxDepth != 0 || options.BigEndian || options.Length != 0) {
+				rts, err := recursiveTypes(f.Type())
+				if err != nil {
+					return "", err
+				}
+
+				if options.MaxDepth != 0 && len(rts) == 0 {
+					return "", fmt.Errorf("maxdepth is only valid for fields containing a recursive type (field %s)", f.Name())
+				}
+
+				// Recursive types are decoded by function literals shared by all fields, using the struct's byte order
+				if options.BigEndian && len(rts) != 0 {
+					return "", fmt.Errorf("be is not valid for fields containing a recursive type (field %s)", f.Name())
+				}
+
+				// A fixed length may not end the recursion, so that values could not be finite
+				if options.Length != 0 && len(rts) != 0 {
+					return "", fmt.Errorf("len is not valid for fields containing a recursive type (field %s)", f.Name())
+				}
+			}
+
+			options = inheritOptions(parentOptions, options)
+
 			nextVarName := fmt.Sprintf("%s.%s", varName, f.Name())
-			section, err := buildCodeSectionDecode(f.Type(), p, nextVarName, false, "", depth+1, options)
+			section, err := buildCodeSectionDecode(f.Type(), p, nextVarName, false, "", depth+1, reuse, options)
 			if err != nil {
 				return "", err
 			}
 
-			sections[i] = section
+			sections[j] = section
+			leaves[j] = fixedLeaves(f.Type(), p, nextVarName, false, "", options)
 		}
 
-		return strings.Join(sections, "\n\n"), nil
+		return renderCodeSteps(optimizeRuns(structSteps(sections, leaves, func(leaves []fixedLeaf, section string) codeStep {
+			return decodeLeaves{leaves: leaves, section: section}
+		})))
 
 	default:
 		return "", fmt.Errorf("Unhandled type %T for var %s", x, varName)
@@ -750,25 +2506,390 @@ func isEmptyStruct(t types.Type) (bool, error) {
 	}
 }
 
-// structHasEncodableFields returns true if a struct has an exported field that is not disabled from encoding by a struct tag
-func structHasEncodableFields(t *types.Struct) (bool, error) {
-	has := false
-	for i := 0; i < t.NumFields(); i++ {
-		f := t.Field(i)
-		if !f.Exported() {
-			continue
-		}
+// structHasEncodableFields returns true if a struct has an exported field that is not disabled from encoding by a struct tag
+func structHasEncodableFields(t *types.Struct) (bool, error) {
+	has := false
+	for i := 0; i < t.NumFields(); i++ {
+		f := t.Field(i)
+		if !f.Exported() {
+			continue
+		}
+
+		ignore, _, err := parseTag(t.Tag(i))
+		if err != nil {
+			return false, err
+		}
+
+		if !ignore {
+			has = true
+		}
+	}
+	return has, nil
+}
+
+// isRecursive returns true if a named type contains itself, through a slice or a map.
+// Recursive types are encoded and decoded by function literals which call themselves, instead of inline.
+func isRecursive(t *types.Named) bool {
+	return containsNamed(t.Underlying(), t, make(map[*types.Named]bool))
+}
+
+// containsNamed returns true if the encoded fields of a type contain the named type target.
+// Named types in seen are not walked again.
+func containsNamed(t types.Type, target *types.Named, seen map[*types.Named]bool) bool {
+	switch x := t.(type) {
+	case *types.Named:
+		if x == target {
+			return true
+		}
+		if seen[x] {
+			return false
+		}
+		seen[x] = true
+		return containsNamed(x.Underlying(), target, seen)
+
+	case *types.Array:
+		return containsNamed(x.Elem(), target, seen)
+
+	case *types.Slice:
+		return containsNamed(x.Elem(), target, seen)
+
+	case *types.Map:
+		return containsNamed(x.Key(), target, seen) || containsNamed(x.Elem(), target, seen)
+
+	case *types.Struct:
+		for i := 0; i < x.NumFields(); i++ {
+			f := x.Field(i)
+			if !f.Exported() {
+				continue
+			}
+
+			ignore, _, err := parseTag(x.Tag(i))
+			if err == nil && !ignore && containsNamed(f.Type(), target, seen) {
+				return true
+			}
+		}
+		return false
+
+	default:
+		return false
+	}
+}
+
+// recursiveTypes returns the recursive types contained in the encoded fields of types, in the order they are found
+func recursiveTypes(ts ...types.Type) ([]*types.Named, error) {
+	var found []*types.Named
+	seen := make(map[*types.Named]bool)
+	names := make(map[string]*types.Named)
+
+	var walk func(t types.Type) error
+	walk = func(t types.Type) error {
+		switch x := t.(type) {
+		case *types.Named:
+			if seen[x] {
+				return nil
+			}
+			seen[x] = true
+
+			if isRecursive(x) {
+				// Tag options of a field apply to the value encoded in the field, which would be lost
+				// when calling the function literal of a recursive type which is not a struct
+				if _, ok := x.Underlying().(*types.Struct); !ok {
+					return fmt.Errorf("Recursive type %s must be a struct", x)
+				}
+
+				// The function literals of recursive types are named after the types
+				name := x.Obj().Name()
+				if other, ok := names[name]; ok {
+					return fmt.Errorf("Recursive types %s and %s have the same name", other, x)
+				}
+				names[name] = x
+				found = append(found, x)
+			}
+
+			return walk(x.Underlying())
+
+		case *types.Array:
+			return walk(x.Elem())
+
+		case *types.Slice:
+			return walk(x.Elem())
+
+		case *types.Map:
+			if err := walk(x.Key()); err != nil {
+				return err
+			}
+			return walk(x.Elem())
+
+		case *types.Struct:
+			for i := 0; i < x.NumFields(); i++ {
+				f := x.Field(i)
+				if !f.Exported() {
+					continue
+				}
+
+				ignore, _, err := parseTag(x.Tag(i))
+				if err != nil {
+					return err
+				}
+
+				if ignore {
+					continue
+				}
+
+				if err := walk(f.Type()); err != nil {
+					return err
+				}
+			}
+			return nil
+
+		default:
+			return nil
+		}
+	}
+
+	for _, t := range ts {
+		if err := walk(t); err != nil {
+			return nil, err
+		}
+	}
+
+	return found, nil
+}
+
+// isUnion returns true if a named type is an interface type.
+// Values of interface types are encoded as a tag byte followed by the value of a concrete type,
+// with the concrete types and their tags registered by a union directive of the struct.
+func isUnion(t *types.Named) bool {
+	_, ok := t.Underlying().(*types.Interface)
+	return ok
+}
+
+// unionMember is a concrete type of a union, and the tag byte which its values are encoded with
+type unionMember struct {
+	tag     uint8
+	t       *types.Named
+	pointer bool
+}
+
+// findUnion returns the members of the union of an interface type, registered by a union directive in options
+func findUnion(t *types.Named, options *Options) ([]unionMember, error) {
+	var members []UnionMember
+	if options != nil && options.Unions != nil {
+		members = (*options.Unions)[t.Obj().Name()]
+	}
+	if len(members) == 0 || t.Obj().Pkg() == nil {
+		return nil, fmt.Errorf("Interface type %s has no union directive", t)
+	}
+
+	iface := t.Underlying().(*types.Interface)
+	found := make([]unionMember, len(members))
+	for i, m := range members {
+		name := strings.TrimPrefix(m.Type, "*")
+		obj, ok := t.Obj().Pkg().Scope().Lookup(name).(*types.TypeName)
+		if !ok {
+			return nil, fmt.Errorf("Union type %s of interface type %s not found", m.Type, t)
+		}
+
+		mt, ok := obj.Type().(*types.Named)
+		if !ok || isUnion(mt) {
+			return nil, fmt.Errorf("Union type %s of interface type %s must be a named type which is not an interface", m.Type, t)
+		}
+
+		pointer := name != m.Type
+		var impl types.Type = mt
+		if pointer {
+			impl = types.NewPointer(mt)
+		}
+		if !types.Implements(impl, iface) {
+			return nil, fmt.Errorf("Union type %s does not implement interface type %s", m.Type, t)
+		}
+
+		// The members are encoded inline, so they can't contain the function literals of recursive types or other unions
+		rts, err := recursiveTypes(mt)
+		if err != nil {
+			return nil, err
+		}
+		uts, err := unionTypes(mt)
+		if err != nil {
+			return nil, err
+		}
+		if len(rts) != 0 || len(uts) != 0 {
+			return nil, fmt.Errorf("Union type %s of interface type %s must not contain recursive types or interface types", m.Type, t)
+		}
+
+		found[i] = unionMember{
+			tag:     m.Tag,
+			t:       mt,
+			pointer: pointer,
+		}
+	}
+
+	return found, nil
+}
+
+// unionMemberName returns the name of the type of a union member in the generated code
+func unionMemberName(m unionMember, p *types.Package) string {
+	name := typeNameOf(m.t, p)
+	if m.pointer {
+		return "*" + name
+	}
+	return name
+}
+
+// unionTypes returns the interface types contained in the encoded fields of types, in the order they are found.
+// The members of the unions are not walked.
+func unionTypes(ts ...types.Type) ([]*types.Named, error) {
+	var found []*types.Named
+	seen := make(map[*types.Named]bool)
+
+	var walk func(t types.Type) error
+	walk = func(t types.Type) error {
+		switch x := t.(type) {
+		case *types.Named:
+			if seen[x] {
+				return nil
+			}
+			seen[x] = true
+
+			if isUnion(x) {
+				found = append(found, x)
+				return nil
+			}
+
+			return walk(x.Underlying())
+
+		case *types.Array:
+			return walk(x.Elem())
+
+		case *types.Slice:
+			return walk(x.Elem())
+
+		case *types.Map:
+			if err := walk(x.Key()); err != nil {
+				return err
+			}
+			return walk(x.Elem())
+
+		case *types.Struct:
+			for i := 0; i < x.NumFields(); i++ {
+				f := x.Field(i)
+				if !f.Exported() {
+					continue
+				}
+
+				ignore, _, err := parseTag(x.Tag(i))
+				if err != nil {
+					return err
+				}
+
+				if ignore {
+					continue
+				}
+
+				if err := walk(f.Type()); err != nil {
+					return err
+				}
+			}
+			return nil
+
+		default:
+			return nil
+		}
+	}
+
+	for _, t := range ts {
+		if err := walk(t); err != nil {
+			return nil, err
+		}
+	}
+
+	return found, nil
+}
+
+// unionMemberTypes returns the member types of the unions contained in the encoded fields of types,
+// so that checks of the encoded fields of a type can be applied to the members of its unions too
+func unionMemberTypes(options *Options, ts ...types.Type) ([]types.Type, error) {
+	uts, err := unionTypes(ts...)
+	if err != nil {
+		return nil, err
+	}
+
+	var memberTypes []types.Type
+	for _, ut := range uts {
+		members, err := findUnion(ut, options)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, m := range members {
+			memberTypes = append(memberTypes, m.t)
+		}
+	}
+
+	return memberTypes, nil
+}
+
+// recursiveFuncName returns the name of the function literal which encodes, decodes, etc. a recursive type
+func recursiveFuncName(prefix string, t *types.Named) string {
+	return prefix + t.Obj().Name()
+}
+
+// recursiveOptions returns the options which the function literal of a recursive type is built with,
+// from the options of a value of the type. Only the struct's byte order and unions apply to all values of the type.
+func recursiveOptions(options *Options) *Options {
+	if options == nil || (!options.BigEndian && options.Unions == nil) {
+		return nil
+	}
+	return &Options{BigEndian: options.BigEndian, Unions: options.Unions}
+}
+
+// structPackage returns the package of a struct, or nil if the generated code is in another package
+func structPackage(s *StructInfo, externalPackage bool) *types.Package {
+	if externalPackage {
+		return nil
+	}
+	return s.Package
+}
+
+// buildRecursiveFuncs returns the code section declaring the function literals of the recursive types contained in
+// the encoded fields of types, for a generated function whose code sections call them.
+// The body of each literal is built by build from the type's underlying struct, named obj.
+// If obj is true, the literals take a pointer to the value. If depth is true, they take the depth of the value
+// in the recursive types, to check the maxdepth options, which is 0 outside of the literals.
+// The literals return results, with the final return statement ret.
+func buildRecursiveFuncs(ts []types.Type, p *types.Package, prefix string, obj, depth bool, results, ret string, build func(t types.Type, varName string) (string, error)) (string, error) {
+	rts, err := recursiveTypes(ts...)
+	if err != nil {
+		return "", err
+	}
 
-		ignore, _, err := parseTag(t.Tag(i))
+	if len(rts) == 0 {
+		return "", nil
+	}
+
+	names := make([]string, len(rts))
+	signatures := make([]string, len(rts))
+	bodies := make([]string, len(rts))
+	for i, rt := range rts {
+		body, err := build(rt.Underlying(), "obj")
 		if err != nil {
-			return false, err
+			return "", err
 		}
 
-		if !ignore {
-			has = true
+		var params []string
+		if obj {
+			params = append(params, fmt.Sprintf("obj *%s", typeNameOf(rt, p)))
+		}
+		if depth {
+			params = append(params, "depth int")
 		}
+
+		names[i] = recursiveFuncName(prefix, rt)
+		signatures[i] = fmt.Sprintf("func(%s) %s", strings.Join(params, ", "), results)
+		bodies[i] = body
 	}
-	return has, nil
+
+	return wrapRecursiveFuncs(names, signatures, bodies, ret, depth), nil
 }
 
 func sliceTypeName(t *types.Slice, p *types.Package) string {
@@ -801,6 +2922,8 @@ func typeNameOf(t types.Type, p *types.Package) string {
 		return fmt.Sprintf("%s.%s", obj.Pkg().Name(), obj.Name())
 	case *types.Basic:
 		return x.Name()
+	case *types.Pointer:
+		return "*" + typeNameOf(x.Elem(), p)
 	case *types.Map:
 		return mapTypeName(x, p)
 	case *types.Slice:
@@ -850,6 +2973,204 @@ func maxLenIsValid(t types.Type) bool {
 	}
 }
 
+func lenIsValid(t types.Type) bool {
+	switch x := t.(type) {
+	case *types.Named:
+		return lenIsValid(x.Underlying())
+	case *types.Basic:
+		switch x.Kind() {
+		case types.String:
+			return true
+		default:
+			return false
+		}
+	case *types.Slice:
+		return true
+	default:
+		return false
+	}
+}
+
+// inheritOptions returns the options of a struct field or container element, which inherit
+// the byte order, the maximum depth of recursive types and the unions of the parent's options
+func inheritOptions(parent, options *Options) *Options {
+	if parent == nil || (!parent.BigEndian && parent.MaxDepth == 0 && parent.Unions == nil) {
+		return options
+	}
+
+	if options == nil {
+		options = &Options{}
+	}
+	if parent.BigEndian {
+		options.BigEndian = true
+	}
+	if options.MaxDepth == 0 {
+		options.MaxDepth = parent.MaxDepth
+	}
+	options.Unions = parent.Unions
+
+	return options
+}
+
+// parseDirectives parses the "//skyencoder:" directives of a struct into the options applied to the whole struct
+func parseDirectives(directives []string) (*Options, error) {
+	var opts *Options
+	for _, d := range directives {
+		fields := strings.Fields(d)
+		if len(fields) == 0 {
+			return nil, fmt.Errorf("Invalid directive %q", directivePrefix+d)
+		}
+
+		switch fields[0] {
+		case "byteorder":
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("Invalid byteorder directive %q", directivePrefix+d)
+			}
+
+			switch fields[1] {
+			case "big":
+				if opts == nil {
+					opts = &Options{}
+				}
+				opts.BigEndian = true
+			case "little":
+			default:
+				return nil, fmt.Errorf("Invalid byteorder directive %q (must be \"big\" or \"little\")", directivePrefix+d)
+			}
+		case "union":
+			if len(fields) < 3 {
+				return nil, fmt.Errorf("Invalid union directive %q (must list the interface type and its types)", directivePrefix+d)
+			}
+
+			if opts == nil {
+				opts = &Options{}
+			}
+			if opts.Unions == nil {
+				opts.Unions = &Unions{}
+			}
+
+			name := fields[1]
+			if _, ok := (*opts.Unions)[name]; ok {
+				return nil, fmt.Errorf("Duplicate union directive for interface type %s", name)
+			}
+
+			members, err := parseUnionMembers(fields[2:])
+			if err != nil {
+				return nil, fmt.Errorf("Invalid union directive %q: %v", directivePrefix+d, err)
+			}
+			(*opts.Unions)[name] = members
+		case "checksum":
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("Invalid checksum directive %q", directivePrefix+d)
+			}
+
+			if _, ok := checksums[fields[1]]; !ok {
+				return nil, fmt.Errorf("Invalid checksum directive %q (must be \"crc32\", \"crc32c\" or \"sha256-truncated\")", directivePrefix+d)
+			}
+
+			if opts == nil {
+				opts = &Options{}
+			}
+			if opts.Checksum != "" {
+				return nil, errors.New("Duplicate checksum directive")
+			}
+			opts.Checksum = fields[1]
+		default:
+			return nil, fmt.Errorf("Invalid directive %q", directivePrefix+d)
+		}
+	}
+
+	return opts, nil
+}
+
+// checksum is an algorithm of the checksum directive
+type checksum struct {
+	// funcName is the name of the function of the runtime package which computes the checksum
+	funcName string
+	sum      func([]byte) [runtime.ChecksumSize]byte
+}
+
+// checksums are the algorithms of the checksum directive, by name
+var checksums = map[string]checksum{
+	"crc32": {
+		funcName: "ChecksumCRC32",
+		sum:      runtime.ChecksumCRC32,
+	},
+	"crc32c": {
+		funcName: "ChecksumCRC32C",
+		sum:      runtime.ChecksumCRC32C,
+	},
+	"sha256-truncated": {
+		funcName: "ChecksumSHA256Truncated",
+		sum:      runtime.ChecksumSHA256Truncated,
+	},
+}
+
+// findChecksum returns the algorithm of the checksum directive of a struct, or nil if it has none.
+// The checksum follows the encoded fields, so it can't follow an omitempty field,
+// which is decoded whenever bytes remain.
+func findChecksum(t *types.Struct, options *Options) (*checksum, error) {
+	if options == nil || options.Checksum == "" {
+		return nil, nil
+	}
+
+	for i := 0; i < t.NumFields(); i++ {
+		f := t.Field(i)
+		if !f.Exported() {
+			continue
+		}
+
+		ignore, fieldOptions, err := parseTag(t.Tag(i))
+		if err != nil {
+			return nil, err
+		}
+
+		if !ignore && fieldOptions != nil && fieldOptions.OmitEmpty {
+			return nil, fmt.Errorf("Field %s has the omitempty option, which can't be used with the checksum directive", f.Name())
+		}
+	}
+
+	c := checksums[options.Checksum]
+	return &c, nil
+}
+
+// parseUnionMembers parses the "tag=Type" members of a union directive
+func parseUnionMembers(fields []string) ([]UnionMember, error) {
+	members := make([]UnionMember, len(fields))
+	tags := make(map[uint64]struct{}, len(fields))
+	names := make(map[string]struct{}, len(fields))
+	for i, f := range fields {
+		parts := strings.SplitN(f, "=", 2)
+		if len(parts) != 2 || parts[1] == "" || parts[1] == "*" {
+			return nil, fmt.Errorf("member %q must be tag=Type", f)
+		}
+
+		// Tag 0 is the encoding of a nil interface value
+		tag, err := strconv.ParseUint(parts[0], 10, 8)
+		if err != nil || tag == 0 {
+			return nil, fmt.Errorf("member %q tag must be between 1 and 255", f)
+		}
+		if _, ok := tags[tag]; ok {
+			return nil, fmt.Errorf("duplicate tag %d", tag)
+		}
+		tags[tag] = struct{}{}
+
+		// A type and its pointer type would have the same name in the canonical JSON form
+		name := strings.TrimPrefix(parts[1], "*")
+		if _, ok := names[name]; ok {
+			return nil, fmt.Errorf("duplicate type %s", name)
+		}
+		names[name] = struct{}{}
+
+		members[i] = UnionMember{
+			Tag:  uint8(tag),
+			Type: parts[1],
+		}
+	}
+
+	return members, nil
+}
+
 func parseTag(tag string) (bool, *Options, error) {
 	tags, err := structtag.Parse(tag)
 	if err != nil {
@@ -884,6 +3205,44 @@ func parseTag(tag string) (bool, *Options, error) {
 				return false, nil, fmt.Errorf("Invalid maxlen option %q", o)
 			}
 			opts.MaxLength = n
+		} else if strings.HasPrefix(o, "since=") {
+			numStr := o[len("since="):]
+			n, err := strconv.ParseUint(numStr, 10, 64)
+			if err != nil || n == 0 {
+				return false, nil, fmt.Errorf("Invalid since option %q", o)
+			}
+			opts.Since = n
+		} else if o == "be" {
+			opts.BigEndian = true
+		} else if o == "nohash" {
+			opts.NoHash = true
+		} else if strings.HasPrefix(o, "len=") {
+			numStr := o[len("len="):]
+			n, err := strconv.ParseUint(numStr, 10, 64)
+			if err != nil || n == 0 {
+				return false, nil, fmt.Errorf("Invalid len option %q", o)
+			}
+			opts.Length = n
+		} else if strings.HasPrefix(o, "maxdepth=") {
+			numStr := o[len("maxdepth="):]
+			n, err := strconv.ParseUint(numStr, 10, 64)
+			if err != nil || n == 0 {
+				return false, nil, fmt.Errorf("Invalid maxdepth option %q", o)
+			}
+			opts.MaxDepth = n
+		} else if strings.HasPrefix(o, "const=") {
+			value := o[len("const="):]
+			if value == "" {
+				return false, nil, fmt.Errorf("Invalid const option %q", o)
+			}
+			opts.Const = value
+		} else if strings.HasPrefix(o, "order=") {
+			numStr := o[len("order="):]
+			n, err := strconv.ParseUint(numStr, 10, 64)
+			if err != nil || n == 0 {
+				return false, nil, fmt.Errorf("Invalid order option %q", o)
+			}
+			opts.Order = n
 		} else {
 			return false, nil, fmt.Errorf("Invalid struct tag option %q", o)
 		}
@@ -892,6 +3251,135 @@ func parseTag(tag string) (bool, *Options, error) {
 	return false, opts, nil
 }
 
+// encodedFields returns the indexes of the fields of a struct in encoding order.
+// Fields are encoded in declaration order, unless every encoded field has an order option,
+// in which case they are encoded by order, after the fields which are not encoded.
+// The orders of a struct's fields must be 1 to the number of encoded fields.
+func encodedFields(t *types.Struct) ([]int, error) {
+	var skipped, encoded []int
+	var unordered string
+	orders := make(map[uint64]int, t.NumFields())
+	for i := 0; i < t.NumFields(); i++ {
+		f := t.Field(i)
+		if !f.Exported() {
+			skipped = append(skipped, i)
+			continue
+		}
+
+		ignore, options, err := parseTag(t.Tag(i))
+		if err != nil {
+			return nil, err
+		}
+
+		if ignore {
+			skipped = append(skipped, i)
+			continue
+		}
+
+		encoded = append(encoded, i)
+
+		if options == nil || options.Order == 0 {
+			if unordered == "" {
+				unordered = f.Name()
+			}
+			continue
+		}
+
+		if j, ok := orders[options.Order]; ok {
+			return nil, fmt.Errorf("Fields %s and %s have the same order %d", t.Field(j).Name(), f.Name(), options.Order)
+		}
+		orders[options.Order] = i
+	}
+
+	if len(orders) == 0 {
+		order := make([]int, t.NumFields())
+		for i := range order {
+			order[i] = i
+		}
+		return order, nil
+	}
+
+	if unordered != "" {
+		return nil, fmt.Errorf("Field %s has no order option, but other fields of its struct do", unordered)
+	}
+
+	order := skipped
+	for n := uint64(1); n <= uint64(len(encoded)); n++ {
+		i, ok := orders[n]
+		if !ok {
+			return nil, fmt.Errorf("Field orders must be 1 to %d, but no field has order %d", len(encoded), n)
+		}
+		order = append(order, i)
+	}
+
+	return order, nil
+}
+
+// constValue returns the encoded bytes of the value of a const option on a value of type t,
+// and the value as a Go expression which can be assigned to and compared with the value.
+// The value of an integer type is a Go integer literal, e.g. 0x534b5943 or -1,
+// and the value of a byte array is the hex of its bytes with a 0x prefix, e.g. 0x534b5943 for [4]byte{'S', 'K', 'Y', 'C'}.
+func constValue(t types.Type, p *types.Package, options *Options) ([]byte, string, error) {
+	if options.OmitEmpty || options.MaxLength != 0 || options.Length != 0 {
+		return nil, "", errors.New("const cannot be combined with omitempty, maxlen or len")
+	}
+
+	switch x := t.Underlying().(type) {
+	case *types.Basic:
+		size, _, err := fixedEncodedSize(x, nil)
+		if err != nil {
+			return nil, "", err
+		}
+
+		switch x.Kind() {
+		case types.Int8, types.Int16, types.Int32, types.Int64:
+			n, err := strconv.ParseInt(options.Const, 0, int(8*size))
+			if err != nil {
+				return nil, "", fmt.Errorf("Invalid const option %q for type %s", options.Const, typeNameOf(t, p))
+			}
+			return putVectorUint(nil, int(size), uint64(n), options), strconv.FormatInt(n, 10), nil
+
+		case types.Uint8, types.Uint16, types.Uint32, types.Uint64:
+			n, err := strconv.ParseUint(options.Const, 0, int(8*size))
+			if err != nil {
+				return nil, "", fmt.Errorf("Invalid const option %q for type %s", options.Const, typeNameOf(t, p))
+			}
+			return putVectorUint(nil, int(size), n, options), fmt.Sprintf("%#x", n), nil
+		}
+
+	case *types.Array:
+		if !isByte(x.Elem()) {
+			break
+		}
+
+		b, err := hex.DecodeString(strings.TrimPrefix(options.Const, "0x"))
+		if err != nil || !strings.HasPrefix(options.Const, "0x") || int64(len(b)) != x.Len() {
+			return nil, "", fmt.Errorf("Invalid const option %q for type %s (must be 0x followed by %d hex digits)", options.Const, typeNameOf(t, p), 2*x.Len())
+		}
+
+		return b, fmt.Sprintf("%s{%s}", typeNameOf(x, p), byteLiterals(b)), nil
+	}
+
+	return nil, "", errors.New("const is only valid for integer types and byte arrays")
+}
+
+// constZero returns the Go expression of the zero value of a type which can have a const option
+func constZero(t types.Type, p *types.Package) string {
+	if x, ok := t.Underlying().(*types.Array); ok {
+		return fmt.Sprintf("%s{}", typeNameOf(x, p))
+	}
+	return "0"
+}
+
+// byteLiterals returns the elements of a composite literal of bytes, e.g. "0x53, 0x4b"
+func byteLiterals(b []byte) string {
+	elems := make([]string, len(b))
+	for i, c := range b {
+		elems[i] = fmt.Sprintf("0x%02x", c)
+	}
+	return strings.Join(elems, ", ")
+}
+
 func isByte(t types.Type) bool {
 	switch x := t.(type) {
 	case *types.Named:
@@ -909,15 +3397,24 @@ func isByte(t types.Type) bool {
 }
 
 func hasMap(t types.Type) (bool, error) {
+	return hasMapSeen(t, make(map[*types.Named]bool))
+}
+
+// hasMapSeen is hasMap, which does not walk the named types in seen again, so that it stops on recursive types
+func hasMapSeen(t types.Type, seen map[*types.Named]bool) (bool, error) {
 	switch x := t.(type) {
 	case *types.Named:
-		return hasMap(x.Underlying())
+		if seen[x] {
+			return false, nil
+		}
+		seen[x] = true
+		return hasMapSeen(x.Underlying(), seen)
 
 	case *types.Array:
-		return hasMap(x.Elem())
+		return hasMapSeen(x.Elem(), seen)
 
 	case *types.Slice:
-		return hasMap(x.Elem())
+		return hasMapSeen(x.Elem(), seen)
 
 	case *types.Map:
 		return true, nil
@@ -939,7 +3436,85 @@ func hasMap(t types.Type) (bool, error) {
 				continue
 			}
 
-			has, err := hasMap(f.Type())
+			has, err := hasMapSeen(f.Type(), seen)
+			if err != nil {
+				return false, err
+			}
+
+			if has {
+				return true, nil
+			}
+		}
+
+		return false, nil
+
+	default:
+		return false, nil
+	}
+}
+
+// anyType returns true if match returns true for any of the types
+func anyType(ts []types.Type, match func(types.Type) (bool, error)) (bool, error) {
+	for _, t := range ts {
+		if ok, err := match(t); err != nil || ok {
+			return ok, err
+		}
+	}
+
+	return false, nil
+}
+
+// hasFieldOption returns true if the type has a field with tag options matching match, at any depth
+func hasFieldOption(t types.Type, match func(*Options) bool) (bool, error) {
+	return hasFieldOptionSeen(t, match, make(map[*types.Named]bool))
+}
+
+// hasFieldOptionSeen is hasFieldOption, which does not walk the named types in seen again, so that it stops on recursive types
+func hasFieldOptionSeen(t types.Type, match func(*Options) bool, seen map[*types.Named]bool) (bool, error) {
+	switch x := t.(type) {
+	case *types.Named:
+		if seen[x] {
+			return false, nil
+		}
+		seen[x] = true
+		return hasFieldOptionSeen(x.Underlying(), match, seen)
+
+	case *types.Array:
+		return hasFieldOptionSeen(x.Elem(), match, seen)
+
+	case *types.Slice:
+		return hasFieldOptionSeen(x.Elem(), match, seen)
+
+	case *types.Map:
+		has, err := hasFieldOptionSeen(x.Key(), match, seen)
+		if err != nil || has {
+			return has, err
+		}
+
+		return hasFieldOptionSeen(x.Elem(), match, seen)
+
+	case *types.Struct:
+		for i := 0; i < x.NumFields(); i++ {
+			f := x.Field(i)
+
+			if !f.Exported() {
+				continue
+			}
+
+			ignore, options, err := parseTag(x.Tag(i))
+			if err != nil {
+				return false, err
+			}
+
+			if ignore {
+				continue
+			}
+
+			if options != nil && match(options) {
+				return true, nil
+			}
+
+			has, err := hasFieldOptionSeen(f.Type(), match, seen)
 			if err != nil {
 				return false, err
 			}
@@ -955,3 +3530,50 @@ func hasMap(t types.Type) (bool, error) {
 		return false, nil
 	}
 }
+
+// rewriteStandalone rewrites generated code to use the runtime package's Encoder, Decoder and errors
+// instead of the encoder package's. Other uses of the encoder package, such as encoder.Serialize
+// in the generated tests, are unchanged. Unused imports are left for imports.Process to remove.
+func rewriteStandalone(filename string, src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+
+	isStandaloneName := func(name string) bool {
+		for _, n := range standaloneNames {
+			if n == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	ast.Inspect(f, func(n ast.Node) bool {
+		if sel, ok := n.(*ast.SelectorExpr); ok {
+			if x, ok := sel.X.(*ast.Ident); ok && x.Name == "encoder" && isStandaloneName(sel.Sel.Name) {
+				x.Name = "runtime"
+			}
+		}
+		return true
+	})
+
+	// Keep the doc comments consistent with the code
+	for _, cg := range f.Comments {
+		for _, c := range cg.List {
+			for _, n := range standaloneNames {
+				c.Text = strings.Replace(c.Text, "encoder."+n, "runtime."+n, -1)
+			}
+		}
+	}
+
+	astutil.AddImport(fset, f, runtimeImportPath)
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, f); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -1,6 +1,7 @@
 package skyencoder
 
 import (
+	"bytes"
 	"fmt"
 	"go/build"
 	"go/types"
@@ -48,7 +49,7 @@ func verifyProgramCompiles(t *testing.T, dir string) {
 	}
 }
 
-func testBuildCode(t *testing.T, structName, filename string) []byte {
+func testBuildCode(t *testing.T, structName, filename string, opts BuildOptions) []byte {
 	program, err := LoadProgram([]string{"."}, nil)
 	if err != nil {
 		t.Fatal(err)
@@ -59,7 +60,7 @@ func testBuildCode(t *testing.T, structName, filename string) []byte {
 		t.Fatal(err)
 	}
 
-	src, err := BuildStructEncoder(sInfo, "", filename, true)
+	src, err := BuildStructEncoder(sInfo, "", filename, true, opts)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -97,7 +98,15 @@ func TestBuildSkycoinSignedBlock(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	src, err := BuildStructEncoder(sInfo, "", filename, true)
+	src, err := BuildStructEncoder(sInfo, "", filename, true, BuildOptions{
+		Hash:        true,
+		Peek:        true,
+		Validate:    true,
+		Reuse:       true,
+		Pooled:      true,
+		Framed:      true,
+		DebugFormat: true,
+	})
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -113,6 +122,28 @@ func TestBuildSkycoinSignedBlock(t *testing.T) {
 	verifyProgramCompiles(t, importPath)
 }
 
+type StandaloneBuild struct {
+	Bool    bool
+	Strings []string `enc:",maxlen=4"`
+	Map     map[uint64]int32
+	Extra   []byte `enc:",omitempty"`
+}
+
+func TestBuildStandalone(t *testing.T) {
+	src := testBuildCode(t, "StandaloneBuild", "./standalone_build_skyencoder_test.go", BuildOptions{
+		Validate:   true,
+		Reuse:      true,
+		Standalone: true,
+	})
+
+	if bytes.Contains(src, []byte("github.com/skycoin/skycoin/src/cipher/encoder")) {
+		t.Fatalf("Standalone code imports the encoder package:\n%s", src)
+	}
+	if !bytes.Contains(src, []byte("runtime.ErrMaxLenExceeded")) {
+		t.Fatalf("Standalone code does not use the runtime package's errors:\n%s", src)
+	}
+}
+
 func testBuildCodeFails(t *testing.T, structName, filename string) {
 	program, err := LoadProgram([]string{"."}, nil)
 	if err != nil {
@@ -124,7 +155,9 @@ func testBuildCodeFails(t *testing.T, structName, filename string) {
 		t.Fatal(err)
 	}
 
-	_, err = BuildStructEncoder(sInfo, "", filename, true)
+	_, err = BuildStructEncoder(sInfo, "", filename, true, BuildOptions{
+		Hash: true,
+	})
 	if err == nil {
 		t.Fatal("Expected BuildStructEncoder error")
 	}
@@ -166,6 +199,212 @@ type EmptyStructSlice3 struct {
 	}
 }
 
+type SinceNotTrailing struct {
+	Int64  int64 `enc:",since=2"`
+	String string
+}
+
+type SinceDecreasing struct {
+	Int64  int64  `enc:",since=3"`
+	String string `enc:",since=2"`
+}
+
+type SinceInvalid struct {
+	Int64 int64 `enc:",since=0"`
+}
+
+type SinceNestedInner struct {
+	Int64 int64 `enc:",since=2"`
+}
+
+type SinceNested struct {
+	Inner SinceNestedInner
+}
+
+type LenInt struct {
+	Int64 int64 `enc:",len=4"`
+}
+
+type LenArray struct {
+	Array [4]byte `enc:",len=4"`
+}
+
+type LenInvalid struct {
+	String string `enc:",len=0"`
+}
+
+type LenMaxLen struct {
+	String string `enc:",len=4,maxlen=4"`
+}
+
+type LenOmitEmpty struct {
+	Extra []byte `enc:",len=4,omitempty"`
+}
+
+type NoHashNestedInner struct {
+	Int64 int64 `enc:",nohash"`
+}
+
+type NoHashNested struct {
+	Inner NoHashNestedInner
+}
+
+type HashMap struct {
+	Map map[string]int64
+}
+
+type MaxDepthInvalid struct {
+	Children []MaxDepthInvalid `enc:",maxdepth=0"`
+}
+
+type MaxDepthNotRecursive struct {
+	Strings []string `enc:",maxdepth=4"`
+}
+
+type RecursiveBigEndianInner struct {
+	Children []RecursiveBigEndianInner
+}
+
+type RecursiveBigEndian struct {
+	Inner RecursiveBigEndianInner `enc:",be"`
+}
+
+type RecursiveLenInner struct {
+	Children []RecursiveLenInner `enc:",len=2"`
+}
+
+type RecursiveLen struct {
+	Inner RecursiveLenInner
+}
+
+type RecursiveSliceType []RecursiveSliceType
+
+type RecursiveSlice struct {
+	Slice RecursiveSliceType
+}
+
+//skyencoder:byteorder middle
+type ByteOrderInvalid struct {
+	Int64 int64
+}
+
+//skyencoder:unknown
+type DirectiveUnknown struct {
+	Int64 int64
+}
+
+type UnionPayload interface {
+	isUnionPayload()
+}
+
+type UnionPayloadA struct {
+	A uint64
+}
+
+func (UnionPayloadA) isUnionPayload() {}
+
+type UnionPayloadNotImplemented struct {
+	B string
+}
+
+type UnionPayloadNested struct {
+	Inner UnionPayload
+}
+
+func (UnionPayloadNested) isUnionPayload() {}
+
+type UnionUnregistered struct {
+	Payload UnionPayload
+}
+
+//skyencoder:union UnionPayload 0=UnionPayloadA
+type UnionTagZero struct {
+	Payload UnionPayload
+}
+
+//skyencoder:union UnionPayload 1=UnionPayloadA 1=UnionPayloadNested
+type UnionTagDuplicate struct {
+	Payload UnionPayload
+}
+
+//skyencoder:union UnionPayload 1=UnionPayloadA 2=*UnionPayloadA
+type UnionTypeDuplicate struct {
+	Payload UnionPayload
+}
+
+//skyencoder:union UnionPayload 1=UnionPayloadMissing
+type UnionTypeMissing struct {
+	Payload UnionPayload
+}
+
+//skyencoder:union UnionPayload 1=UnionPayloadNotImplemented
+type UnionTypeNotImplemented struct {
+	Payload UnionPayload
+}
+
+//skyencoder:union UnionPayload 1=UnionPayloadNested
+type UnionTypeNested struct {
+	Payload UnionPayload
+}
+
+//skyencoder:union UnionPayload 1=UnionPayloadA
+//skyencoder:union UnionPayload 2=UnionPayloadNested
+type UnionDirectiveDuplicate struct {
+	Payload UnionPayload
+}
+
+type OrderMissing struct {
+	A uint32 `enc:",order=2"`
+	B uint32
+	C uint32 `enc:",order=1"`
+}
+
+type OrderDuplicate struct {
+	A uint32 `enc:",order=1"`
+	B uint32 `enc:",order=1"`
+}
+
+type OrderGap struct {
+	A uint32 `enc:",order=1"`
+	B uint32 `enc:",order=3"`
+}
+
+type OrderInvalid struct {
+	A uint32 `enc:",order=0"`
+}
+
+type OrderOmitEmptyNotLast struct {
+	A []byte `enc:",order=2"`
+	B []byte `enc:",order=1,omitempty"`
+}
+
+type ConstFloat struct {
+	A float64 `enc:",const=1"`
+}
+
+type ConstString struct {
+	A string `enc:",const=0x00"`
+}
+
+type ConstOverflow struct {
+	A uint8 `enc:",const=256"`
+}
+
+type ConstArrayLength struct {
+	A [4]byte `enc:",const=0x534b59"`
+}
+
+//skyencoder:checksum md5
+type ChecksumInvalid struct {
+	A uint64
+}
+
+//skyencoder:checksum crc32
+type ChecksumOmitEmpty struct {
+	A uint64
+	B []byte `enc:",omitempty"`
+}
+
 func TestBuildFails(t *testing.T) {
 	cases := []struct {
 		name string
@@ -191,6 +430,117 @@ func TestBuildFails(t *testing.T) {
 		{
 			name: "EmptyStructSlice3",
 		},
+		{
+			name: "SinceNotTrailing",
+		},
+		{
+			name: "SinceDecreasing",
+		},
+		{
+			name: "SinceInvalid",
+		},
+		{
+			name: "SinceNested",
+		},
+		{
+			name: "LenInt",
+		},
+		{
+			name: "LenArray",
+		},
+		{
+			name: "LenInvalid",
+		},
+		{
+			name: "LenMaxLen",
+		},
+		{
+			name: "LenOmitEmpty",
+		},
+		{
+			name: "NoHashNested",
+		},
+		{
+			name: "HashMap",
+		},
+		{
+			name: "MaxDepthInvalid",
+		},
+		{
+			name: "MaxDepthNotRecursive",
+		},
+		{
+			name: "RecursiveBigEndian",
+		},
+		{
+			name: "RecursiveLen",
+		},
+		{
+			name: "RecursiveSlice",
+		},
+		{
+			name: "ByteOrderInvalid",
+		},
+		{
+			name: "DirectiveUnknown",
+		},
+		{
+			name: "UnionUnregistered",
+		},
+		{
+			name: "UnionTagZero",
+		},
+		{
+			name: "UnionTagDuplicate",
+		},
+		{
+			name: "UnionTypeDuplicate",
+		},
+		{
+			name: "UnionTypeMissing",
+		},
+		{
+			name: "UnionTypeNotImplemented",
+		},
+		{
+			name: "UnionTypeNested",
+		},
+		{
+			name: "UnionDirectiveDuplicate",
+		},
+		{
+			name: "OrderMissing",
+		},
+		{
+			name: "OrderDuplicate",
+		},
+		{
+			name: "OrderGap",
+		},
+		{
+			name: "OrderInvalid",
+		},
+		{
+			name: "OrderOmitEmptyNotLast",
+		},
+		{
+			name: "ConstFloat",
+		},
+		{
+			name: "ConstString",
+		},
+		{
+			name: "ConstOverflow",
+		},
+		{
+			name: "ConstArrayLength",
+		},
+		{
+			name: "ChecksumInvalid",
+		},
+		{
+			name: "ChecksumOmitEmpty",
+		},
 	}
 
 	for _, tc := range cases {
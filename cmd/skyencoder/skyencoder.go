@@ -1,6 +1,7 @@
 package main
 
 import (
+	"encoding/hex"
 	"flag"
 	"fmt"
 	"io/ioutil"
@@ -8,6 +9,10 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/tools/go/loader"
 
 	"github.com/skycoin/skyencoder"
 )
@@ -35,12 +40,36 @@ var (
 	unexported     = flag.Bool("unexported", false, "don't export generated methods (always true if the struct is not an exported type)")
 	silent         = flag.Bool("silent", false, "disable all non-error log output")
 	noTest         = flag.Bool("no-test", false, "disable generating the _test.go file (test files require github.com/google/go-cmp/cmp and github.com/skycoin/encodertest)")
+	typescript     = flag.Bool("typescript", false, "also generate a TypeScript encoder <struct_name>_skyencoder.ts, with golden test vectors and a test script for it")
+	tsOutputPath   = flag.String("typescript-output-path", "", "output path for the TypeScript files; defaults to the output path")
+	hash           = flag.Bool("hash", false, "also generate HashX(obj) cipher.SHA256, which streams the encoding of the object to a SHA256 hash, excluding fields tagged with enc:\",nohash\"")
+	peek           = flag.Bool("peek", false, "also generate PeekX<Field>(buf) functions, which decode a fixed size field of an encoded object without decoding the fields preceding it")
+	pooled         = flag.Bool("pooled", false, "also generate EncodeXPooled(obj), which encodes an object to a buffer from a sync.Pool, to be released after use")
+	framed         = flag.Bool("framed", false, "also generate WriteFramedX(w, obj) and ReadFramedX(r, obj, maxFrame), which write and read an object as a uint32 length prefixed frame of a stream")
+	standalone     = flag.Bool("standalone", false, "generate code which uses github.com/skycoin/skyencoder/runtime instead of github.com/skycoin/skycoin/src/cipher/encoder")
+	reuse          = flag.Bool("reuse", false, "also generate DecodeXReuse(buf, obj), which decodes into the existing slices and maps of an object to avoid allocating")
+	configFile     = flag.String("config", "", "generate the packages and structs listed in a YAML config file, e.g. skyencoder.yaml, in one run; other flags except -silent and -watch are ignored")
+	watch          = flag.Bool("watch", false, "keep running, and regenerate the code of a struct when its fields, tags or the types it references change in the source files")
+	watchInterval  = flag.Duration("watch-interval", time.Second, "how often -watch checks the source files for changes")
+	debugFormat    = flag.Bool("debug-format", false, "also generate FormatX(obj) string, which formats an object for debugging with its fields in encoded order and byte arrays in hex, and a GoString method calling it if the code is generated in the struct's package")
+	validate       = flag.Bool("validate", false, "also generate ValidateX(buf), which checks that a buffer is a valid encoding of an object without decoding it")
+)
+
+const (
+	// defaultVectorsSeed is the default random seed of golden test vectors
+	defaultVectorsSeed = 1
+	// defaultVectorsCount is the default number of golden test vectors
+	defaultVectorsCount = 10
 )
 
 func usage() {
 	fmt.Fprintf(os.Stderr, "Usage of skyencoder:\n")
 	fmt.Fprintf(os.Stderr, "\tskyencoder [flags] -struct T [go import path e.g. github.com/skycoin/skycoin/src/coin]\n")
 	fmt.Fprintf(os.Stderr, "\tskyencoder [flags] -struct T files... # Must be a single package\n")
+	fmt.Fprintf(os.Stderr, "\tskyencoder [-silent] [-watch] -config skyencoder.yaml # Generate the packages and structs listed in a config file\n")
+	fmt.Fprintf(os.Stderr, "\tskyencoder vectors [flags] -struct T [go import path or files...] # Write golden test vectors, see skyencoder vectors -h\n")
+	fmt.Fprintf(os.Stderr, "\tskyencoder explain [flags] -struct T file.bin [go import path or files...] # Print the layout of an encoded object, see skyencoder explain -h\n")
+	fmt.Fprintf(os.Stderr, "\tskyencoder convert [flags] -struct T -from json -to bin [go import path or files...] # Convert an object between JSON and binary, see skyencoder convert -h\n")
 	fmt.Fprintf(os.Stderr, "Flags:\n")
 	flag.PrintDefaults()
 }
@@ -49,9 +78,29 @@ func main() {
 	log.SetFlags(0)
 	log.SetPrefix("skyencoder: ")
 
+	if len(os.Args) > 1 && os.Args[1] == "vectors" {
+		vectorsMain(os.Args[2:])
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "explain" {
+		explainMain(os.Args[2:])
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "convert" {
+		convertMain(os.Args[2:])
+		return
+	}
+
 	flag.Usage = usage
 	flag.Parse()
 
+	if *configFile != "" {
+		configMain(*configFile)
+		return
+	}
+
 	if *structName == "" {
 		flag.Usage()
 		os.Exit(2)
@@ -62,8 +111,531 @@ func main() {
 		tags = strings.Split(*buildTags, ",")
 	}
 
+	if *watch {
+		p := skyencoder.PackageConfig{
+			Tags:       tags,
+			Package:    *destPackage,
+			OutputPath: *outputPath,
+			Structs: []skyencoder.StructConfig{{
+				Struct:      *structName,
+				OutputFile:  *outputFilename,
+				Unexported:  *unexported,
+				NoTest:      *noTest,
+				Hash:        *hash,
+				Peek:        *peek,
+				Validate:    *validate,
+				Reuse:       *reuse,
+				Pooled:      *pooled,
+				Framed:      *framed,
+				Standalone:  *standalone,
+				DebugFormat: *debugFormat,
+			}},
+		}
+		if flag.NArg() == 1 {
+			p.Path = flag.Arg(0)
+		} else {
+			p.Files = flag.Args()
+		}
+
+		watchMain([]skyencoder.PackageConfig{p})
+		return
+	}
+
+	structInfo, fmtFilename, destPath := loadStruct(*structName, flag.Args(), tags)
+
+	exported := structInfo.Exported
+	if *unexported {
+		exported = false
+	}
+
+	buildOpts := skyencoder.BuildOptions{
+		Hash:        *hash,
+		Peek:        *peek,
+		Validate:    *validate,
+		Reuse:       *reuse,
+		Pooled:      *pooled,
+		Framed:      *framed,
+		Standalone:  *standalone,
+		DebugFormat: *debugFormat,
+	}
+
+	outputPth := *outputPath
+	if outputPth == "" {
+		outputPth = destPath
+	}
+
+	if err := writeEncoder(structInfo, *destPackage, fmtFilename, outputPth, *outputFilename, exported, *noTest, buildOpts); err != nil {
+		log.Fatal(err)
+	}
+
+	if *typescript {
+		tsPath := *tsOutputPath
+		if tsPath == "" {
+			tsPath = outputPth
+		}
+
+		if err := writeTypeScript(structInfo, tsPath, defaultVectorsSeed, defaultVectorsCount); err != nil {
+			log.Fatal(err)
+		}
+	}
+}
+
+// vectorsMain runs the vectors subcommand, which writes golden test vectors for a struct to testdata/<struct_name>.vectors.json
+// and a test that decodes and re-encodes them to <struct_name>_skyencoder_vectors_test.go
+func vectorsMain(args []string) {
+	fs := flag.NewFlagSet("vectors", flag.ExitOnError)
+	structName := fs.String("struct", "", "struct name, must be set")
+	outputPath := fs.String("output-path", "", "output path of the test file, with the vectors in its testdata folder; defaults to the package's path, or the file's containing folder")
+	buildTags := fs.String("tags", "", "comma-separated list of build tags to apply")
+	destPackage := fs.String("package", "", "package name for the test file; if not provided, defaults to the struct's package")
+	unexported := fs.Bool("unexported", false, "the encoder was generated with -unexported (always true if the struct is not an exported type)")
+	fs.BoolVar(silent, "silent", false, "disable all non-error log output")
+	noTest := fs.Bool("no-test", false, "disable generating the _test.go file")
+	seed := fs.Int64("seed", defaultVectorsSeed, "random seed of the sample objects")
+	count := fs.Int("count", defaultVectorsCount, "number of sample objects")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage of skyencoder vectors:\n")
+		fmt.Fprintf(os.Stderr, "\tskyencoder vectors [flags] -struct T [go import path e.g. github.com/skycoin/skycoin/src/coin]\n")
+		fmt.Fprintf(os.Stderr, "\tskyencoder vectors [flags] -struct T files... # Must be a single package\n")
+		fmt.Fprintf(os.Stderr, "Flags:\n")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		log.Fatal(err)
+	}
+
+	if *structName == "" || *count <= 0 {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	var tags []string
+	if len(*buildTags) > 0 {
+		tags = strings.Split(*buildTags, ",")
+	}
+
+	structInfo, fmtFilename, destPath := loadStruct(*structName, fs.Args(), tags)
+
+	exported := structInfo.Exported
+	if *unexported {
+		exported = false
+	}
+
+	outputPth := *outputPath
+	if outputPth == "" {
+		outputPth = destPath
+	}
+
+	if err := writeVectors(structInfo, *destPackage, fmtFilename, outputPth, exported, *noTest, *seed, *count); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// explainMain runs the explain subcommand, which decodes an encoded object and prints a hexdump annotated with its fields.
+// The exit code is 1 if the object could not be decoded.
+func explainMain(args []string) {
+	fs := flag.NewFlagSet("explain", flag.ExitOnError)
+	structName := fs.String("struct", "", "struct name, must be set")
+	buildTags := fs.String("tags", "", "comma-separated list of build tags to apply")
+	hexInput := fs.Bool("hex", false, "the file contains the encoded object in hex instead of binary; always true for stdin")
+	version := fs.Int64("version", -1, "decode an object encoded at this version, for structs with fields tagged with enc:\",since=N\"; defaults to the latest version")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage of skyencoder explain:\n")
+		fmt.Fprintf(os.Stderr, "\tskyencoder explain [flags] -struct T file.bin [go import path e.g. github.com/skycoin/skycoin/src/coin]\n")
+		fmt.Fprintf(os.Stderr, "\tskyencoder explain [flags] -struct T file.bin files... # Must be a single package\n")
+		fmt.Fprintf(os.Stderr, "\tskyencoder explain [flags] -struct T - [go import path or files...] # Read the object in hex from stdin\n")
+		fmt.Fprintf(os.Stderr, "Flags:\n")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		log.Fatal(err)
+	}
+
+	if *structName == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	var tags []string
+	if len(*buildTags) > 0 {
+		tags = strings.Split(*buildTags, ",")
+	}
+
+	inputFn := "-"
+	if fs.NArg() > 0 {
+		inputFn = fs.Arg(0)
+	}
+
+	var buf []byte
+	var err error
+	if inputFn == "-" {
+		buf, err = ioutil.ReadAll(os.Stdin)
+		*hexInput = true
+	} else {
+		buf, err = ioutil.ReadFile(inputFn)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if *hexInput {
+		buf, err = hex.DecodeString(strings.Join(strings.Fields(string(buf)), ""))
+		if err != nil {
+			log.Fatal("Invalid hex input: ", err)
+		}
+	}
+
+	var pkgArgs []string
+	if fs.NArg() > 1 {
+		pkgArgs = fs.Args()[1:]
+	}
+
+	structInfo, _, _ := loadStruct(*structName, pkgArgs, tags)
+
+	var x *skyencoder.Explanation
+	if *version < 0 {
+		x, err = skyencoder.ExplainStruct(structInfo, buf)
+	} else {
+		x, err = skyencoder.ExplainStructVersion(structInfo, buf, uint64(*version))
+	}
+	if err != nil {
+		log.Fatal("skyencoder.ExplainStruct failed: ", err)
+	}
+
+	os.Stdout.Write(x.Hexdump())
+
+	if x.Err != nil {
+		log.Fatalf("Decoding %s failed at %v", structInfo.Name, x.Err)
+	}
+}
+
+// convertMain runs the convert subcommand, which converts an object between its JSON form and its encoding.
+// The JSON form is the canonical JSON form of golden test vectors: 64-bit integers are decimal strings,
+// byte arrays and byte slices are hex strings and maps are lists of {"key", "value"} objects.
+func convertMain(args []string) {
+	fs := flag.NewFlagSet("convert", flag.ExitOnError)
+	structName := fs.String("struct", "", "struct name, must be set")
+	buildTags := fs.String("tags", "", "comma-separated list of build tags to apply")
+	from := fs.String("from", "json", "input format: json, bin or hex")
+	to := fs.String("to", "bin", "output format: json, bin or hex")
+	inputFn := fs.String("in", "-", "input file, - for stdin")
+	outputFn := fs.String("out", "-", "output file, - for stdout")
+
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage of skyencoder convert:\n")
+		fmt.Fprintf(os.Stderr, "\tskyencoder convert [flags] -struct T -from json -to bin [go import path e.g. github.com/skycoin/skycoin/src/coin]\n")
+		fmt.Fprintf(os.Stderr, "\tskyencoder convert [flags] -struct T -from bin -to json files... # Must be a single package\n")
+		fmt.Fprintf(os.Stderr, "Flags:\n")
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		log.Fatal(err)
+	}
+
+	if *structName == "" {
+		fs.Usage()
+		os.Exit(2)
+	}
+
+	for _, f := range []string{*from, *to} {
+		switch f {
+		case "json", "bin", "hex":
+		default:
+			log.Fatalf("Invalid format %q, must be json, bin or hex", f)
+		}
+	}
+
+	var tags []string
+	if len(*buildTags) > 0 {
+		tags = strings.Split(*buildTags, ",")
+	}
+
+	var in []byte
+	var err error
+	if *inputFn == "-" {
+		in, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		in, err = ioutil.ReadFile(*inputFn)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	structInfo, _, _ := loadStruct(*structName, fs.Args(), tags)
+
+	// Convert the input to binary, then the binary to the output format
+	var buf []byte
+	switch *from {
+	case "json":
+		buf, err = skyencoder.StructJSONToBinary(structInfo, in)
+		if err != nil {
+			log.Fatalf("Encoding %s failed: %v", structInfo.Name, err)
+		}
+	case "hex":
+		buf, err = hex.DecodeString(strings.Join(strings.Fields(string(in)), ""))
+		if err != nil {
+			log.Fatal("Invalid hex input: ", err)
+		}
+	case "bin":
+		buf = in
+	}
+
+	// Binary input is always decoded, so that only valid objects are converted
+	var out []byte
+	if *from != "json" || *to == "json" {
+		out, err = skyencoder.StructBinaryToJSON(structInfo, buf)
+		if err != nil {
+			log.Fatalf("Decoding %s failed at %v", structInfo.Name, err)
+		}
+	}
+
+	switch *to {
+	case "hex":
+		out = []byte(hex.EncodeToString(buf) + "\n")
+	case "bin":
+		out = buf
+	}
+
+	if *outputFn == "-" {
+		_, err = os.Stdout.Write(out)
+	} else {
+		err = ioutil.WriteFile(*outputFn, out, 0644)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// configMain generates the code for every struct of the config file.
+// Each package is loaded once, and the packages are processed concurrently.
+func configMain(filename string) {
+	c, err := skyencoder.LoadConfig(filename)
+	if err != nil {
+		log.Fatal("skyencoder.LoadConfig failed: ", err)
+	}
+
+	if *watch {
+		watchMain(c.Packages)
+		return
+	}
+
+	errs := make([][]error, len(c.Packages))
+
+	var wg sync.WaitGroup
+	for i, p := range c.Packages {
+		wg.Add(1)
+		go func(i int, p skyencoder.PackageConfig) {
+			defer wg.Done()
+			errs[i] = generatePackage(p)
+		}(i, p)
+	}
+	wg.Wait()
+
+	failed := false
+	for _, pkgErrs := range errs {
+		for _, err := range pkgErrs {
+			log.Print(err)
+			failed = true
+		}
+	}
+
+	if failed {
+		log.Fatalf("Generating from %q failed", filename)
+	}
+}
+
+// generatePackage loads a package and generates the code for each of its listed structs.
+// A failure for one struct does not stop the others from being generated.
+func generatePackage(p skyencoder.PackageConfig) []error {
+	args := p.Args()
+
+	program, err := skyencoder.LoadProgram(args, p.Tags)
+	if err != nil {
+		return []error{fmt.Errorf("skyencoder.LoadProgram %v failed: %v", args, err)}
+	}
+
+	var errs []error
+	for _, s := range p.Structs {
+		structInfo, err := findStruct(program, s.Struct)
+		if err == nil {
+			err = generateStruct(structInfo, args, p, s)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("struct %q: %v", s.Struct, err))
+		}
+	}
+
+	return errs
+}
+
+// generateStruct writes the code for a struct of a loaded package
+func generateStruct(structInfo *skyencoder.StructInfo, args []string, p skyencoder.PackageConfig, s skyencoder.StructConfig) error {
+	fmtFilename, destPath, err := packagePaths(args, structInfo)
+	if err != nil {
+		return err
+	}
+
+	destPackage := p.Package
+	if s.Package != "" {
+		destPackage = s.Package
+	}
+
+	outputPth := p.OutputPath
+	if s.OutputPath != "" {
+		outputPth = s.OutputPath
+	}
+	if outputPth == "" {
+		outputPth = destPath
+	}
+
+	exported := structInfo.Exported && !s.Unexported
+
+	buildOpts := skyencoder.BuildOptions{
+		Hash:        s.Hash,
+		Peek:        s.Peek,
+		Validate:    s.Validate,
+		Reuse:       s.Reuse,
+		Pooled:      s.Pooled,
+		Framed:      s.Framed,
+		Standalone:  s.Standalone,
+		DebugFormat: s.DebugFormat,
+	}
+
+	if err := writeEncoder(structInfo, destPackage, fmtFilename, outputPth, s.OutputFile, exported, s.NoTest, buildOpts); err != nil {
+		return err
+	}
+
+	seed := s.VectorsSeed
+	if seed == 0 {
+		seed = defaultVectorsSeed
+	}
+	count := s.VectorsCount
+	if count == 0 {
+		count = defaultVectorsCount
+	}
+
+	if s.Vectors {
+		if err := writeVectors(structInfo, destPackage, fmtFilename, outputPth, exported, s.NoTest, seed, count); err != nil {
+			return err
+		}
+	}
+
+	if s.TypeScript {
+		tsPath := s.TypeScriptOutputPath
+		if tsPath == "" {
+			tsPath = outputPth
+		}
+
+		return writeTypeScript(structInfo, tsPath, seed, count)
+	}
+
+	return nil
+}
+
+// watchMain generates the code for the structs of the packages, then keeps polling the source files of each package,
+// regenerating the code of a struct only when its skyencoder.StructFingerprint changes. It does not return.
+func watchMain(pkgs []skyencoder.PackageConfig) {
+	if !*silent {
+		log.Printf("Watching %d package(s) for changes every %v", len(pkgs), *watchInterval)
+	}
+
+	var wg sync.WaitGroup
+	for _, p := range pkgs {
+		wg.Add(1)
+		go func(p skyencoder.PackageConfig) {
+			defer wg.Done()
+			watchPackage(p)
+		}(p)
+	}
+	wg.Wait()
+}
+
+// watchPackage reloads a package whenever its source files change, and regenerates the code of its structs
+// whose fingerprint changed since they were last generated
+func watchPackage(p skyencoder.PackageConfig) {
+	args := p.Args()
+	fingerprints := make(map[string]string, len(p.Structs))
+	var modTimes map[string]time.Time
+
+	for {
+		program, err := skyencoder.LoadProgram(args, p.Tags)
+		if err != nil {
+			if modTimes == nil {
+				log.Fatalf("skyencoder.LoadProgram %v failed: %v", args, err)
+			}
+			log.Printf("skyencoder.LoadProgram %v failed: %v", args, err)
+		} else {
+			modTimes = make(map[string]time.Time)
+
+			for _, s := range p.Structs {
+				structInfo, err := findStruct(program, s.Struct)
+				if err != nil {
+					log.Printf("struct %q: %v", s.Struct, err)
+					continue
+				}
+
+				// Watch the directories as well, to notice new files
+				for _, fn := range skyencoder.StructSourceFiles(program, structInfo) {
+					addModTime(modTimes, fn)
+					addModTime(modTimes, filepath.Dir(fn))
+				}
+
+				fingerprint := skyencoder.StructFingerprint(program, structInfo)
+				if fingerprint == fingerprints[s.Struct] {
+					continue
+				}
+
+				if err := generateStruct(structInfo, args, p, s); err != nil {
+					log.Printf("struct %q: %v", s.Struct, err)
+					continue
+				}
+
+				fingerprints[s.Struct] = fingerprint
+			}
+		}
+
+		waitForChange(modTimes, *watchInterval)
+	}
+}
+
+// addModTime records the modification time of a file, if it exists
+func addModTime(modTimes map[string]time.Time, fn string) {
+	if _, ok := modTimes[fn]; ok {
+		return
+	}
+
+	stat, err := os.Stat(fn)
+	if err != nil {
+		return
+	}
+
+	modTimes[fn] = stat.ModTime()
+}
+
+// waitForChange polls the files every interval, until one of them is modified or removed
+func waitForChange(modTimes map[string]time.Time, interval time.Duration) {
+	for {
+		time.Sleep(interval)
+
+		for fn, t := range modTimes {
+			stat, err := os.Stat(fn)
+			if err != nil || !stat.ModTime().Equal(t) {
+				debugPrintln("changed:", fn)
+				return
+			}
+		}
+	}
+}
+
+// loadStruct loads the struct from the program given by args, either one directory, a go import path or a list of files.
+// Also returns a filename for goimports formatting and the default output path.
+func loadStruct(name string, args, tags []string) (*skyencoder.StructInfo, string, string) {
 	// We accept either one directory or a list of files. Which do we have?
-	args := flag.Args()
 	if len(args) == 0 {
 		// Default: process whole package in current directory.
 		args = []string{"."}
@@ -76,14 +648,34 @@ func main() {
 
 	debugPrintln("args:", args)
 
-	structInfo, err := skyencoder.FindStructInfoInProgram(program, *structName)
+	structInfo, err := findStruct(program, name)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmtFilename, destPath, err := packagePaths(args, structInfo)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	return structInfo, fmtFilename, destPath
+}
+
+// findStruct finds a struct in a loaded program
+func findStruct(program *loader.Program, name string) (*skyencoder.StructInfo, error) {
+	structInfo, err := skyencoder.FindStructInfoInProgram(program, name)
 	if err != nil {
-		log.Fatalf("Program did not contain valid struct for name %s: %v", *structName, err)
+		return nil, fmt.Errorf("Program did not contain valid struct for name %s: %v", name, err)
 	}
 	if structInfo == nil {
-		log.Fatal("Program does not contain struct: ", *structName)
+		return nil, fmt.Errorf("Program does not contain struct: %s", name)
 	}
+	return structInfo, nil
+}
 
+// packagePaths returns a filename for goimports formatting and the default output path,
+// for a struct of the program loaded from args
+func packagePaths(args []string, structInfo *skyencoder.StructInfo) (string, string, error) {
 	// Determine if the arg is a directory or multiple files
 	// If it is a directory, construct an artificial filename in that directory for goimports formatting,
 	// otherwise use the first filename specified (they must all be in the same package)
@@ -93,12 +685,12 @@ func main() {
 	stat, err := os.Stat(args[0])
 	if err != nil {
 		if !os.IsNotExist(err) {
-			log.Fatal(err)
+			return "", "", err
 		}
 		// argument is a import path e.g. "github.com/skycoin/skycoin/src/coin"
 		destPath, err = skyencoder.FindDiskPathOfImport(structInfo.Package.Path())
 		if err != nil {
-			log.Fatal(err)
+			return "", "", err
 		}
 		fmtFilename = filepath.Join(structInfo.Package.Path(), "foo123123123123999.go")
 	} else if stat.IsDir() {
@@ -106,58 +698,141 @@ func main() {
 		fmtFilename = filepath.Join(args[0], "foo123123123123999.go")
 	}
 
-	exported := structInfo.Exported
-	if *unexported {
-		exported = false
-	}
+	return fmtFilename, destPath, nil
+}
 
-	src, err := skyencoder.BuildStructEncoder(structInfo, *destPackage, fmtFilename, exported)
+// writeEncoder writes the encoder for a struct to outputFn in outputPth, defaulting to <struct_name>_skyencoder.go,
+// and unless noTest is set, its tests to the same filename with a _test suffix
+func writeEncoder(structInfo *skyencoder.StructInfo, destPackage, fmtFilename, outputPth, outputFn string, exported, noTest bool, buildOpts skyencoder.BuildOptions) error {
+	src, err := skyencoder.BuildStructEncoder(structInfo, destPackage, fmtFilename, exported, buildOpts)
 	if err != nil {
-		log.Fatal("skyencoder.BuildStructEncoder failed: ", err)
+		return fmt.Errorf("skyencoder.BuildStructEncoder failed: %v", err)
 	}
 
 	var testSrc []byte
-	if !*noTest {
-		testSrc, err = skyencoder.BuildStructEncoderTest(structInfo, *destPackage, fmtFilename, exported)
+	if !noTest {
+		testSrc, err = skyencoder.BuildStructEncoderTest(structInfo, destPackage, fmtFilename, exported, buildOpts)
 		if err != nil {
-			log.Fatal("skyencoder.BuildStructEncoderTest failed: ", err)
+			return fmt.Errorf("skyencoder.BuildStructEncoderTest failed: %v", err)
 		}
 	}
 
 	debugPrintln(string(src))
 
-	outputFn := *outputFilename
 	if outputFn == "" {
-		// If the input is a filename, put next to the file
-		// If the input is a package, put in the package
-		outputFn = fmt.Sprintf("%s_skyencoder.go", skyencoder.ToSnakeCase(*structName))
-	}
-
-	outputPth := *outputPath
-	if outputPth == "" {
-		outputPth = destPath
+		outputFn = fmt.Sprintf("%s_skyencoder.go", skyencoder.ToSnakeCase(structInfo.Name))
 	}
 	outputFn = filepath.Join(outputPth, outputFn)
 
 	if !*silent {
-		log.Printf("Writing skyencoder for struct %q to file %q", *structName, outputFn)
+		log.Printf("Writing skyencoder for struct %q to file %q", structInfo.Name, outputFn)
 	}
 
 	if err := ioutil.WriteFile(outputFn, src, 0644); err != nil {
-		log.Fatal("ioutil.WriteFile failed: ", err)
+		return fmt.Errorf("ioutil.WriteFile failed: %v", err)
 	}
 
-	if !*noTest {
+	if !noTest {
 		outputExt := filepath.Ext(outputFn)
 		base := outputFn[:len(outputFn)-len(outputExt)]
 		testOutputFn := fmt.Sprintf("%s_test%s", base, outputExt)
 
 		if !*silent {
-			log.Printf("Writing skyencoder tests for struct %q to file %q", *structName, testOutputFn)
+			log.Printf("Writing skyencoder tests for struct %q to file %q", structInfo.Name, testOutputFn)
 		}
 
 		if err := ioutil.WriteFile(testOutputFn, testSrc, 0644); err != nil {
-			log.Fatal("ioutil.WriteFile failed: ", err)
+			return fmt.Errorf("ioutil.WriteFile failed: %v", err)
 		}
 	}
+
+	return nil
+}
+
+// writeVectors writes the golden test vectors for a struct to testdata/<struct_name>.vectors.json in outputPth,
+// and unless noTest is set, their test to <struct_name>_skyencoder_vectors_test.go
+func writeVectors(structInfo *skyencoder.StructInfo, destPackage, fmtFilename, outputPth string, exported, noTest bool, seed int64, count int) error {
+	vectors, err := skyencoder.BuildStructVectors(structInfo, seed, count)
+	if err != nil {
+		return fmt.Errorf("skyencoder.BuildStructVectors failed: %v", err)
+	}
+
+	vectorsFn := fmt.Sprintf("testdata/%s.vectors.json", structInfo.Name)
+
+	var testSrc []byte
+	if !noTest {
+		testSrc, err = skyencoder.BuildStructVectorsTest(structInfo, destPackage, fmtFilename, vectorsFn, exported)
+		if err != nil {
+			return fmt.Errorf("skyencoder.BuildStructVectorsTest failed: %v", err)
+		}
+	}
+
+	vectorsOutputFn := filepath.Join(outputPth, filepath.FromSlash(vectorsFn))
+
+	if !*silent {
+		log.Printf("Writing golden test vectors for struct %q to file %q", structInfo.Name, vectorsOutputFn)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(vectorsOutputFn), 0755); err != nil {
+		return fmt.Errorf("os.MkdirAll failed: %v", err)
+	}
+
+	if err := ioutil.WriteFile(vectorsOutputFn, vectors, 0644); err != nil {
+		return fmt.Errorf("ioutil.WriteFile failed: %v", err)
+	}
+
+	if !noTest {
+		testOutputFn := filepath.Join(outputPth, fmt.Sprintf("%s_skyencoder_vectors_test.go", skyencoder.ToSnakeCase(structInfo.Name)))
+
+		if !*silent {
+			log.Printf("Writing golden test vectors test for struct %q to file %q", structInfo.Name, testOutputFn)
+		}
+
+		if err := ioutil.WriteFile(testOutputFn, testSrc, 0644); err != nil {
+			return fmt.Errorf("ioutil.WriteFile failed: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// writeTypeScript writes the TypeScript encoder, its golden test vectors and its test script
+func writeTypeScript(structInfo *skyencoder.StructInfo, outputPth string, seed int64, count int) error {
+	base := fmt.Sprintf("%s_skyencoder", skyencoder.ToSnakeCase(structInfo.Name))
+	tsFn := base + ".ts"
+	vectorsFn := base + ".vectors.json"
+	tsTestFn := base + "_test.ts"
+
+	tsSrc, err := skyencoder.BuildStructTypeScript(structInfo)
+	if err != nil {
+		return fmt.Errorf("skyencoder.BuildStructTypeScript failed: %v", err)
+	}
+
+	vectors, err := skyencoder.BuildStructVectors(structInfo, seed, count)
+	if err != nil {
+		return fmt.Errorf("skyencoder.BuildStructVectors failed: %v", err)
+	}
+
+	tsTestSrc := skyencoder.BuildStructTypeScriptTest(structInfo, "./"+base, vectorsFn)
+
+	for _, f := range []struct {
+		fn   string
+		data []byte
+	}{
+		{tsFn, tsSrc},
+		{vectorsFn, vectors},
+		{tsTestFn, tsTestSrc},
+	} {
+		fn := filepath.Join(outputPth, f.fn)
+
+		if !*silent {
+			log.Printf("Writing TypeScript skyencoder for struct %q to file %q", structInfo.Name, fn)
+		}
+
+		if err := ioutil.WriteFile(fn, f.data, 0644); err != nil {
+			return fmt.Errorf("ioutil.WriteFile failed: %v", err)
+		}
+	}
+
+	return nil
 }
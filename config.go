@@ -0,0 +1,116 @@
+package skyencoder
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+// Config lists the packages and structs to generate code for in one run, loaded from a YAML file with -config
+type Config struct {
+	Packages []PackageConfig `yaml:"packages"`
+}
+
+// PackageConfig is a package to load, and the structs of it to generate code for.
+// The package is either a go import path or directory in Path, or a list of files in Files.
+type PackageConfig struct {
+	Path       string         `yaml:"path"`
+	Files      []string       `yaml:"files"`
+	Tags       []string       `yaml:"tags"`
+	Package    string         `yaml:"package"`
+	OutputPath string         `yaml:"output-path"`
+	Structs    []StructConfig `yaml:"structs"`
+}
+
+// StructConfig is a struct to generate code for, with the same options as the command line flags.
+// Package and OutputPath override those of the PackageConfig.
+type StructConfig struct {
+	Struct               string `yaml:"struct"`
+	OutputFile           string `yaml:"output-file"`
+	OutputPath           string `yaml:"output-path"`
+	Package              string `yaml:"package"`
+	Unexported           bool   `yaml:"unexported"`
+	NoTest               bool   `yaml:"no-test"`
+	Vectors              bool   `yaml:"vectors"`
+	VectorsSeed          int64  `yaml:"vectors-seed"`  // 0 for the default seed
+	VectorsCount         int    `yaml:"vectors-count"` // 0 for the default count
+	TypeScript           bool   `yaml:"typescript"`
+	TypeScriptOutputPath string `yaml:"typescript-output-path"`
+	Hash                 bool   `yaml:"hash"`
+	Peek                 bool   `yaml:"peek"`
+	Validate             bool   `yaml:"validate"`
+	Reuse                bool   `yaml:"reuse"`
+	Pooled               bool   `yaml:"pooled"`
+	Framed               bool   `yaml:"framed"`
+	Standalone           bool   `yaml:"standalone"`
+	DebugFormat          bool   `yaml:"debug-format"`
+}
+
+// Args returns the arguments for LoadProgram, defaulting to the package in the current directory
+func (c PackageConfig) Args() []string {
+	if len(c.Files) != 0 {
+		return c.Files
+	}
+	if c.Path != "" {
+		return []string{c.Path}
+	}
+	return []string{"."}
+}
+
+// LoadConfig loads and validates a Config from a YAML file
+func LoadConfig(filename string) (*Config, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	var c Config
+	if err := yaml.UnmarshalStrict(data, &c); err != nil {
+		return nil, err
+	}
+
+	if err := c.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %v", filename, err)
+	}
+
+	return &c, nil
+}
+
+func (c *Config) validate() error {
+	if len(c.Packages) == 0 {
+		return errors.New("no packages listed")
+	}
+
+	// Each package is loaded once, so a package listed twice with the same build tags is an error
+	seen := make(map[string]struct{}, len(c.Packages))
+	for _, p := range c.Packages {
+		if p.Path != "" && len(p.Files) != 0 {
+			return fmt.Errorf("package %q has both path and files", p.Path)
+		}
+
+		args := strings.Join(p.Args(), " ")
+		key := args + "|" + strings.Join(p.Tags, ",")
+		if _, ok := seen[key]; ok {
+			return fmt.Errorf("package %q is listed more than once", args)
+		}
+		seen[key] = struct{}{}
+
+		if len(p.Structs) == 0 {
+			return fmt.Errorf("package %q has no structs", args)
+		}
+
+		for _, s := range p.Structs {
+			if s.Struct == "" {
+				return fmt.Errorf("package %q has a struct with no name", args)
+			}
+			if s.VectorsCount < 0 {
+				return fmt.Errorf("package %q struct %q has a negative vectors-count", args, s.Struct)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package skyencoder
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	// The config used by make generate
+	c, err := LoadConfig("skyencoder.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(c.Packages) != 3 {
+		t.Fatalf("LoadConfig loaded %d packages, expected 3", len(c.Packages))
+	}
+
+	p := c.Packages[2]
+	if p.Path != "github.com/skycoin/skycoin/src/coin" || p.Package != "benchmark" || p.OutputPath != "./benchmark" {
+		t.Fatalf("LoadConfig package wrong: %+v", p)
+	}
+	if len(p.Structs) != 2 || p.Structs[0] != (StructConfig{
+		Struct:      "SignedBlock",
+		Hash:        true,
+		Peek:        true,
+		Validate:    true,
+		Reuse:       true,
+		Pooled:      true,
+		DebugFormat: true,
+	}) || p.Structs[1] != (StructConfig{
+		Struct: "BlockHeader",
+	}) {
+		t.Fatalf("LoadConfig structs wrong: %+v", p.Structs)
+	}
+
+	if args := (PackageConfig{}).Args(); len(args) != 1 || args[0] != "." {
+		t.Fatalf("PackageConfig.Args default is %v", args)
+	}
+}
+
+func TestLoadConfigStructOptions(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+
+	fn := filepath.Join(dir, "skyencoder.yaml")
+	if err := ioutil.WriteFile(fn, []byte(`packages:
+- path: foo
+  structs:
+  - struct: Foo
+    vectors: true
+    vectors-seed: 7
+    vectors-count: 3
+    typescript: true
+    typescript-output-path: ./ts
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := LoadConfig(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s := c.Packages[0].Structs[0]; s != (StructConfig{
+		Struct:               "Foo",
+		Vectors:              true,
+		VectorsSeed:          7,
+		VectorsCount:         3,
+		TypeScript:           true,
+		TypeScriptOutputPath: "./ts",
+	}) {
+		t.Fatalf("LoadConfig struct wrong: %+v", s)
+	}
+}
+
+func TestLoadConfigInvalid(t *testing.T) {
+	cases := []struct {
+		name   string
+		config string
+		err    string
+	}{
+		{
+			name:   "no packages",
+			config: "packages: []",
+			err:    "no packages listed",
+		},
+		{
+			name:   "unknown field",
+			config: "packages:\n- path: foo\n  structs:\n  - struct: Foo\n    hsah: true",
+			err:    "field hsah not found",
+		},
+		{
+			name:   "path and files",
+			config: "packages:\n- path: foo\n  files: [foo.go]\n  structs:\n  - struct: Foo",
+			err:    "has both path and files",
+		},
+		{
+			name:   "duplicate package",
+			config: "packages:\n- path: foo\n  structs:\n  - struct: Foo\n- path: foo\n  structs:\n  - struct: Bar",
+			err:    "is listed more than once",
+		},
+		{
+			name:   "no structs",
+			config: "packages:\n- path: foo",
+			err:    "has no structs",
+		},
+		{
+			name:   "negative vectors count",
+			config: "packages:\n- path: foo\n  structs:\n  - struct: Foo\n    vectors: true\n    vectors-count: -1",
+			err:    "has a negative vectors-count",
+		},
+		{
+			name:   "no struct name",
+			config: "packages:\n- path: foo\n  structs:\n  - output-file: foo.go",
+			err:    "has a struct with no name",
+		},
+	}
+
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			fn := filepath.Join(dir, "skyencoder.yaml")
+			if err := ioutil.WriteFile(fn, []byte(tc.config), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err := LoadConfig(fn)
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("LoadConfig error %v, expected %q", err, tc.err)
+			}
+		})
+	}
+
+	// The same package may be listed twice with different build tags
+	fn := filepath.Join(dir, "skyencoder.yaml")
+	config := "packages:\n- path: foo\n  structs:\n  - struct: Foo\n- path: foo\n  tags: [bar]\n  structs:\n  - struct: Foo"
+	if err := ioutil.WriteFile(fn, []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(fn); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,619 @@
+package skyencoder
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/types"
+	"math"
+	"strconv"
+
+	"github.com/skycoin/skyencoder/runtime"
+)
+
+// StructBinaryToJSON decodes an encoded object of a struct, like DecodeXExact of the generated code,
+// and returns it in the canonical JSON form of golden test vectors (see Vector).
+// Floats which are not finite are the strings "NaN", "+Inf" and "-Inf".
+// If the buffer is not a valid encoding of the object, returns an *ExplainError.
+func StructBinaryToJSON(s *StructInfo, buf []byte) ([]byte, error) {
+	e := &explainer{
+		buf: buf,
+	}
+
+	obj, err := e.walkStruct(s, math.MaxUint64)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.MarshalIndent(obj, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(b, '\n'), nil
+}
+
+// StructJSONToBinary encodes an object of a struct given in the canonical JSON form of golden test vectors (see Vector),
+// like EncodeX of the generated code. 64-bit integers may also be JSON numbers, missing fields and nulls are empty,
+// and the map entries are encoded in the order given.
+func StructJSONToBinary(s *StructInfo, data []byte) ([]byte, error) {
+	options, err := parseDirectives(s.Directives)
+	if err != nil {
+		return nil, err
+	}
+
+	d := json.NewDecoder(bytes.NewReader(data))
+	d.UseNumber()
+
+	var v interface{}
+	if err := d.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	b, err := encodeJSONValue(s.Type, v, "", options)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := findChecksum(s.Type, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if c != nil {
+		sum := c.sum(b)
+		b = append(b, sum[:]...)
+	}
+
+	return b, nil
+}
+
+// jsonFloat is a float in canonical JSON form, a string for values which are not finite
+type jsonFloat float64
+
+// MarshalJSON implements json.Marshaler
+func (f jsonFloat) MarshalJSON() ([]byte, error) {
+	x := float64(f)
+	switch {
+	case math.IsNaN(x):
+		return []byte(`"NaN"`), nil
+	case math.IsInf(x, 1):
+		return []byte(`"+Inf"`), nil
+	case math.IsInf(x, -1):
+		return []byte(`"-Inf"`), nil
+	default:
+		return json.Marshal(x)
+	}
+}
+
+// jsonKey returns a comparable form of a map key in canonical JSON form, which is equal for keys which are equal in Go
+func jsonKey(v interface{}) interface{} {
+	switch v.(type) {
+	case []interface{}, jsonObject, *jsonUnion:
+		b, _ := json.Marshal(v) // nolint: errcheck
+		return string(b)
+	default:
+		return v
+	}
+}
+
+// zeroJSONValue returns the zero value of a type in canonical JSON form
+func zeroJSONValue(t types.Type, path string) (interface{}, error) {
+	switch x := t.(type) {
+	case *types.Named:
+		if isUnion(x) {
+			return nil, nil
+		}
+		return zeroJSONValue(x.Underlying(), path)
+
+	case *types.Basic:
+		switch x.Kind() {
+		case types.Bool:
+			return false, nil
+		case types.Int8, types.Int16, types.Int32, types.Uint8, types.Uint16, types.Uint32:
+			return 0, nil
+		case types.Int64, types.Uint64:
+			return "0", nil
+		case types.Float32, types.Float64:
+			return jsonFloat(0), nil
+		case types.String:
+			return "", nil
+		default:
+			return nil, fmt.Errorf("Unhandled *types.Basic type %s for %s", x.Name(), path)
+		}
+
+	case *types.Array:
+		if isByte(x.Elem()) {
+			return hex.EncodeToString(make([]byte, x.Len())), nil
+		}
+
+		values := make([]interface{}, x.Len())
+		for i := range values {
+			v, err := zeroJSONValue(x.Elem(), fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+			values[i] = v
+		}
+		return values, nil
+
+	case *types.Slice:
+		if isByte(x.Elem()) {
+			return "", nil
+		}
+		return []interface{}{}, nil
+
+	case *types.Map:
+		return []jsonMapEntry{}, nil
+
+	case *types.Struct:
+		order, err := encodedFields(x)
+		if err != nil {
+			return nil, err
+		}
+
+		obj := jsonObject{}
+		for _, i := range order {
+			f := x.Field(i)
+
+			if !f.Exported() {
+				continue
+			}
+
+			ignore, _, err := parseTag(x.Tag(i))
+			if err != nil {
+				return nil, err
+			}
+
+			if ignore {
+				continue
+			}
+
+			v, err := zeroJSONValue(f.Type(), joinExplainPath(path, f.Name()))
+			if err != nil {
+				return nil, err
+			}
+
+			obj = append(obj, jsonField{
+				Name:  f.Name(),
+				Value: v,
+			})
+		}
+		return obj, nil
+
+	default:
+		return nil, fmt.Errorf("Unhandled type %T for %s", x, path)
+	}
+}
+
+// encodeJSONValue encodes a value of type t given in canonical JSON form, like vectorSampler encodes its samples.
+// A nil value is the zero value of the type.
+func encodeJSONValue(t types.Type, v interface{}, path string, options *Options) ([]byte, error) {
+	if options != nil {
+		if options.MaxLength != 0 && !maxLenIsValid(t) {
+			return nil, errors.New("maxlen is only valid for slice, string and map")
+		}
+	}
+
+	// Like the generated encoder, a const field is encoded as its constant if it is the constant or the zero value
+	if options != nil && options.Const != "" {
+		encoded, _, err := constValue(t, nil, options)
+		if err != nil {
+			return nil, err
+		}
+
+		constOptions := *options
+		constOptions.Const = ""
+		b, err := encodeJSONValue(t, v, path, &constOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		if !bytes.Equal(b, encoded) && !bytes.Equal(b, make([]byte, len(b))) {
+			return nil, fmt.Errorf("%s: %v", path, runtime.ErrConstMismatch)
+		}
+
+		return encoded, nil
+	}
+
+	switch x := t.(type) {
+	case *types.Named:
+		if isUnion(x) {
+			return encodeJSONUnion(x, v, path, options)
+		}
+		return encodeJSONValue(x.Underlying(), v, path, options)
+
+	case *types.Basic:
+		switch x.Kind() {
+		case types.Bool:
+			b := false
+			if v != nil {
+				var ok bool
+				if b, ok = v.(bool); !ok {
+					return nil, jsonTypeError(path, "a bool", v)
+				}
+			}
+			if b {
+				return []byte{1}, nil
+			}
+			return []byte{0}, nil
+
+		case types.Int8, types.Int16, types.Int32, types.Int64:
+			size, _, err := fixedEncodedSize(x, nil)
+			if err != nil {
+				return nil, err
+			}
+			s, err := jsonNumberString(path, v)
+			if err != nil {
+				return nil, err
+			}
+			i, err := strconv.ParseInt(s, 10, int(8*size))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", path, err)
+			}
+			return putVectorUint(nil, int(size), uint64(i), options), nil
+
+		case types.Uint8, types.Uint16, types.Uint32, types.Uint64:
+			size, _, err := fixedEncodedSize(x, nil)
+			if err != nil {
+				return nil, err
+			}
+			s, err := jsonNumberString(path, v)
+			if err != nil {
+				return nil, err
+			}
+			u, err := strconv.ParseUint(s, 10, int(8*size))
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", path, err)
+			}
+			return putVectorUint(nil, int(size), u, options), nil
+
+		case types.Float32:
+			s, err := jsonNumberString(path, v)
+			if err != nil {
+				return nil, err
+			}
+			f, err := strconv.ParseFloat(s, 32)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", path, err)
+			}
+			return putVectorUint(nil, 4, uint64(math.Float32bits(float32(f))), options), nil
+
+		case types.Float64:
+			s, err := jsonNumberString(path, v)
+			if err != nil {
+				return nil, err
+			}
+			f, err := strconv.ParseFloat(s, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", path, err)
+			}
+			return putVectorUint(nil, 8, math.Float64bits(f), options), nil
+
+		case types.String:
+			s := ""
+			if v != nil {
+				var ok bool
+				if s, ok = v.(string); !ok {
+					return nil, jsonTypeError(path, "a string", v)
+				}
+			}
+			prefix, err := encodeJSONLength(path, len(s), options)
+			if err != nil {
+				return nil, err
+			}
+			return append(prefix, s...), nil
+
+		default:
+			return nil, fmt.Errorf("Unhandled *types.Basic type %s for %s", x.Name(), path)
+		}
+
+	case *types.Array:
+		if isByte(x.Elem()) {
+			b, err := jsonHexBytes(path, v)
+			if err != nil {
+				return nil, err
+			}
+			if v == nil {
+				b = make([]byte, x.Len())
+			}
+			if int64(len(b)) != x.Len() {
+				return nil, fmt.Errorf("%s: expected %d bytes, got %d", path, x.Len(), len(b))
+			}
+			return b, nil
+		}
+
+		values, err := jsonList(path, v)
+		if err != nil {
+			return nil, err
+		}
+		if v == nil {
+			values = make([]interface{}, x.Len())
+		}
+		if int64(len(values)) != x.Len() {
+			return nil, fmt.Errorf("%s: expected %d elements, got %d", path, x.Len(), len(values))
+		}
+
+		return encodeJSONList(x.Elem(), values, path, nil, options)
+
+	case *types.Slice:
+		if empty, err := isEmptyStruct(x.Elem()); err != nil {
+			return nil, err
+		} else if empty {
+			return nil, fmt.Errorf("A slice of an empty encoded struct is not allowed (%s)", path)
+		}
+
+		if isByte(x.Elem()) {
+			b, err := jsonHexBytes(path, v)
+			if err != nil {
+				return nil, err
+			}
+			prefix, err := encodeJSONLength(path, len(b), options)
+			if err != nil {
+				return nil, err
+			}
+			return append(prefix, b...), nil
+		}
+
+		values, err := jsonList(path, v)
+		if err != nil {
+			return nil, err
+		}
+		prefix, err := encodeJSONLength(path, len(values), options)
+		if err != nil {
+			return nil, err
+		}
+
+		return encodeJSONList(x.Elem(), values, path, prefix, options)
+
+	case *types.Map:
+		entries, err := jsonList(path, v)
+		if err != nil {
+			return nil, err
+		}
+
+		encoded, err := encodeJSONLength(path, len(entries), options)
+		if err != nil {
+			return nil, err
+		}
+
+		seen := make(map[string]struct{}, len(entries))
+		for i, entry := range entries {
+			entryPath := fmt.Sprintf("%s[%d]", path, i)
+			obj, ok := entry.(map[string]interface{})
+			if !ok {
+				return nil, jsonTypeError(entryPath, `an object with "key" and "value"`, entry)
+			}
+			for k := range obj {
+				if k != "key" && k != "value" {
+					return nil, fmt.Errorf("%s: unknown field %q", entryPath, k)
+				}
+			}
+
+			kb, err := encodeJSONValue(x.Key(), obj["key"], entryPath+".key", inheritOptions(options, nil))
+			if err != nil {
+				return nil, err
+			}
+
+			// Keys are compared by their encoding, which is stricter than the generated decoder for float keys
+			if _, ok := seen[string(kb)]; ok {
+				return nil, fmt.Errorf("%s.key: %v", entryPath, runtime.ErrMapDuplicateKeys)
+			}
+			seen[string(kb)] = struct{}{}
+
+			vb, err := encodeJSONValue(x.Elem(), obj["value"], entryPath+".value", inheritOptions(options, nil))
+			if err != nil {
+				return nil, err
+			}
+
+			encoded = append(append(encoded, kb...), vb...)
+		}
+
+		return encoded, nil
+
+	case *types.Struct:
+		var obj map[string]interface{}
+		if v != nil {
+			var ok bool
+			if obj, ok = v.(map[string]interface{}); !ok {
+				return nil, jsonTypeError(path, "an object", v)
+			}
+		}
+
+		var encoded []byte
+		fields := make(map[string]struct{}, x.NumFields())
+		order, err := encodedFields(x)
+		if err != nil {
+			return nil, err
+		}
+
+		parentOptions := options
+		for _, i := range order {
+			f := x.Field(i)
+
+			if !f.Exported() {
+				continue
+			}
+
+			ignore, options, err := parseTag(x.Tag(i))
+			if err != nil {
+				return nil, err
+			}
+
+			if ignore {
+				continue
+			}
+
+			options = inheritOptions(parentOptions, options)
+			fields[f.Name()] = struct{}{}
+
+			fv := obj[f.Name()]
+			b, err := encodeJSONValue(f.Type(), fv, joinExplainPath(path, f.Name()), options)
+			if err != nil {
+				return nil, err
+			}
+
+			// An empty omitempty field is not encoded
+			if options != nil && options.OmitEmpty && isEmptyJSONValue(fv) {
+				continue
+			}
+
+			encoded = append(encoded, b...)
+		}
+
+		for k := range obj {
+			if _, ok := fields[k]; !ok {
+				return nil, fmt.Errorf("%s: unknown field %q", joinExplainPath(path, k), k)
+			}
+		}
+
+		return encoded, nil
+
+	default:
+		return nil, fmt.Errorf("Unhandled type %T for %s", x, path)
+	}
+}
+
+// encodeJSONUnion encodes a value of an interface type given as an object with the "type" and "value" of the value,
+// or nil for a nil value
+func encodeJSONUnion(t *types.Named, v interface{}, path string, options *Options) ([]byte, error) {
+	members, err := findUnion(t, options)
+	if err != nil {
+		return nil, err
+	}
+
+	if v == nil {
+		return []byte{0}, nil
+	}
+
+	obj, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, jsonTypeError(path, `an object with "type" and "value"`, v)
+	}
+	for k := range obj {
+		if k != "type" && k != "value" {
+			return nil, fmt.Errorf("%s: unknown field %q", path, k)
+		}
+	}
+
+	name, ok := obj["type"].(string)
+	if !ok {
+		return nil, jsonTypeError(path+".type", "a string", obj["type"])
+	}
+
+	for _, m := range members {
+		if m.t.Obj().Name() != name {
+			continue
+		}
+
+		b, err := encodeJSONValue(m.t, obj["value"], path+".value", inheritOptions(options, nil))
+		if err != nil {
+			return nil, err
+		}
+
+		return append([]byte{m.tag}, b...), nil
+	}
+
+	return nil, fmt.Errorf("%s.type: %v (%s)", path, runtime.ErrUnknownUnionType, name)
+}
+
+// encodeJSONList encodes the elements of an array or slice, appending their encodings to prefix
+func encodeJSONList(elem types.Type, values []interface{}, path string, prefix []byte, options *Options) ([]byte, error) {
+	encoded := prefix
+	for i, v := range values {
+		b, err := encodeJSONValue(elem, v, fmt.Sprintf("%s[%d]", path, i), inheritOptions(options, nil))
+		if err != nil {
+			return nil, err
+		}
+		encoded = append(encoded, b...)
+	}
+
+	return encoded, nil
+}
+
+// encodeJSONLength encodes the length prefix of a string, slice or map, with the same checks as the generated encoder
+func encodeJSONLength(path string, n int, options *Options) ([]byte, error) {
+	if options != nil && options.Length > 0 && uint64(n) != options.Length {
+		return nil, fmt.Errorf("%s: length must be %d", path, options.Length)
+	}
+
+	if options != nil && options.MaxLength > 0 && uint64(n) > options.MaxLength {
+		return nil, fmt.Errorf("%s: %v", path, runtime.ErrMaxLenExceeded)
+	}
+
+	if uint64(n) > math.MaxUint32 {
+		return nil, fmt.Errorf("%s: length exceeds math.MaxUint32", path)
+	}
+
+	return putVectorLength(nil, n, options), nil
+}
+
+// isEmptyJSONValue returns true if a string, slice or map value is missing or empty
+func isEmptyJSONValue(v interface{}) bool {
+	switch x := v.(type) {
+	case nil:
+		return true
+	case string:
+		return x == ""
+	case []interface{}:
+		return len(x) == 0
+	default:
+		return false
+	}
+}
+
+// jsonNumberString returns a number given as a JSON number or string, "0" for nil
+func jsonNumberString(path string, v interface{}) (string, error) {
+	switch x := v.(type) {
+	case nil:
+		return "0", nil
+	case json.Number:
+		return x.String(), nil
+	case string:
+		return x, nil
+	default:
+		return "", jsonTypeError(path, "a number", v)
+	}
+}
+
+// jsonHexBytes returns the bytes of a hex string, nil for nil
+func jsonHexBytes(path string, v interface{}) ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	s, ok := v.(string)
+	if !ok {
+		return nil, jsonTypeError(path, "a hex string", v)
+	}
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+
+	return b, nil
+}
+
+// jsonList returns the elements of a JSON array, nil for nil
+func jsonList(path string, v interface{}) ([]interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	values, ok := v.([]interface{})
+	if !ok {
+		return nil, jsonTypeError(path, "an array", v)
+	}
+
+	return values, nil
+}
+
+func jsonTypeError(path, expected string, v interface{}) error {
+	if path == "" {
+		path = "object"
+	}
+	return fmt.Errorf("%s: expected %s, got %T", path, expected, v)
+}
@@ -0,0 +1,185 @@
+package skyencoder
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestStructJSONToBinary(t *testing.T) {
+	sInfo := loadExplainTestStruct(t)
+	buf := mustDecodeHex(t, explainTestBuf)
+
+	obj := `{
+		"A": -2,
+		"B": true,
+		"C": [{"X": 258, "Y": "hi"}],
+		"D": [{"key": "k", "value": 5}],
+		"E": "aabb",
+		"F": 7,
+		"G": ""
+	}`
+
+	b, err := StructJSONToBinary(sInfo, []byte(obj))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b, buf) {
+		t.Fatalf("StructJSONToBinary = %x, expected %x", b, buf)
+	}
+
+	j, err := StructBinaryToJSON(sInfo, buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var x, y interface{}
+	if err := json.Unmarshal(j, &x); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(obj), &y); err != nil {
+		t.Fatal(err)
+	}
+	if xb, yb := mustMarshalJSON(t, x), mustMarshalJSON(t, y); xb != yb {
+		t.Fatalf("StructBinaryToJSON = %s, expected %s", xb, yb)
+	}
+
+	// Missing fields are empty
+	b, err = StructJSONToBinary(sInfo, []byte(`{"E": "0000"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := mustDecodeHex(t, "00 00 00000000 00000000 0000 00000000"); !bytes.Equal(b, expected) {
+		t.Fatalf("StructJSONToBinary = %x, expected %x", b, expected)
+	}
+}
+
+func TestStructJSONToBinaryInvalid(t *testing.T) {
+	sInfo := loadExplainTestStruct(t)
+
+	cases := []struct {
+		name string
+		obj  string
+		err  string
+	}{
+		{
+			name: "unknown field",
+			obj:  `{"Z": 1}`,
+			err:  `Z: unknown field "Z"`,
+		},
+		{
+			name: "out of range",
+			obj:  `{"A": 128}`,
+			err:  "A: strconv.ParseInt",
+		},
+		{
+			name: "wrong type",
+			obj:  `{"C": [{"Y": 1}]}`,
+			err:  "C[0].Y: expected a string, got json.Number",
+		},
+		{
+			name: "maxlen exceeded",
+			obj:  `{"C": [{}, {}, {}]}`,
+			err:  "C: Maximum length exceeded",
+		},
+		{
+			name: "byte array length",
+			obj:  `{"E": "aa"}`,
+			err:  "E: expected 2 bytes, got 1",
+		},
+		{
+			name: "invalid hex",
+			obj:  `{"E": "zzzz"}`,
+			err:  "E: encoding/hex",
+		},
+		{
+			name: "duplicate map keys",
+			obj:  `{"D": [{"key": "k", "value": 1}, {"key": "k", "value": 2}]}`,
+			err:  "D[1].key: Duplicate keys",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := StructJSONToBinary(sInfo, []byte(tc.obj))
+			if err == nil {
+				t.Fatal("StructJSONToBinary did not fail")
+			}
+			if !strings.HasPrefix(err.Error(), tc.err) {
+				t.Fatalf("StructJSONToBinary failed with %q, expected %q", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestStructConvertVectors(t *testing.T) {
+	program, err := LoadProgram([]string{"github.com/skycoin/skyencoder/tests"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := filepath.Glob("tests/testdata/*.vectors.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("No vector files found")
+	}
+
+	// Every golden test vector converts both ways
+	for _, fn := range files {
+		b, err := ioutil.ReadFile(fn)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var vf VectorFile
+		if err := json.Unmarshal(b, &vf); err != nil {
+			t.Fatal(err)
+		}
+
+		sInfo, err := FindStructInfoInProgram(program, vf.Struct)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i, v := range vf.Vectors {
+			encoded, err := StructJSONToBinary(sInfo, v.Value)
+			if err != nil {
+				t.Fatalf("StructJSONToBinary of %s vector %d failed: %v", vf.Struct, i, err)
+			}
+			if hex.EncodeToString(encoded) != v.Encoded {
+				t.Fatalf("StructJSONToBinary of %s vector %d = %x, expected %s", vf.Struct, i, encoded, v.Encoded)
+			}
+
+			j, err := StructBinaryToJSON(sInfo, encoded)
+			if err != nil {
+				t.Fatalf("StructBinaryToJSON of %s vector %d failed: %v", vf.Struct, i, err)
+			}
+
+			var x, y bytes.Buffer
+			if err := json.Compact(&x, j); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Compact(&y, v.Value); err != nil {
+				t.Fatal(err)
+			}
+			if x.String() != y.String() {
+				t.Fatalf("StructBinaryToJSON of %s vector %d = %s, expected %s", vf.Struct, i, x.String(), y.String())
+			}
+		}
+	}
+}
+
+func mustMarshalJSON(t *testing.T, v interface{}) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(b)
+}
@@ -0,0 +1,634 @@
+package skyencoder
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go/types"
+	"math"
+	"strconv"
+
+	"github.com/skycoin/skyencoder/runtime"
+)
+
+// Explanation is the layout of an encoded object: the byte range, path and decoded value of each of its fields.
+// If the buffer is not a valid encoding of the object, Err has the offset and path where decoding failed.
+type Explanation struct {
+	Buffer []byte
+	Fields []ExplainedField
+	Err    *ExplainError
+}
+
+// ExplainedField is a decoded value, or the length prefix of a string, slice or map, of an encoded object
+type ExplainedField struct {
+	Offset int
+	Length int
+	// Path is the field path from the object, e.g. "Body[2].Name", with a " (length)" suffix for length prefixes
+	Path  string
+	Value string
+}
+
+// ExplainError is a decoding error and the position where it happened.
+// Err is one of the errors of the generated decoder, e.g. runtime.ErrBufferUnderflow.
+type ExplainError struct {
+	Offset int
+	Path   string
+	Err    error
+}
+
+// Error implements error
+func (e *ExplainError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("offset %d: %v", e.Offset, e.Err)
+	}
+	return fmt.Sprintf("offset %d, %s: %v", e.Offset, e.Path, e.Err)
+}
+
+// ExplainStruct decodes buf as an encoded object of a struct, like DecodeXExact of the generated code,
+// and returns the layout of the fields decoded before any error
+func ExplainStruct(s *StructInfo, buf []byte) (*Explanation, error) {
+	return ExplainStructVersion(s, buf, math.MaxUint64)
+}
+
+// ExplainStructVersion is ExplainStruct for an object encoded at a given version, like DecodeXVersionExact of the generated code.
+// Fields introduced after the version are not read from the buffer.
+func ExplainStructVersion(s *StructInfo, buf []byte, version uint64) (*Explanation, error) {
+	e := &explainer{
+		buf: buf,
+	}
+
+	_, err := e.walkStruct(s, version)
+	return e.explanation(err)
+}
+
+// explainer walks a type like buildCodeSectionDecode, decoding it from a buffer instead of generating code for it
+type explainer struct {
+	buf    []byte
+	offset int
+	fields []ExplainedField
+	// omitted is set when an omitempty field is omitted, which ends the object like it returns from the generated decoder
+	omitted bool
+	// depth is the depth of the decoded value in recursive types
+	depth int
+}
+
+// walkStruct decodes the top level fields of a struct, like buildDecodeVersion, verifies the checksum
+// of the checksum directive, and checks that no bytes remain.
+// Returns the object in canonical JSON form.
+func (e *explainer) walkStruct(s *StructInfo, version uint64) (jsonObject, error) {
+	structOptions, err := parseDirectives(s.Directives)
+	if err != nil {
+		return nil, err
+	}
+
+	obj := jsonObject{}
+	order, err := encodedFields(s.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, i := range order {
+		f := s.Type.Field(i)
+
+		if !f.Exported() {
+			continue
+		}
+
+		ignore, options, err := parseTag(s.Type.Tag(i))
+		if err != nil {
+			return nil, err
+		}
+
+		if ignore {
+			continue
+		}
+
+		var v interface{}
+		if options != nil && options.Since > version {
+			// Fields introduced after the version are left empty
+			v, err = zeroJSONValue(f.Type(), f.Name())
+		} else {
+			v, err = e.walk(f.Type(), f.Name(), inheritOptions(structOptions, options))
+		}
+		if err != nil {
+			return obj, err
+		}
+
+		obj = append(obj, jsonField{
+			Name:  f.Name(),
+			Value: v,
+		})
+	}
+
+	c, err := findChecksum(s.Type, structOptions)
+	if err != nil {
+		return obj, err
+	}
+
+	if c != nil {
+		start := e.offset
+		b, err := e.read(runtime.ChecksumSize, "(checksum)")
+		if err != nil {
+			return obj, err
+		}
+
+		sum := c.sum(e.buf[:start])
+		e.add(start, "(checksum)", hex.EncodeToString(b))
+		if !bytes.Equal(b, sum[:]) {
+			e.offset = start
+			return obj, e.fail("(checksum)", runtime.ErrChecksumMismatch)
+		}
+	}
+
+	if e.offset != len(e.buf) {
+		return obj, e.fail("", runtime.ErrRemainingBytes)
+	}
+
+	return obj, nil
+}
+
+// explanation returns the Explanation of the walk, which ended with err.
+// Decoding errors are returned in the Explanation, other errors are returned.
+func (e *explainer) explanation(err error) (*Explanation, error) {
+	x := &Explanation{
+		Buffer: e.buf,
+		Fields: e.fields,
+	}
+
+	if err != nil {
+		explainErr, ok := err.(*ExplainError)
+		if !ok {
+			return nil, err
+		}
+		x.Err = explainErr
+	}
+
+	return x, nil
+}
+
+func (e *explainer) fail(path string, err error) error {
+	return &ExplainError{
+		Offset: e.offset,
+		Path:   path,
+		Err:    err,
+	}
+}
+
+func (e *explainer) add(offset int, path, value string) {
+	e.fields = append(e.fields, ExplainedField{
+		Offset: offset,
+		Length: e.offset - offset,
+		Path:   path,
+		Value:  value,
+	})
+}
+
+// read reads n bytes
+func (e *explainer) read(n int, path string) ([]byte, error) {
+	if n < 0 || n > len(e.buf)-e.offset {
+		return nil, e.fail(path, runtime.ErrBufferUnderflow)
+	}
+
+	b := e.buf[e.offset : e.offset+n]
+	e.offset += n
+	return b, nil
+}
+
+// uint reads an unsigned integer of size bytes, in big-endian byte order if the be option is set
+func (e *explainer) uint(size int, path string, options *Options) (uint64, error) {
+	b, err := e.read(size, path)
+	if err != nil {
+		return 0, err
+	}
+
+	full := make([]byte, 8)
+	if options != nil && options.BigEndian {
+		copy(full[8-size:], b)
+		return binary.BigEndian.Uint64(full), nil
+	}
+
+	copy(full, b)
+	return binary.LittleEndian.Uint64(full), nil
+}
+
+// length reads the length prefix of a string, slice or map, with the same checks as the generated decoder.
+// Returns false if the field is an omitted omitempty field.
+func (e *explainer) length(path string, options *Options) (int, bool, error) {
+	if options != nil && options.Length > 0 {
+		return int(options.Length), true, nil
+	}
+
+	start := e.offset
+
+	if options != nil && options.OmitEmpty && start == len(e.buf) {
+		e.omitted = true
+		e.add(start, path, "(omitted)")
+		return 0, false, nil
+	}
+
+	ul, err := e.uint(4, path+" (length)", options)
+	if err != nil {
+		return 0, false, err
+	}
+	e.add(start, path+" (length)", strconv.FormatUint(ul, 10))
+
+	length := int(ul)
+	if length < 0 || length > len(e.buf)-e.offset {
+		e.offset = start
+		return 0, false, e.fail(path+" (length)", runtime.ErrBufferUnderflow)
+	}
+
+	if options != nil && options.MaxLength > 0 && uint64(length) > options.MaxLength {
+		e.offset = start
+		return 0, false, e.fail(path+" (length)", runtime.ErrMaxLenExceeded)
+	}
+
+	return length, true, nil
+}
+
+// walk decodes a value of type t, returning the value in canonical JSON form.
+// After an omitted omitempty field, nothing more is decoded and the values are empty.
+func (e *explainer) walk(t types.Type, path string, options *Options) (interface{}, error) {
+	if e.omitted {
+		return zeroJSONValue(t, path)
+	}
+
+	if options != nil {
+		if options.MaxLength != 0 && !maxLenIsValid(t) {
+			return nil, errors.New("maxlen is only valid for slice, string and map")
+		}
+	}
+
+	start := e.offset
+
+	// The value of a const field is decoded like any other value, after checking that it is the encoded constant
+	if options != nil && options.Const != "" {
+		encoded, _, err := constValue(t, nil, options)
+		if err != nil {
+			return nil, err
+		}
+
+		b, err := e.read(len(encoded), path)
+		if err != nil {
+			return nil, err
+		}
+
+		e.offset = start
+		if !bytes.Equal(b, encoded) {
+			return nil, e.fail(path, runtime.ErrConstMismatch)
+		}
+
+		constOptions := *options
+		constOptions.Const = ""
+		return e.walk(t, path, &constOptions)
+	}
+
+	switch x := t.(type) {
+	case *types.Named:
+		if isRecursive(x) {
+			if options != nil && options.MaxDepth > 0 && uint64(e.depth) >= options.MaxDepth {
+				return nil, e.fail(path, runtime.ErrMaxDepthExceeded)
+			}
+
+			e.depth++
+			defer func() {
+				e.depth--
+			}()
+			return e.walk(x.Underlying(), path, recursiveOptions(options))
+		}
+		if isUnion(x) {
+			members, err := findUnion(x, options)
+			if err != nil {
+				return nil, err
+			}
+
+			tag, err := e.read(1, path+" (tag)")
+			if err != nil {
+				return nil, err
+			}
+
+			if tag[0] == 0 {
+				e.add(start, path+" (tag)", "nil")
+				return nil, nil
+			}
+
+			for _, m := range members {
+				if m.tag != tag[0] {
+					continue
+				}
+
+				name := m.t.Obj().Name()
+				e.add(start, path+" (tag)", name)
+
+				v, err := e.walk(m.t, path, inheritOptions(options, nil))
+				if err != nil {
+					return nil, err
+				}
+
+				return &jsonUnion{
+					Type:  name,
+					Value: v,
+				}, nil
+			}
+
+			e.offset = start
+			return nil, e.fail(path+" (tag)", runtime.ErrUnknownUnionTag)
+		}
+		return e.walk(x.Underlying(), path, options)
+
+	case *types.Basic:
+		var v interface{}
+		var value string
+
+		switch x.Kind() {
+		case types.Bool:
+			b, err := e.read(1, path)
+			if err != nil {
+				return nil, err
+			}
+			switch b[0] {
+			case 0:
+				v = false
+			case 1:
+				v = true
+			default:
+				e.offset = start
+				return nil, e.fail(path, runtime.ErrInvalidBool)
+			}
+			value = fmt.Sprint(v)
+		case types.Int8, types.Int16, types.Int32, types.Int64:
+			size, _, err := fixedEncodedSize(x, nil)
+			if err != nil {
+				return nil, err
+			}
+			u, err := e.uint(int(size), path, options)
+			if err != nil {
+				return nil, err
+			}
+			// Sign extend
+			shift := uint(64 - 8*size)
+			i := int64(u<<shift) >> shift
+			value = strconv.FormatInt(i, 10)
+			if size == 8 {
+				v = value
+			} else {
+				v = i
+			}
+		case types.Uint8, types.Uint16, types.Uint32, types.Uint64:
+			size, _, err := fixedEncodedSize(x, nil)
+			if err != nil {
+				return nil, err
+			}
+			u, err := e.uint(int(size), path, options)
+			if err != nil {
+				return nil, err
+			}
+			value = strconv.FormatUint(u, 10)
+			if size == 8 {
+				v = value
+			} else {
+				v = u
+			}
+		case types.Float32:
+			u, err := e.uint(4, path, options)
+			if err != nil {
+				return nil, err
+			}
+			f := math.Float32frombits(uint32(u))
+			v = jsonFloat(f)
+			value = strconv.FormatFloat(float64(f), 'g', -1, 32)
+		case types.Float64:
+			u, err := e.uint(8, path, options)
+			if err != nil {
+				return nil, err
+			}
+			f := math.Float64frombits(u)
+			v = jsonFloat(f)
+			value = strconv.FormatFloat(f, 'g', -1, 64)
+		case types.String:
+			length, ok, err := e.length(path, options)
+			if err != nil {
+				return nil, err
+			} else if !ok {
+				return zeroJSONValue(t, path)
+			}
+			start = e.offset
+			b, err := e.read(length, path)
+			if err != nil {
+				return nil, err
+			}
+			v = string(b)
+			value = strconv.Quote(string(b))
+		default:
+			return nil, fmt.Errorf("Unhandled *types.Basic type %s for %s", x.Name(), path)
+		}
+
+		e.add(start, path, value)
+		return v, nil
+
+	case *types.Array:
+		elem := x.Elem()
+
+		if isByte(elem) {
+			b, err := e.read(int(x.Len()), path)
+			if err != nil {
+				return nil, err
+			}
+			v := hex.EncodeToString(b)
+			e.add(start, path, v)
+			return v, nil
+		}
+
+		return e.walkList(elem, int(x.Len()), path, options)
+
+	case *types.Slice:
+		elem := x.Elem()
+
+		if empty, err := isEmptyStruct(elem); err != nil {
+			return nil, err
+		} else if empty {
+			return nil, fmt.Errorf("A slice of an empty encoded struct is not allowed (%s)", path)
+		}
+
+		length, ok, err := e.length(path, options)
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			return zeroJSONValue(t, path)
+		}
+
+		if isByte(elem) {
+			start = e.offset
+			b, err := e.read(length, path)
+			if err != nil {
+				return nil, err
+			}
+			v := hex.EncodeToString(b)
+			if length != 0 {
+				e.add(start, path, v)
+			}
+			return v, nil
+		}
+
+		return e.walkList(elem, length, path, options)
+
+	case *types.Map:
+		length, ok, err := e.length(path, options)
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			return zeroJSONValue(t, path)
+		}
+
+		entries := make([]jsonMapEntry, 0, length)
+		keys := make(map[interface{}]struct{}, length)
+		for i := 0; i < length; i++ {
+			keyPath := fmt.Sprintf("%s[%d].key", path, i)
+			keyStart := e.offset
+			k, err := e.walk(x.Key(), keyPath, inheritOptions(options, nil))
+			if err != nil {
+				return nil, err
+			}
+
+			if _, ok := keys[jsonKey(k)]; ok {
+				e.offset = keyStart
+				return nil, e.fail(keyPath, runtime.ErrMapDuplicateKeys)
+			}
+			keys[jsonKey(k)] = struct{}{}
+
+			v, err := e.walk(x.Elem(), fmt.Sprintf("%s[%d].value", path, i), inheritOptions(options, nil))
+			if err != nil {
+				return nil, err
+			}
+
+			entries = append(entries, jsonMapEntry{
+				Key:   k,
+				Value: v,
+			})
+		}
+
+		return entries, nil
+
+	case *types.Struct:
+		obj := jsonObject{}
+		parentOptions := options
+		order, err := encodedFields(x)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range order {
+			f := x.Field(i)
+
+			if !f.Exported() {
+				continue
+			}
+
+			ignore, options, err := parseTag(x.Tag(i))
+			if err != nil {
+				return nil, err
+			}
+
+			if ignore {
+				continue
+			}
+
+			options = inheritOptions(parentOptions, options)
+
+			v, err := e.walk(f.Type(), joinExplainPath(path, f.Name()), options)
+			if err != nil {
+				return nil, err
+			}
+
+			obj = append(obj, jsonField{
+				Name:  f.Name(),
+				Value: v,
+			})
+		}
+
+		return obj, nil
+
+	default:
+		return nil, fmt.Errorf("Unhandled type %T for %s", x, path)
+	}
+}
+
+// walkList decodes the n elements of an array or slice
+func (e *explainer) walkList(elem types.Type, n int, path string, options *Options) (interface{}, error) {
+	values := make([]interface{}, n)
+	for i := range values {
+		v, err := e.walk(elem, fmt.Sprintf("%s[%d]", path, i), inheritOptions(options, nil))
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+
+	return values, nil
+}
+
+func joinExplainPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// explainHexdumpWidth is the number of bytes on a line of the hexdump
+const explainHexdumpWidth = 16
+
+// Hexdump formats the explanation as a hexdump annotated with the field of each range of bytes.
+// If decoding failed, the last line shows the error and the remaining bytes.
+func (x *Explanation) Hexdump() []byte {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "%-8s  %6s  %-*s  %s\n", "offset", "length", 3*explainHexdumpWidth-1, "bytes", "field = value")
+
+	for _, f := range x.Fields {
+		data := x.Buffer[f.Offset : f.Offset+f.Length]
+		line := fmt.Sprintf("%s = %s", f.Path, f.Value)
+
+		if len(data) == 0 {
+			fmt.Fprintf(&b, "%08x  %6d  %-*s  %s\n", f.Offset, 0, 3*explainHexdumpWidth-1, "", line)
+			continue
+		}
+
+		for i := 0; i < len(data); i += explainHexdumpWidth {
+			row := data[i:]
+			if len(row) > explainHexdumpWidth {
+				row = row[:explainHexdumpWidth]
+			}
+
+			if i == 0 {
+				fmt.Fprintf(&b, "%08x  %6d  %-*s  %s\n", f.Offset, f.Length, 3*explainHexdumpWidth-1, hexdumpRow(row), line)
+			} else {
+				fmt.Fprintf(&b, "%08x  %6s  %s\n", f.Offset+i, "", hexdumpRow(row))
+			}
+		}
+	}
+
+	if x.Err != nil {
+		remaining := x.Buffer[x.Err.Offset:]
+		row := remaining
+		if len(row) > explainHexdumpWidth {
+			row = row[:explainHexdumpWidth]
+		}
+
+		fmt.Fprintf(&b, "%08x  %6d  %-*s  ERROR: %v\n", x.Err.Offset, len(remaining), 3*explainHexdumpWidth-1, hexdumpRow(row), x.Err)
+	}
+
+	return b.Bytes()
+}
+
+func hexdumpRow(row []byte) string {
+	var b bytes.Buffer
+	for i, c := range row {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		fmt.Fprintf(&b, "%02x", c)
+	}
+	return b.String()
+}
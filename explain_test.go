@@ -0,0 +1,466 @@
+package skyencoder
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/skycoin/skyencoder/runtime"
+)
+
+const explainTestSrc = `package foo
+
+type Inner struct {
+	X uint16
+	Y string
+}
+
+type Foo struct {
+	A int8
+	B bool
+	C []Inner ` + "`enc:\",maxlen=2\"`" + `
+	D map[string]int32
+	E [2]byte
+	F uint32 ` + "`enc:\",be\"`" + `
+	G []byte ` + "`enc:\",omitempty\"`" + `
+}
+`
+
+func loadExplainTestStruct(t *testing.T) *StructInfo {
+	t.Helper()
+
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	fn := filepath.Join(dir, "foo.go")
+	if err := ioutil.WriteFile(fn, []byte(explainTestSrc), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	program, err := LoadProgram([]string{fn}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sInfo, err := FindStructInfoInProgram(program, "Foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return sInfo
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(strings.Join(strings.Fields(s), ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// explainTestBuf is an encoded Foo with G omitted
+const explainTestBuf = `
+fe
+01
+01000000 0201 02000000 6869
+01000000 01000000 6b 05000000
+aabb
+00000007
+`
+
+func TestExplainStruct(t *testing.T) {
+	sInfo := loadExplainTestStruct(t)
+	buf := mustDecodeHex(t, explainTestBuf)
+
+	x, err := ExplainStruct(sInfo, buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if x.Err != nil {
+		t.Fatalf("ExplainStruct failed: %v", x.Err)
+	}
+
+	expected := []ExplainedField{
+		{0, 1, "A", "-2"},
+		{1, 1, "B", "true"},
+		{2, 4, "C (length)", "1"},
+		{6, 2, "C[0].X", "258"},
+		{8, 4, "C[0].Y (length)", "2"},
+		{12, 2, "C[0].Y", `"hi"`},
+		{14, 4, "D (length)", "1"},
+		{18, 4, "D[0].key (length)", "1"},
+		{22, 1, "D[0].key", `"k"`},
+		{23, 4, "D[0].value", "5"},
+		{27, 2, "E", "aabb"},
+		{29, 4, "F", "7"},
+		{33, 0, "G", "(omitted)"},
+	}
+
+	if len(x.Fields) != len(expected) {
+		t.Fatalf("ExplainStruct has %d fields, expected %d: %+v", len(x.Fields), len(expected), x.Fields)
+	}
+	for i, f := range x.Fields {
+		if f != expected[i] {
+			t.Errorf("ExplainStruct field %d is %+v, expected %+v", i, f, expected[i])
+		}
+	}
+
+	dump := string(x.Hexdump())
+	for _, s := range []string{
+		`0000000c       2  68 69`,
+		`C[0].Y = "hi"`,
+		`G = (omitted)`,
+	} {
+		if !strings.Contains(dump, s) {
+			t.Errorf("Hexdump does not contain %q:\n%s", s, dump)
+		}
+	}
+	if strings.Contains(dump, "ERROR") {
+		t.Errorf("Hexdump has an error:\n%s", dump)
+	}
+}
+
+func TestExplainStructInvalid(t *testing.T) {
+	sInfo := loadExplainTestStruct(t)
+	buf := mustDecodeHex(t, explainTestBuf)
+
+	withByte := func(i int, c byte) []byte {
+		b := append([]byte{}, buf...)
+		b[i] = c
+		return b
+	}
+
+	cases := []struct {
+		name   string
+		buf    []byte
+		offset int
+		path   string
+		err    error
+	}{
+		{
+			name:   "truncated",
+			buf:    buf[:25],
+			offset: 23,
+			path:   "D[0].value",
+			err:    runtime.ErrBufferUnderflow,
+		},
+		{
+			name:   "invalid bool",
+			buf:    withByte(1, 2),
+			offset: 1,
+			path:   "B",
+			err:    runtime.ErrInvalidBool,
+		},
+		{
+			name:   "length exceeds buffer",
+			buf:    withByte(8, 0xff),
+			offset: 8,
+			path:   "C[0].Y (length)",
+			err:    runtime.ErrBufferUnderflow,
+		},
+		{
+			name:   "maxlen exceeded",
+			buf:    withByte(2, 3),
+			offset: 2,
+			path:   "C (length)",
+			err:    runtime.ErrMaxLenExceeded,
+		},
+		{
+			name: "duplicate map keys",
+			buf: mustDecodeHex(t, `fe 01 00000000
+				02000000 01000000 6b 05000000 01000000 6b 06000000
+				aabb 00000007`),
+			offset: 19,
+			path:   "D[1].key",
+			err:    runtime.ErrMapDuplicateKeys,
+		},
+		{
+			name:   "remaining bytes",
+			buf:    append(append([]byte{}, buf...), mustDecodeHex(t, "01000000 cc ff")...),
+			offset: 38,
+			path:   "",
+			err:    runtime.ErrRemainingBytes,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			x, err := ExplainStruct(sInfo, tc.buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if x.Err == nil {
+				t.Fatal("ExplainStruct did not fail")
+			}
+
+			if x.Err.Offset != tc.offset || x.Err.Path != tc.path || x.Err.Err != tc.err {
+				t.Fatalf("ExplainStruct failed with %+v, expected offset %d, path %q and error %v", x.Err, tc.offset, tc.path, tc.err)
+			}
+
+			// The fields decoded before the error are kept
+			if len(x.Fields) == 0 || x.Fields[0].Path != "A" {
+				t.Fatalf("ExplainStruct fields are missing: %+v", x.Fields)
+			}
+
+			if dump := x.Hexdump(); !bytes.Contains(dump, []byte("ERROR: "+x.Err.Error())) {
+				t.Fatalf("Hexdump does not show the error:\n%s", dump)
+			}
+		})
+	}
+}
+
+func TestExplainStructMaxDepth(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	fn := filepath.Join(dir, "foo.go")
+	if err := ioutil.WriteFile(fn, []byte(`package foo
+
+type Node struct {
+	A        uint8
+	Children []Node `+"`enc:\",maxdepth=2\"`"+`
+}
+
+type Foo struct {
+	Root Node
+}
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	program, err := LoadProgram([]string{fn}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sInfo, err := FindStructInfoInProgram(program, "Foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Two levels of nodes are decoded, like by the generated decoder
+	x, err := ExplainStruct(sInfo, mustDecodeHex(t, "01 01000000 02 00000000"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x.Err != nil {
+		t.Fatalf("ExplainStruct failed: %v", x.Err)
+	}
+
+	// The third level exceeds the maxdepth
+	x, err = ExplainStruct(sInfo, mustDecodeHex(t, "01 01000000 02 01000000 03 00000000"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x.Err == nil || x.Err.Offset != 10 || x.Err.Path != "Root.Children[0].Children[0]" || x.Err.Err != runtime.ErrMaxDepthExceeded {
+		t.Fatalf("ExplainStruct failed with %+v, expected runtime.ErrMaxDepthExceeded at Root.Children[0].Children[0]", x.Err)
+	}
+}
+
+func TestExplainStructUnion(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	fn := filepath.Join(dir, "foo.go")
+	if err := ioutil.WriteFile(fn, []byte(`package foo
+
+type Payload interface {
+	isPayload()
+}
+
+type Tx struct {
+	Amount uint16
+}
+
+func (Tx) isPayload() {}
+
+//skyencoder:union Payload 7=Tx
+type Foo struct {
+	A       uint8
+	Payload Payload
+}
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	program, err := LoadProgram([]string{fn}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sInfo, err := FindStructInfoInProgram(program, "Foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	x, err := ExplainStruct(sInfo, mustDecodeHex(t, "01 07 0201"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x.Err != nil {
+		t.Fatalf("ExplainStruct failed: %v", x.Err)
+	}
+
+	expected := []ExplainedField{
+		{Offset: 0, Length: 1, Path: "A", Value: "1"},
+		{Offset: 1, Length: 1, Path: "Payload (tag)", Value: "Tx"},
+		{Offset: 2, Length: 2, Path: "Payload.Amount", Value: "258"},
+	}
+	if !reflect.DeepEqual(x.Fields, expected) {
+		t.Fatalf("ExplainStruct fields = %+v, expected %+v", x.Fields, expected)
+	}
+
+	x, err = ExplainStruct(sInfo, mustDecodeHex(t, "01 08 0201"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x.Err == nil || x.Err.Offset != 1 || x.Err.Path != "Payload (tag)" || x.Err.Err != runtime.ErrUnknownUnionTag {
+		t.Fatalf("ExplainStruct failed with %+v, expected runtime.ErrUnknownUnionTag at Payload (tag)", x.Err)
+	}
+}
+
+func TestExplainStructConst(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	fn := filepath.Join(dir, "foo.go")
+	if err := ioutil.WriteFile(fn, []byte(`package foo
+
+type Foo struct {
+	Magic   [2]byte `+"`"+`enc:",const=0xcafe"`+"`"+`
+	Version uint16  `+"`"+`enc:",be,const=3"`+"`"+`
+}
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	program, err := LoadProgram([]string{fn}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sInfo, err := FindStructInfoInProgram(program, "Foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	x, err := ExplainStruct(sInfo, mustDecodeHex(t, "cafe 0003"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x.Err != nil {
+		t.Fatalf("ExplainStruct failed: %v", x.Err)
+	}
+
+	expected := []ExplainedField{
+		{Offset: 0, Length: 2, Path: "Magic", Value: "cafe"},
+		{Offset: 2, Length: 2, Path: "Version", Value: "3"},
+	}
+	if !reflect.DeepEqual(x.Fields, expected) {
+		t.Fatalf("ExplainStruct fields = %+v, expected %+v", x.Fields, expected)
+	}
+
+	x, err = ExplainStruct(sInfo, mustDecodeHex(t, "cafe 0300"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x.Err == nil || x.Err.Offset != 2 || x.Err.Path != "Version" || x.Err.Err != runtime.ErrConstMismatch {
+		t.Fatalf("ExplainStruct failed with %+v, expected runtime.ErrConstMismatch at Version", x.Err)
+	}
+}
+
+func TestExplainStructChecksum(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	fn := filepath.Join(dir, "foo.go")
+	if err := ioutil.WriteFile(fn, []byte(`package foo
+
+//skyencoder:checksum crc32
+type Foo struct {
+	A uint16
+}
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	program, err := LoadProgram([]string{fn}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sInfo, err := FindStructInfoInProgram(program, "Foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	x, err := ExplainStruct(sInfo, mustDecodeHex(t, "0100 be23c258"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x.Err != nil {
+		t.Fatalf("ExplainStruct failed: %v", x.Err)
+	}
+
+	expected := []ExplainedField{
+		{Offset: 0, Length: 2, Path: "A", Value: "1"},
+		{Offset: 2, Length: 4, Path: "(checksum)", Value: "be23c258"},
+	}
+	if !reflect.DeepEqual(x.Fields, expected) {
+		t.Fatalf("ExplainStruct fields = %+v, expected %+v", x.Fields, expected)
+	}
+
+	x, err = ExplainStruct(sInfo, mustDecodeHex(t, "0200 be23c258"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if x.Err == nil || x.Err.Offset != 2 || x.Err.Path != "(checksum)" || x.Err.Err != runtime.ErrChecksumMismatch {
+		t.Fatalf("ExplainStruct failed with %+v, expected runtime.ErrChecksumMismatch at (checksum)", x.Err)
+	}
+}
+
+func TestExplainStructVectors(t *testing.T) {
+	program, err := LoadProgram([]string{"github.com/skycoin/skyencoder/tests"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := filepath.Glob("tests/testdata/*.vectors.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) == 0 {
+		t.Fatal("No vector files found")
+	}
+
+	// Every golden test vector is a valid encoding
+	for _, fn := range files {
+		b, err := ioutil.ReadFile(fn)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var vf VectorFile
+		if err := json.Unmarshal(b, &vf); err != nil {
+			t.Fatal(err)
+		}
+
+		sInfo, err := FindStructInfoInProgram(program, vf.Struct)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for i, v := range vf.Vectors {
+			x, err := ExplainStruct(sInfo, mustDecodeHex(t, v.Encoded))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if x.Err != nil {
+				t.Fatalf("ExplainStruct of %s vector %d failed: %v", vf.Struct, i, x.Err)
+			}
+		}
+	}
+}
@@ -2,7 +2,13 @@ package skyencoder
 
 import (
 	"fmt"
+	"go/ast"
+	"go/scanner"
+	"go/token"
+	"strconv"
 	"strings"
+
+	"github.com/skycoin/skyencoder/runtime"
 )
 
 func cast(typ, name string) string {
@@ -13,276 +19,50 @@ func cast(typ, name string) string {
 type Options struct {
 	OmitEmpty bool
 	MaxLength uint64
+	Since     uint64
+	Length    uint64
+	BigEndian bool
+	NoHash    bool
+	MaxDepth  uint64
+	Order     uint64
+	Const     string
+	// Checksum is set by the checksum directive
+	Checksum string
+	// Unions are set by union directives, and are a pointer so that options can be compared
+	Unions *Unions
+}
+
+// Unions are the concrete types of interface types and their tag bytes, by interface type name,
+// registered with "//skyencoder:union" directives
+type Unions map[string][]UnionMember
+
+// UnionMember is a concrete type of a union and its tag byte.
+// Type is the name of a type in the package of the interface type, prefixed with * for a pointer type.
+type UnionMember struct {
+	Tag  uint8
+	Type string
 }
 
 /* Encode size */
 
-func wrapEncodeSizeFunc(typeName, typePackageName, counterName, funcBody string, exported bool) []byte {
+func wrapEncodeSizeFunc(typeName, typePackageName string, funcs []sizeFunc, steps []sizeStep, exported bool) ([]byte, error) {
 	titledTypeName := strings.Title(typeName)
-	fullTypeName := typeName
-	if typePackageName != "" {
-		fullTypeName = fmt.Sprintf("%s.%s", typePackageName, typeName)
-	}
 
 	exportChar := "E"
 	if !exported {
 		exportChar = "e"
 	}
 
-	return []byte(fmt.Sprintf(`
-// %[5]sncodeSize%[6]s computes the size of an encoded object of type %[1]s
-func %[5]sncodeSize%[6]s(obj *%[4]s) uint64 {
-	%[2]s := uint64(0)
-
-	%[3]s
-
-	return %[2]s
-}
-`, typeName, counterName, funcBody, fullTypeName, exportChar, titledTypeName))
-}
-
-func buildEncodeSizeBool(name, counterName string, options *Options) string {
-	return fmt.Sprintf(`
-		// %[1]s
-		%[2]s++
-	`, name, counterName)
-}
-
-func buildEncodeSizeUint8(name, counterName string, options *Options) string {
-	return fmt.Sprintf(`
-		// %[1]s
-		%[2]s++
-	`, name, counterName)
-}
-
-func buildEncodeSizeUint16(name, counterName string, options *Options) string {
-	return fmt.Sprintf(`
-		// %[1]s
-		%[2]s += 2
-	`, name, counterName)
-}
-
-func buildEncodeSizeUint32(name, counterName string, options *Options) string {
-	return fmt.Sprintf(`
-		// %[1]s
-		%[2]s += 4
-	`, name, counterName)
-}
-
-func buildEncodeSizeUint64(name, counterName string, options *Options) string {
-	return fmt.Sprintf(`
-		// %[1]s
-		%[2]s += 8
-	`, name, counterName)
-}
-
-func buildEncodeSizeInt8(name, counterName string, options *Options) string {
-	return fmt.Sprintf(`
-		// %[1]s
-		%[2]s++
-	`, name, counterName)
-}
-
-func buildEncodeSizeInt16(name, counterName string, options *Options) string {
-	return fmt.Sprintf(`
-		// %[1]s
-		%[2]s += 2
-	`, name, counterName)
-}
-
-func buildEncodeSizeInt32(name, counterName string, options *Options) string {
-	return fmt.Sprintf(`
-		// %[1]s
-		%[2]s += 4
-	`, name, counterName)
-}
-
-func buildEncodeSizeInt64(name, counterName string, options *Options) string {
-	return fmt.Sprintf(`
-		// %[1]s
-		%[2]s += 8
-	`, name, counterName)
-}
-
-func buildEncodeSizeFloat32(name, counterName string, options *Options) string {
-	return fmt.Sprintf(`
-		// %[1]s
-		%[2]s += 4
-	`, name, counterName)
-}
-
-func buildEncodeSizeFloat64(name, counterName string, options *Options) string {
-	return fmt.Sprintf(`
-		// %[1]s
-		%[2]s += 8
-	`, name, counterName)
-}
-
-func buildEncodeSizeString(name, counterName string, options *Options) string {
-	body := fmt.Sprintf(`
-	// %[1]s
-	%[2]s += 4 + uint64(len(%[1]s))
-	`, name, counterName)
-
-	if options != nil && options.OmitEmpty {
-		return fmt.Sprintf(`
-		// omitempty
-		if len(%[1]s) != 0 {
-			%[2]s
-		}
-		`, name, body)
-	}
-
-	return body
-}
-
-func buildEncodeSizeByteArray(name, counterName string, length int64, options *Options) string {
-	return fmt.Sprintf(`
-	// %[1]s
-	%[2]s += %[3]d
-	`, name, counterName, length)
-}
-
-func buildEncodeSizeArray(name, counterName, nextCounterName, elemVarName, elemSection string, length int64, isDynamic bool, options *Options) string {
-	if isDynamic {
-		return fmt.Sprintf(`
-		// %[1]s
-		for _, %[2]s := range %[1]s {
-			%[4]s := uint64(0)
-
-			%[3]s
-
-			%[5]s += %[4]s
-		}
-		`, name, elemVarName, elemSection, nextCounterName, counterName)
-	}
-
-	return fmt.Sprintf(`
-	// %[1]s
-	{
-		%[5]s := uint64(0)
-
-		%[4]s
-
-		%[2]s += %[3]d * %[5]s
-	}
-	`, name, counterName, length, elemSection, nextCounterName)
-}
-
-func buildEncodeSizeByteSlice(name, counterName string, options *Options) string {
-	debugPrintf("buildEncodeSizeByteSlice: name=%s counterName=%s\n", name, counterName)
-
-	body := fmt.Sprintf(`
-	// %[1]s
-	%[2]s += 4 + uint64(len(%[1]s))
-	`, name, counterName)
-
-	if options != nil && options.OmitEmpty {
-		body = fmt.Sprintf(`
-		// omitempty
-		if len(%[1]s) != 0 {
-			%[2]s
-		}
-		`, name, body)
-	}
-
-	return body
-}
-
-func buildEncodeSizeSlice(name, counterName, nextCounterName, elemVarName, elemSection string, isDynamic bool, options *Options) string {
-	var body string
-
-	debugPrintf("buildEncodeSizeSlice: name=%s counterName=%s nextCounterName=%s\n", name, counterName, nextCounterName)
-
-	if isDynamic {
-		body = fmt.Sprintf(`
-		// %[1]s
-		%[2]s += 4
-		for _, %[3]s := range %[1]s {
-			%[5]s := uint64(0)
-
-			%[4]s
-
-			%[2]s += %[5]s
-		}
-		`, name, counterName, elemVarName, elemSection, nextCounterName)
-	} else {
-		body = fmt.Sprintf(`
-		// %[1]s
-		%[2]s += 4
-		{
-			%[4]s := uint64(0)
-
-			%[3]s
-
-			%[2]s += uint64(len(%[1]s)) * %[4]s
-		}
-		`, name, counterName, elemSection, nextCounterName)
-	}
-
-	if options != nil && options.OmitEmpty {
-		body = fmt.Sprintf(`
-		// omitempty
-		if len(%[1]s) != 0 {
-			%[2]s
-		}
-		`, name, body)
-	}
-
-	return body
-}
-
-func buildEncodeSizeMap(name, counterName, nextCounterName, keyVarName, elemVarName, keySection, elemSection string, isDynamicKey, isDynamicElem bool, options *Options) string {
-	var body string
-
-	if isDynamicKey || isDynamicElem {
-		if !isDynamicKey {
-			keyVarName = "_"
-		}
-
-		if !isDynamicElem {
-			elemVarName = "_"
-		}
-
-		body = fmt.Sprintf(`
-		// %[1]s
-		%[2]s += 4
-		for %[3]s, %[4]s := range %[1]s {
-			%[7]s := uint64(0)
-
-			%[5]s
-
-			%[6]s
-
-			%[2]s += %[7]s
-		}
-		`, name, counterName, keyVarName, elemVarName, keySection, elemSection, nextCounterName)
-	} else {
-		body = fmt.Sprintf(`
-		// %[1]s
-		%[2]s += 4
-		{
-			%[5]s := uint64(0)
-
-			%[3]s
-
-			%[4]s
-
-			%[2]s += uint64(len(%[1]s)) * %[5]s
-		}
-		`, name, counterName, keySection, elemSection, nextCounterName)
-	}
-
-	if options != nil && options.OmitEmpty {
-		return fmt.Sprintf(`
-		// omitempty
-		if len(%[1]s) != 0 {
-			%[2]s
-		}
-		`, name, body)
+	funcName := fmt.Sprintf("%sncodeSize%s", exportChar, titledTypeName)
+	src, err := renderSizeFunc(funcName, []*ast.Field{objParam(typeName, typePackageName)}, funcs, steps)
+	if err != nil {
+		return nil, err
 	}
 
-	return body
+	return []byte(fmt.Sprintf(`
+// %[2]s computes the size of an encoded object of type %[1]s
+%[3]s
+`, typeName, funcName, src)), nil
 }
 
 /* Encode */
@@ -331,6 +111,87 @@ func %[4]sncode%[5]sToBuffer(buf []byte, obj *%[3]s) error {
 `, typeName, funcBody, fullTypeName, exportChar, titledTypeName))
 }
 
+func wrapEncodePooledFunc(typeName, typePackageName string, exported bool) []byte {
+	titledTypeName := strings.Title(typeName)
+	fullTypeName := typeName
+	if typePackageName != "" {
+		fullTypeName = fmt.Sprintf("%s.%s", typePackageName, fullTypeName)
+	}
+
+	exportChar := "E"
+	if !exported {
+		exportChar = "e"
+	}
+
+	return []byte(fmt.Sprintf(`
+// %[3]sncode%[4]sPooled encodes an object of type %[1]s to a buffer from a pool of buffers,
+// sized to the exact size required to encode the object.
+// Call Release on the buffer when its bytes are no longer used, to return it to the pool.
+func %[3]sncode%[4]sPooled(obj *%[2]s) (*runtime.Buffer, error) {
+	n := %[3]sncodeSize%[4]s(obj)
+	buf := runtime.GetBuffer(n)
+
+	if err := %[3]sncode%[4]sToBuffer(buf.Bytes(), obj); err != nil {
+		buf.Release()
+		return nil, err
+	}
+
+	return buf, nil
+}
+`, typeName, fullTypeName, exportChar, titledTypeName))
+}
+
+func wrapFramedFuncs(typeName, typePackageName string, exported bool) []byte {
+	titledTypeName := strings.Title(typeName)
+	fullTypeName := typeName
+	if typePackageName != "" {
+		fullTypeName = fmt.Sprintf("%s.%s", typePackageName, fullTypeName)
+	}
+
+	exportChar := "E"
+	writeFunc := "WriteFramed"
+	readFunc := "ReadFramed"
+	decodeFunc := "Decode"
+	if !exported {
+		exportChar = "e"
+		writeFunc = "writeFramed"
+		readFunc = "readFramed"
+		decodeFunc = "decode"
+	}
+
+	return []byte(fmt.Sprintf(`
+// %[5]s%[4]s writes an object of type %[1]s to w as a frame, its encoding prefixed with its length as a uint32.
+// If the encoding is longer than the uint32 prefix allows, returns runtime.ErrFrameTooLarge.
+func %[5]s%[4]s(w io.Writer, obj *%[2]s) error {
+	n := %[3]sncodeSize%[4]s(obj)
+	frame, err := runtime.NewFrame(n)
+	if err != nil {
+		return err
+	}
+
+	if err := %[3]sncode%[4]sToBuffer(frame[runtime.FrameHeaderSize:], obj); err != nil {
+		return err
+	}
+
+	_, err = w.Write(frame)
+	return err
+}
+
+// %[6]s%[4]s reads a frame written by %[5]s%[4]s from r and decodes an object of type %[1]s from it.
+// If the frame's length exceeds maxFrame, returns runtime.ErrFrameTooLarge without reading the frame.
+// The frame must contain exactly one encoded object, otherwise returns the error of %[7]s%[4]sExact.
+// Returns io.EOF if r ends before the frame, and io.ErrUnexpectedEOF if it ends within the frame.
+func %[6]s%[4]s(r io.Reader, obj *%[2]s, maxFrame uint32) error {
+	payload, err := runtime.ReadFrame(r, maxFrame)
+	if err != nil {
+		return err
+	}
+
+	return %[7]s%[4]sExact(payload, obj)
+}
+`, typeName, fullTypeName, exportChar, titledTypeName, writeFunc, readFunc, decodeFunc))
+}
+
 func buildEncodeBool(name string, castType bool, options *Options) string {
 	castName := name
 	if castType {
@@ -360,8 +221,8 @@ func buildEncodeUint16(name string, castType bool, options *Options) string {
 	}
 	return fmt.Sprintf(`
 	// %[1]s
-	e.Uint16(%[2]s)
-	`, name, castName)
+	%[2]s
+	`, name, encodeInt("Uint16", castName, options))
 }
 
 func buildEncodeUint32(name string, castType bool, options *Options) string {
@@ -371,8 +232,8 @@ func buildEncodeUint32(name string, castType bool, options *Options) string {
 	}
 	return fmt.Sprintf(`
 	// %[1]s
-	e.Uint32(%[2]s)
-	`, name, castName)
+	%[2]s
+	`, name, encodeInt("Uint32", castName, options))
 }
 
 func buildEncodeUint64(name string, castType bool, options *Options) string {
@@ -382,8 +243,8 @@ func buildEncodeUint64(name string, castType bool, options *Options) string {
 	}
 	return fmt.Sprintf(`
 	// %[1]s
-	e.Uint64(%[2]s)
-	`, name, castName)
+	%[2]s
+	`, name, encodeInt("Uint64", castName, options))
 }
 
 func buildEncodeInt8(name string, castType bool, options *Options) string {
@@ -404,8 +265,8 @@ func buildEncodeInt16(name string, castType bool, options *Options) string {
 	}
 	return fmt.Sprintf(`
 	// %[1]s
-	e.Int16(%[2]s)
-	`, name, castName)
+	%[2]s
+	`, name, encodeInt("Int16", castName, options))
 }
 
 func buildEncodeInt32(name string, castType bool, options *Options) string {
@@ -415,8 +276,8 @@ func buildEncodeInt32(name string, castType bool, options *Options) string {
 	}
 	return fmt.Sprintf(`
 	// %[1]s
-	e.Int32(%[2]s)
-	`, name, castName)
+	%[2]s
+	`, name, encodeInt("Int32", castName, options))
 }
 
 func buildEncodeInt64(name string, castType bool, options *Options) string {
@@ -426,8 +287,8 @@ func buildEncodeInt64(name string, castType bool, options *Options) string {
 	}
 	return fmt.Sprintf(`
 	// %[1]s
-	e.Int64(%[2]s)
-	`, name, castName)
+	%[2]s
+	`, name, encodeInt("Int64", castName, options))
 }
 
 func buildEncodeFloat32(name string, castType bool, options *Options) string {
@@ -437,8 +298,8 @@ func buildEncodeFloat32(name string, castType bool, options *Options) string {
 	}
 	return fmt.Sprintf(`
 	// %[1]s
-	e.Uint32(math.Float32bits(%[2]s))
-	`, name, castName)
+	%[2]s
+	`, name, encodeInt("Uint32", fmt.Sprintf("math.Float32bits(%s)", castName), options))
 }
 
 func buildEncodeFloat64(name string, castType bool, options *Options) string {
@@ -448,11 +309,20 @@ func buildEncodeFloat64(name string, castType bool, options *Options) string {
 	}
 	return fmt.Sprintf(`
 	// %[1]s
-	e.Uint64(math.Float64bits(%[2]s))
-	`, name, castName)
+	%[2]s
+	`, name, encodeInt("Uint64", fmt.Sprintf("math.Float64bits(%s)", castName), options))
 }
 
 func buildEncodeString(name string, options *Options) string {
+	if options != nil && options.Length > 0 {
+		return fmt.Sprintf(`
+		%[2]s
+
+		// %[1]s
+		e.CopyBytes([]byte(%[1]s))
+		`, name, encodeLengthCheck(name, options))
+	}
+
 	body := fmt.Sprintf(`
 	%[2]s
 
@@ -461,9 +331,8 @@ func buildEncodeString(name string, options *Options) string {
 		return errors.New("%[1]s length exceeds math.MaxUint32")
 	}
 
-	// %[1]s
-	e.ByteSlice([]byte(%[1]s))
-	`, name, encodeMaxLengthCheck(name, options))
+	%[3]s
+	`, name, encodeMaxLengthCheck(name, options), encodeStringBytes(name, options))
 
 	if options != nil && options.OmitEmpty {
 		return fmt.Sprintf(`
@@ -484,7 +353,20 @@ func buildEncodeByteArray(name string, options *Options) string {
 	`, name)
 }
 
+// byteOrder returns the name of the encoding/binary byte order
+func byteOrder(bigEndian bool) string {
+	if bigEndian {
+		return "BigEndian"
+	}
+	return "LittleEndian"
+}
+
 func buildEncodeArray(name, elemVarName, elemSection string, options *Options) string {
+	if elemSection == "" {
+		// The elements are empty structs, which are not encoded
+		return ""
+	}
+
 	return fmt.Sprintf(`
 	// %[1]s
 	for _, %[2]s := range %[1]s {
@@ -494,6 +376,10 @@ func buildEncodeArray(name, elemVarName, elemSection string, options *Options) s
 }
 
 func buildEncodeByteSlice(name string, options *Options) string {
+	if options != nil && options.Length > 0 {
+		return encodeLengthCheck(name, options) + buildEncodeByteArray(name, options)
+	}
+
 	body := fmt.Sprintf(`
 	%[2]s
 
@@ -503,11 +389,11 @@ func buildEncodeByteSlice(name string, options *Options) string {
 	}
 
 	// %[1]s length
-	e.Uint32(uint32(len(%[1]s)))
+	%[3]s
 
 	// %[1]s copy
 	e.CopyBytes(%[1]s)
-	`, name, encodeMaxLengthCheck(name, options))
+	`, name, encodeMaxLengthCheck(name, options), encodeLength(name, options))
 
 	if options != nil && options.OmitEmpty {
 		return fmt.Sprintf(`
@@ -522,6 +408,10 @@ func buildEncodeByteSlice(name string, options *Options) string {
 }
 
 func buildEncodeSlice(name, elemVarName, elemSection string, options *Options) string {
+	if options != nil && options.Length > 0 {
+		return encodeLengthCheck(name, options) + buildEncodeArray(name, elemVarName, elemSection, options)
+	}
+
 	body := fmt.Sprintf(`
 	%[4]s
 
@@ -531,13 +421,13 @@ func buildEncodeSlice(name, elemVarName, elemSection string, options *Options) s
 	}
 
 	// %[1]s length
-	e.Uint32(uint32(len(%[1]s)))
+	%[5]s
 
 	// %[1]s
 	for _, %[2]s := range %[1]s {
 		%[3]s
 	}
-	`, name, elemVarName, elemSection, encodeMaxLengthCheck(name, options))
+	`, name, elemVarName, elemSection, encodeMaxLengthCheck(name, options), encodeLength(name, options))
 
 	if options != nil && options.OmitEmpty {
 		return fmt.Sprintf(`
@@ -551,18 +441,15 @@ func buildEncodeSlice(name, elemVarName, elemSection string, options *Options) s
 	return body
 }
 
-func buildEncodeMap(name, keyVarName, elemVarName, keySection, elemSection string, options *Options) string {
-	if keySection == "" {
-		keyVarName = "_"
-	}
-	if elemSection == "" {
-		elemVarName = "_"
+func buildEncodeBulkSlice(name, bulkSection string, options *Options) string {
+	if options != nil && options.Length > 0 {
+		return encodeLengthCheck(name, options) + fmt.Sprintf(`
+		// %[1]s
+		%[2]s`, name, bulkSection)
 	}
 
 	body := fmt.Sprintf(`
-	// %[1]s
-
-	%[6]s
+	%[3]s
 
 	// %[1]s length check
 	if uint64(len(%[1]s)) > math.MaxUint32 {
@@ -570,14 +457,71 @@ func buildEncodeMap(name, keyVarName, elemVarName, keySection, elemSection strin
 	}
 
 	// %[1]s length
-	e.Uint32(uint32(len(%[1]s)))
+	%[4]s
 
-	for %[2]s, %[3]s := range %[1]s {
-		%[4]s
+	// %[1]s
+	%[2]s
+	`, name, bulkSection, encodeMaxLengthCheck(name, options), encodeLength(name, options))
 
-		%[5]s
-	}
-	`, name, keyVarName, elemVarName, keySection, elemSection, encodeMaxLengthCheck(name, options))
+	if options != nil && options.OmitEmpty {
+		return fmt.Sprintf(`
+			// omitempty
+			if len(%[1]s) != 0 {
+				%[2]s
+			}
+		`, name, body)
+	}
+
+	return body
+}
+
+// buildEncodeBulk writes a numeric slice to the encoder's buffer with a runtime.Put function
+func buildEncodeBulk(slice, put string, size int) string {
+	return fmt.Sprintf(`{
+	n := %[3]d * len(%[1]s)
+	runtime.%[2]s(e.Buffer[:n], %[1]s)
+	e.Buffer = e.Buffer[n:]
+	}`, slice, put, size)
+}
+
+// buildEncodeBulkByteArrays writes a slice of byte arrays to the encoder's buffer
+func buildEncodeBulkByteArrays(slice string, size int64) string {
+	return fmt.Sprintf(`{
+	b := e.Buffer[:%[2]d*len(%[1]s)]
+	for i := range %[1]s {
+		copy(b[%[2]d*i:], %[1]s[i][:])
+	}
+	e.Buffer = e.Buffer[len(b):]
+	}`, slice, size)
+}
+
+func buildEncodeMap(name, keyVarName, elemVarName, keySection, elemSection string, options *Options) string {
+	if keySection == "" {
+		keyVarName = "_"
+	}
+	if elemSection == "" {
+		elemVarName = "_"
+	}
+
+	body := fmt.Sprintf(`
+	// %[1]s
+
+	%[6]s
+
+	// %[1]s length check
+	if uint64(len(%[1]s)) > math.MaxUint32 {
+		return errors.New("%[1]s length exceeds math.MaxUint32")
+	}
+
+	// %[1]s length
+	%[7]s
+
+	for %[2]s, %[3]s := range %[1]s {
+		%[4]s
+
+		%[5]s
+	}
+	`, name, keyVarName, elemVarName, keySection, elemSection, encodeMaxLengthCheck(name, options), encodeLength(name, options))
 
 	if options != nil && options.OmitEmpty {
 		return fmt.Sprintf(`
@@ -591,6 +535,57 @@ func buildEncodeMap(name, keyVarName, elemVarName, keySection, elemSection strin
 	return body
 }
 
+func encodeStringBytes(name string, options *Options) string {
+	if options != nil && options.BigEndian {
+		return fmt.Sprintf(`
+		// %[1]s length
+		%[2]s
+
+		// %[1]s
+		e.CopyBytes([]byte(%[1]s))
+		`, name, encodeLength(name, options))
+	}
+
+	return fmt.Sprintf(`
+	// %[1]s
+	e.ByteSlice([]byte(%[1]s))
+	`, name)
+}
+
+func encodeLength(name string, options *Options) string {
+	return encodeInt("Uint32", fmt.Sprintf("uint32(len(%s))", name), options)
+}
+
+// encodeInt returns the code which writes value with the encoder method of the same name (e.g. "Uint32"),
+// in big-endian byte order if the be option is set
+func encodeInt(method, value string, options *Options) string {
+	if options != nil && options.BigEndian {
+		size, uintMethod := intSize(method)
+		if uintMethod != method {
+			value = cast(strings.ToLower(uintMethod), value)
+		}
+		// Writing the byte-reversed value in little-endian byte order writes the value in big-endian byte order,
+		// using only the methods that the encoder shares with runtime.HashEncoder
+		return fmt.Sprintf("e.%[1]s(bits.ReverseBytes%[2]d(%[3]s))", uintMethod, 8*size, value)
+	}
+
+	return fmt.Sprintf("e.%s(%s)", method, value)
+}
+
+// intSize returns the encoded size of an integer encoder method and the unsigned method of the same size
+func intSize(method string) (int, string) {
+	switch method {
+	case "Uint16", "Int16":
+		return 2, "Uint16"
+	case "Uint32", "Int32":
+		return 4, "Uint32"
+	case "Uint64", "Int64":
+		return 8, "Uint64"
+	default:
+		panic(fmt.Sprintf("intSize unhandled method %s", method))
+	}
+}
+
 func encodeMaxLengthCheck(name string, options *Options) string {
 	if options != nil && options.MaxLength > 0 {
 		return fmt.Sprintf(`
@@ -604,6 +599,19 @@ func encodeMaxLengthCheck(name string, options *Options) string {
 	return ""
 }
 
+func encodeLengthCheck(name string, options *Options) string {
+	if options != nil && options.Length > 0 {
+		return fmt.Sprintf(`
+		// %[1]s len check
+		if len(%[1]s) != %[2]d {
+			return errors.New("%[1]s length must be %[2]d")
+		}
+		`, name, options.Length)
+	}
+
+	return ""
+}
+
 /* Decode */
 
 func wrapDecodeFunc(typeName, typePackageName, funcBody string, exported bool) []byte {
@@ -647,6 +655,51 @@ func %[4]secode%[5]sExact(buf []byte, obj *%[3]s) error {
 `, typeName, funcBody, fullTypeName, exportChar, titledTypeName))
 }
 
+func wrapDecodeReuseFunc(typeName, typePackageName, funcBody string, exported bool) []byte {
+	titledTypeName := strings.Title(typeName)
+	fullTypeName := typeName
+	if typePackageName != "" {
+		fullTypeName = fmt.Sprintf("%s.%s", typePackageName, typeName)
+	}
+
+	exportChar := "D"
+	if !exported {
+		exportChar = "d"
+	}
+
+	return []byte(fmt.Sprintf(`
+// %[4]secode%[5]sReuse decodes an object of type %[1]s from a buffer into an existing object.
+// Slices are resliced when their capacity is large enough, maps are cleared and refilled,
+// strings are kept when unchanged and omitted omitempty fields are reset to empty,
+// so that decoding repeatedly into the same object does not allocate in the steady state.
+// Returns the number of bytes used from the buffer to decode the object.
+// If the buffer not long enough to decode the object, returns encoder.ErrBufferUnderflow.
+func %[4]secode%[5]sReuse(buf []byte, obj *%[3]s) (uint64, error) {
+	d := &encoder.Decoder{
+		Buffer: buf[:],
+	}
+
+	%[2]s
+
+	return uint64(len(buf) - len(d.Buffer)), nil
+}
+
+// %[4]secode%[5]sReuseExact decodes an object of type %[1]s from a buffer into an existing object,
+// like %[4]secode%[5]sReuse.
+// If the buffer not long enough to decode the object, returns encoder.ErrBufferUnderflow.
+// If the buffer is longer than required to decode the object, returns encoder.ErrRemainingBytes.
+func %[4]secode%[5]sReuseExact(buf []byte, obj *%[3]s) error {
+	if n, err := %[4]secode%[5]sReuse(buf, obj); err != nil {
+		return err
+	} else if n != uint64(len(buf)) {
+		return encoder.ErrRemainingBytes
+	}
+
+	return nil
+}
+`, typeName, funcBody, fullTypeName, exportChar, titledTypeName))
+}
+
 func buildDecodeBool(name string, castType bool, typeName string, options *Options) string {
 	assign := "i"
 	if castType {
@@ -685,13 +738,10 @@ func buildDecodeUint16(name string, castType bool, typeName string, options *Opt
 	}
 	return fmt.Sprintf(`{
 	// %[1]s
-	i, err := d.Uint16()
-	if err != nil {
-		return 0, err
-	}
+	%[3]s
 	%[1]s = %[2]s
 	}
-	`, name, assign)
+	`, name, assign, decodeInt("i", "Uint16", options))
 }
 
 func buildDecodeUint32(name string, castType bool, typeName string, options *Options) string {
@@ -701,13 +751,10 @@ func buildDecodeUint32(name string, castType bool, typeName string, options *Opt
 	}
 	return fmt.Sprintf(`{
 	// %[1]s
-	i, err := d.Uint32()
-	if err != nil {
-		return 0, err
-	}
+	%[3]s
 	%[1]s = %[2]s
 	}
-	`, name, assign)
+	`, name, assign, decodeInt("i", "Uint32", options))
 }
 
 func buildDecodeUint64(name string, castType bool, typeName string, options *Options) string {
@@ -717,13 +764,10 @@ func buildDecodeUint64(name string, castType bool, typeName string, options *Opt
 	}
 	return fmt.Sprintf(`{
 	// %[1]s
-	i, err := d.Uint64()
-	if err != nil {
-		return 0, err
-	}
+	%[3]s
 	%[1]s = %[2]s
 	}
-	`, name, assign)
+	`, name, assign, decodeInt("i", "Uint64", options))
 }
 
 func buildDecodeInt8(name string, castType bool, typeName string, options *Options) string {
@@ -749,13 +793,10 @@ func buildDecodeInt16(name string, castType bool, typeName string, options *Opti
 	}
 	return fmt.Sprintf(`{
 	// %[1]s
-	i, err := d.Int16()
-	if err != nil {
-		return 0, err
-	}
+	%[3]s
 	%[1]s = %[2]s
 	}
-	`, name, assign)
+	`, name, assign, decodeInt("i", "Int16", options))
 }
 
 func buildDecodeInt32(name string, castType bool, typeName string, options *Options) string {
@@ -765,13 +806,10 @@ func buildDecodeInt32(name string, castType bool, typeName string, options *Opti
 	}
 	return fmt.Sprintf(`{
 	// %[1]s
-	i, err := d.Int32()
-	if err != nil {
-		return 0, err
-	}
+	%[3]s
 	%[1]s = %[2]s
 	}
-	`, name, assign)
+	`, name, assign, decodeInt("i", "Int32", options))
 }
 
 func buildDecodeInt64(name string, castType bool, typeName string, options *Options) string {
@@ -781,13 +819,10 @@ func buildDecodeInt64(name string, castType bool, typeName string, options *Opti
 	}
 	return fmt.Sprintf(`{
 	// %[1]s
-	i, err := d.Int64()
-	if err != nil {
-		return 0, err
-	}
+	%[3]s
 	%[1]s = %[2]s
 	}
-	`, name, assign)
+	`, name, assign, decodeInt("i", "Int64", options))
 }
 
 func buildDecodeFloat32(name string, castType bool, typeName string, options *Options) string {
@@ -797,13 +832,10 @@ func buildDecodeFloat32(name string, castType bool, typeName string, options *Op
 	}
 	return fmt.Sprintf(`{
 	// %[1]s
-	i, err := d.Uint32()
-	if err != nil {
-		return 0, err
-	}
+	%[3]s
 	%[1]s = math.Float32frombits(%[2]s)
 	}
-	`, name, assign)
+	`, name, assign, decodeInt("i", "Uint32", options))
 }
 
 func buildDecodeFloat64(name string, castType bool, typeName string, options *Options) string {
@@ -813,25 +845,35 @@ func buildDecodeFloat64(name string, castType bool, typeName string, options *Op
 	}
 	return fmt.Sprintf(`{
 	// %[1]s
-	i, err := d.Uint64()
-	if err != nil {
-		return 0, err
-	}
+	%[3]s
 	%[1]s = math.Float64frombits(%[2]s)
 	}
-	`, name, assign)
+	`, name, assign, decodeInt("i", "Uint64", options))
 }
 
-func buildDecodeString(name string, options *Options) string {
+func buildDecodeString(name string, reuse bool, options *Options) string {
+	if options != nil && options.Length > 0 {
+		return fmt.Sprintf(`{
+		// %[1]s
+		if len(d.Buffer) < %[2]d {
+			return 0, encoder.ErrBufferUnderflow
+		}
+		%[3]s
+		d.Buffer = d.Buffer[%[2]d:]
+		}`, name, options.Length, decodeAssignString(name, fmt.Sprint(options.Length), reuse))
+	}
+
+	omitEmptyCheck := decodeOmitEmptyCheck(options)
+	if reuse {
+		omitEmptyCheck = decodeOmitEmptyResetCheck(fmt.Sprintf(`%s = ""`, name), options)
+	}
+
 	return fmt.Sprintf(`{
 	// %[1]s
 
 	%[3]s
 
-	ul, err := d.Uint32()
-	if err != nil {
-		return 0, err
-	}
+	%[4]s
 
 	length := int(ul)
 	if length < 0 || length > len(d.Buffer) {
@@ -840,9 +882,21 @@ func buildDecodeString(name string, options *Options) string {
 
 	%[2]s
 
-	%[1]s = string(d.Buffer[:length])
+	%[5]s
 	d.Buffer = d.Buffer[length:]
-	}`, name, decodeMaxLengthCheck(options), decodeOmitEmptyCheck(options))
+	}`, name, decodeMaxLengthCheck(options), omitEmptyCheck, decodeInt("ul", "Uint32", options), decodeAssignString(name, "length", reuse))
+}
+
+// decodeAssignString returns the code which assigns the next length bytes of the buffer to a string.
+// If reuse is true, a string equal to the bytes is kept, to avoid allocating a new string.
+func decodeAssignString(name, length string, reuse bool) string {
+	if reuse {
+		return fmt.Sprintf(`if string(d.Buffer[:%[2]s]) != %[1]s {
+			%[1]s = string(d.Buffer[:%[2]s])
+		}`, name, length)
+	}
+
+	return fmt.Sprintf("%s = string(d.Buffer[:%s])", name, length)
 }
 
 func buildDecodeByteArray(name string, options *Options) string {
@@ -858,6 +912,11 @@ func buildDecodeByteArray(name string, options *Options) string {
 }
 
 func buildDecodeArray(name, elemCounterName, elemVarName, elemSection string, options *Options) string {
+	if elemSection == "" {
+		// The elements are empty structs, which are not decoded
+		return ""
+	}
+
 	return fmt.Sprintf(`{
 	// %[1]s
 	for %[2]s := range %[1]s {
@@ -867,17 +926,42 @@ func buildDecodeArray(name, elemCounterName, elemVarName, elemSection string, op
 	`, name, elemCounterName, elemVarName, elemSection)
 }
 
-func buildDecodeByteSlice(name string, options *Options) string {
-	return fmt.Sprintf(`{
+func buildDecodeByteSlice(name string, reuse bool, options *Options) string {
+	if options != nil && options.Length > 0 {
+		return fmt.Sprintf(`
+		%[1]s
+		%[2]s`, decodeMakeSlice(name, "[]byte", fmt.Sprint(options.Length), reuse), buildDecodeByteArray(name, options))
+	}
+
+	if reuse {
+		return fmt.Sprintf(`{
 	// %[1]s
 
 	%[3]s
 
-	ul, err := d.Uint32()
-	if err != nil {
-		return 0, err
+	%[4]s
+
+	length := int(ul)
+	if length < 0 || length > len(d.Buffer) {
+		return 0, encoder.ErrBufferUnderflow
+	}
+
+	%[2]s
+
+	%[5]s
+
+	copy(%[1]s[:], d.Buffer[:length])
+	d.Buffer = d.Buffer[length:]
+	}`, name, decodeMaxLengthCheck(options), decodeOmitEmptyResetCheck(fmt.Sprintf("%[1]s = %[1]s[:0]", name), options), decodeInt("ul", "Uint32", options), decodeMakeSlice(name, "[]byte", "length", true))
 	}
 
+	return fmt.Sprintf(`{
+	// %[1]s
+
+	%[3]s
+
+	%[4]s
+
 	length := int(ul)
 	if length < 0 || length > len(d.Buffer) {
 		return 0, encoder.ErrBufferUnderflow
@@ -891,19 +975,45 @@ func buildDecodeByteSlice(name string, options *Options) string {
 		copy(%[1]s[:], d.Buffer[:length])
 		d.Buffer = d.Buffer[length:]
 	}
-	}`, name, decodeMaxLengthCheck(options), decodeOmitEmptyCheck(options))
+	}`, name, decodeMaxLengthCheck(options), decodeOmitEmptyCheck(options), decodeInt("ul", "Uint32", options))
 }
 
-func buildDecodeSlice(name, elemCounterName, elemVarName, elemSection, typeName string, options *Options) string {
-	return fmt.Sprintf(`{
+func buildDecodeSlice(name, elemCounterName, elemVarName, elemSection, typeName string, reuse bool, options *Options) string {
+	if options != nil && options.Length > 0 {
+		return fmt.Sprintf(`
+		%[1]s
+		%[2]s`, decodeMakeSlice(name, typeName, fmt.Sprint(options.Length), reuse), buildDecodeArray(name, elemCounterName, elemVarName, elemSection, options))
+	}
+
+	if reuse {
+		return fmt.Sprintf(`{
 	// %[1]s
 
+	%[6]s
+
 	%[7]s
 
-	ul, err := d.Uint32()
-	if err != nil {
-		return 0, err
+	length := int(ul)
+	if length < 0 || length > len(d.Buffer) {
+		return 0, encoder.ErrBufferUnderflow
+	}
+
+	%[5]s
+
+	%[8]s
+
+	for %[2]s := range %[1]s {
+		%[4]s
 	}
+	}`, name, elemCounterName, elemVarName, elemSection, decodeMaxLengthCheck(options), decodeOmitEmptyResetCheck(fmt.Sprintf("%[1]s = %[1]s[:0]", name), options), decodeInt("ul", "Uint32", options), decodeMakeSlice(name, typeName, "length", true))
+	}
+
+	return fmt.Sprintf(`{
+	// %[1]s
+
+	%[7]s
+
+	%[8]s
 
 	length := int(ul)
 	if length < 0 || length > len(d.Buffer) {
@@ -919,19 +1029,51 @@ func buildDecodeSlice(name, elemCounterName, elemVarName, elemSection, typeName
 			%[4]s
 		}
 	}
-	}`, name, elemCounterName, elemVarName, elemSection, typeName, decodeMaxLengthCheck(options), decodeOmitEmptyCheck(options))
+	}`, name, elemCounterName, elemVarName, elemSection, typeName, decodeMaxLengthCheck(options), decodeOmitEmptyCheck(options), decodeInt("ul", "Uint32", options))
 }
 
-func buildDecodeMap(name, keyVarName, elemVarName, keyType, elemType, keySection, elemSection, typeName string, options *Options) string {
-	return fmt.Sprintf(`{
+func buildDecodeMap(name, keyVarName, elemVarName, keyType, elemType, keySection, elemSection, typeName string, reuse bool, options *Options) string {
+	if reuse {
+		return fmt.Sprintf(`{
 	// %[1]s
 
 	%[8]s
 
-	ul, err := d.Uint32()
-	if err != nil {
-		return 0, err
+	%[11]s
+
+	length := int(ul)
+	if length < 0 || length > len(d.Buffer) {
+		return 0, encoder.ErrBufferUnderflow
+	}
+
+	%[7]s
+
+	%[12]s
+
+	for counter := 0; counter < length; counter++ {
+		var %[2]s %[9]s
+
+		%[4]s
+
+		if _, ok := %[1]s[%[2]s]; ok {
+			return 0, encoder.ErrMapDuplicateKeys
+		}
+
+		var %[3]s %[10]s
+
+		%[5]s
+
+		%[1]s[%[2]s] = %[3]s
 	}
+	}`, name, keyVarName, elemVarName, keySection, elemSection, typeName, decodeMaxLengthCheck(options), decodeOmitEmptyResetCheck(decodeClearMap(name), options), keyType, elemType, decodeInt("ul", "Uint32", options), decodeClearOrMakeMap(name, typeName))
+	}
+
+	return fmt.Sprintf(`{
+	// %[1]s
+
+	%[8]s
+
+	%[11]s
 
 	length := int(ul)
 	if length < 0 || length > len(d.Buffer) {
@@ -959,7 +1101,29 @@ func buildDecodeMap(name, keyVarName, elemVarName, keyType, elemType, keySection
 			%[1]s[%[2]s] = %[3]s
 		}
 	}
-	}`, name, keyVarName, elemVarName, keySection, elemSection, typeName, decodeMaxLengthCheck(options), decodeOmitEmptyCheck(options), keyType, elemType)
+	}`, name, keyVarName, elemVarName, keySection, elemSection, typeName, decodeMaxLengthCheck(options), decodeOmitEmptyCheck(options), keyType, elemType, decodeInt("ul", "Uint32", options))
+}
+
+// decodeInt returns the code which reads varName with the decoder method of the same name (e.g. "Uint32"),
+// in big-endian byte order if the be option is set
+func decodeInt(varName, method string, options *Options) string {
+	if options != nil && options.BigEndian {
+		size, uintMethod := intSize(method)
+		value := fmt.Sprintf("binary.BigEndian.%s(d.Buffer[:%d])", uintMethod, size)
+		if uintMethod != method {
+			value = cast(strings.ToLower(method), value)
+		}
+		return fmt.Sprintf(`if len(d.Buffer) < %[2]d {
+			return 0, encoder.ErrBufferUnderflow
+		}
+		%[1]s := %[3]s
+		d.Buffer = d.Buffer[%[2]d:]`, varName, size, value)
+	}
+
+	return fmt.Sprintf(`%[1]s, err := d.%[2]s()
+	if err != nil {
+		return 0, err
+	}`, varName, method)
 }
 
 func decodeMaxLengthCheck(options *Options) string {
@@ -982,10 +1146,2016 @@ func decodeOmitEmptyCheck(options *Options) string {
 	return ""
 }
 
-/* Test snippets */
+// decodeOmitEmptyResetCheck is decodeOmitEmptyCheck for decoding into an existing object,
+// where an omitted field must be reset to empty
+func decodeOmitEmptyResetCheck(reset string, options *Options) string {
+	if options != nil && options.OmitEmpty {
+		return fmt.Sprintf(`if len(d.Buffer) == 0 {
+			%s
+			return uint64(len(buf) - len(d.Buffer)), nil
+		}`, reset)
+	}
 
-func buildTest(typeName, typePackageName, packageName string, hasMap, exported bool) string {
-	titledTypeName := strings.Title(typeName)
+	return ""
+}
+
+// decodeMakeSlice returns the code which sets a slice to a given length.
+// If reuse is true, the existing backing array of the slice is used if it has enough capacity.
+func decodeMakeSlice(name, typeName, length string, reuse bool) string {
+	if reuse {
+		return fmt.Sprintf(`if cap(%[1]s) >= %[3]s {
+			%[1]s = %[1]s[:%[3]s]
+		} else {
+			%[1]s = make(%[2]s, %[3]s)
+		}`, name, typeName, length)
+	}
+
+	return fmt.Sprintf("%s = make(%s, %s)", name, typeName, length)
+}
+
+func decodeClearMap(name string) string {
+	return fmt.Sprintf(`for key := range %[1]s {
+				delete(%[1]s, key)
+			}`, name)
+}
+
+// decodeClearOrMakeMap returns the code which clears an existing map, or makes a new map if it is nil
+func decodeClearOrMakeMap(name, typeName string) string {
+	return fmt.Sprintf(`if %[1]s == nil {
+		if length != 0 {
+			%[1]s = make(%[2]s, length)
+		}
+	} else {
+		%[3]s
+	}`, name, typeName, decodeClearMap(name))
+}
+
+/* Versioning */
+
+func wrapEncodeSizeVersionFunc(typeName, typePackageName string, funcs []sizeFunc, steps []sizeStep, exported bool) ([]byte, error) {
+	titledTypeName := strings.Title(typeName)
+
+	exportChar := "E"
+	if !exported {
+		exportChar = "e"
+	}
+
+	funcName := fmt.Sprintf("%sncodeSize%sVersion", exportChar, titledTypeName)
+	src, err := renderSizeFunc(funcName, []*ast.Field{
+		objParam(typeName, typePackageName),
+		{
+			Names: []*ast.Ident{ast.NewIdent("version")},
+			Type:  ast.NewIdent("uint64"),
+		},
+	}, funcs, steps)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(fmt.Sprintf(`
+// %[2]s computes the size of an encoded object of type %[1]s at a given version.
+// Fields introduced after the version are not counted.
+%[3]s
+`, typeName, funcName, src)), nil
+}
+
+func wrapEncodeVersionFunc(typeName, typePackageName, funcBody string, exported bool) []byte {
+	titledTypeName := strings.Title(typeName)
+	fullTypeName := typeName
+	if typePackageName != "" {
+		fullTypeName = fmt.Sprintf("%s.%s", typePackageName, fullTypeName)
+	}
+
+	exportChar := "E"
+	if !exported {
+		exportChar = "e"
+	}
+
+	return []byte(fmt.Sprintf(`
+// %[4]sncode%[5]sVersion encodes an object of type %[1]s at a given version to a buffer allocated to the exact size
+// required to encode the object. Fields introduced after the version are not encoded.
+func %[4]sncode%[5]sVersion(obj *%[3]s, version uint64) ([]byte, error) {
+	n := %[4]sncodeSize%[5]sVersion(obj, version)
+	buf := make([]byte, n)
+
+	if err := %[4]sncode%[5]sVersionToBuffer(buf, obj, version); err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+// %[4]sncode%[5]sVersionToBuffer encodes an object of type %[1]s at a given version to a []byte buffer.
+// The buffer must be large enough to encode the object, otherwise an error is returned.
+func %[4]sncode%[5]sVersionToBuffer(buf []byte, obj *%[3]s, version uint64) error {
+	if uint64(len(buf)) < %[4]sncodeSize%[5]sVersion(obj, version) {
+		return encoder.ErrBufferUnderflow
+	}
+
+	e := &encoder.Encoder{
+		Buffer: buf[:],
+	}
+
+	%[2]s
+
+	return nil
+}
+`, typeName, funcBody, fullTypeName, exportChar, titledTypeName))
+}
+
+func wrapDecodeVersionFunc(typeName, typePackageName, funcBody string, exported bool) []byte {
+	titledTypeName := strings.Title(typeName)
+	fullTypeName := typeName
+	if typePackageName != "" {
+		fullTypeName = fmt.Sprintf("%s.%s", typePackageName, typeName)
+	}
+
+	exportChar := "D"
+	if !exported {
+		exportChar = "d"
+	}
+
+	return []byte(fmt.Sprintf(`
+// %[4]secode%[5]sVersion decodes an object of type %[1]s that was encoded at a given version.
+// Fields introduced after the version are not read from the buffer and are set to their zero value.
+// Returns the number of bytes used from the buffer to decode the object.
+// If the buffer not long enough to decode the object, returns encoder.ErrBufferUnderflow.
+func %[4]secode%[5]sVersion(buf []byte, obj *%[3]s, version uint64) (uint64, error) {
+	d := &encoder.Decoder{
+		Buffer: buf[:],
+	}
+
+	%[2]s
+
+	return uint64(len(buf) - len(d.Buffer)), nil
+}
+
+// %[4]secode%[5]sVersionExact decodes an object of type %[1]s that was encoded at a given version.
+// If the buffer not long enough to decode the object, returns encoder.ErrBufferUnderflow.
+// If the buffer is longer than required to decode the object, returns encoder.ErrRemainingBytes.
+func %[4]secode%[5]sVersionExact(buf []byte, obj *%[3]s, version uint64) error {
+	if n, err := %[4]secode%[5]sVersion(buf, obj, version); err != nil {
+		return err
+	} else if n != uint64(len(buf)) {
+		return encoder.ErrRemainingBytes
+	}
+
+	return nil
+}
+`, typeName, funcBody, fullTypeName, exportChar, titledTypeName))
+}
+
+func wrapVersionCheck(section string, since uint64) string {
+	return fmt.Sprintf(`
+	// since version %[2]d
+	if version >= %[2]d {
+		%[1]s
+	}
+	`, section, since)
+}
+
+func wrapDecodeVersionCheck(name, typeName, section string, since uint64) string {
+	return fmt.Sprintf(`
+	// since version %[4]d
+	if version >= %[4]d {
+		%[3]s
+	} else {
+		var zero %[2]s
+		%[1]s = zero
+	}
+	`, name, typeName, section, since)
+}
+
+/* Hash */
+
+func wrapHashFunc(typeName, typePackageName, funcBody string, exported bool) []byte {
+	titledTypeName := strings.Title(typeName)
+	fullTypeName := typeName
+	if typePackageName != "" {
+		fullTypeName = fmt.Sprintf("%s.%s", typePackageName, fullTypeName)
+	}
+
+	exportChar := "H"
+	if !exported {
+		exportChar = "h"
+	}
+
+	return []byte(fmt.Sprintf(`
+// %[4]sash%[5]s computes the SHA256 hash of the encoding of an object of type %[1]s, excluding fields tagged with nohash.
+// The encoding is streamed to the hash, without allocating the encoded bytes.
+// Panics if the object can't be encoded, like encoder.Serialize.
+func %[4]sash%[5]s(obj *%[3]s) cipher.SHA256 {
+	h := sha256.New()
+	if err := %[4]sash%[5]sToHash(h, obj); err != nil {
+		panic(err)
+	}
+
+	var sum cipher.SHA256
+	h.Sum(sum[:0])
+	return sum
+}
+
+// %[4]sash%[5]sToHash writes the encoding of an object of type %[1]s to a hash.Hash, excluding fields tagged with nohash.
+// If the object can't be encoded, returns an error, and the encoding may have been partially written to the hash.
+func %[4]sash%[5]sToHash(h hash.Hash, obj *%[3]s) error {
+	e := runtime.NewHashEncoder(h)
+
+	%[2]s
+
+	e.Flush()
+
+	return nil
+}
+`, typeName, funcBody, fullTypeName, exportChar, titledTypeName))
+}
+
+/* Peek */
+
+func wrapPeekFunc(typeName, typePackageName, fieldPath, fieldTypeName, skipSection, decodeSection string, exported bool) []byte {
+	titledTypeName := strings.Title(typeName)
+	fullTypeName := typeName
+	if typePackageName != "" {
+		fullTypeName = fmt.Sprintf("%s.%s", typePackageName, typeName)
+	}
+
+	exportChar := "P"
+	decode := "Decode"
+	if !exported {
+		exportChar = "p"
+		decode = "decode"
+	}
+
+	return []byte(fmt.Sprintf(`
+// %[4]seek%[5]s%[6]s decodes the field %[7]s of an encoded object of type %[1]s,
+// skipping over the fields preceding it without decoding them.
+// If the buffer not long enough to decode the field, returns encoder.ErrBufferUnderflow.
+func %[4]seek%[5]s%[6]s(buf []byte) (%[8]s, error) {
+	var obj %[8]s
+
+	// The decoding code returns (0, err) on error, like in %[9]s%[5]s
+	_, err := func() (uint64, error) {
+		d := &encoder.Decoder{
+			Buffer: buf[:],
+		}
+
+		%[2]s
+
+		%[3]s
+
+		return 0, nil
+	}()
+
+	if err != nil {
+		var zero %[8]s
+		return zero, err
+	}
+
+	return obj, nil
+}
+`, fullTypeName, skipSection, decodeSection, exportChar, titledTypeName, strings.Replace(fieldPath, ".", "", -1), fieldPath, fieldTypeName, decode))
+}
+
+func buildSkipFixed(name string, size uint64) string {
+	return fmt.Sprintf(`{
+	// skip %[1]s
+	if len(d.Buffer) < %[2]d {
+		return 0, encoder.ErrBufferUnderflow
+	}
+	d.Buffer = d.Buffer[%[2]d:]
+	}
+	`, name, size)
+}
+
+func buildSkipBool(name string) string {
+	return fmt.Sprintf(`{
+	// skip %[1]s
+	if _, err := d.Bool(); err != nil {
+		return 0, err
+	}
+	}
+	`, name)
+}
+
+func buildSkipBytes(name string, options *Options) string {
+	return fmt.Sprintf(`{
+	// skip %[1]s
+
+	%[3]s
+
+	%[4]s
+
+	length := int(ul)
+	if length < 0 || length > len(d.Buffer) {
+		return 0, encoder.ErrBufferUnderflow
+	}
+
+	%[2]s
+
+	d.Buffer = d.Buffer[length:]
+	}`, name, decodeMaxLengthCheck(options), decodeOmitEmptyCheck(options), decodeInt("ul", "Uint32", options))
+}
+
+func buildSkipArray(name, elemCounterName, elemSection string, length int64) string {
+	return fmt.Sprintf(`{
+	// skip %[1]s
+	for %[2]s := 0; %[2]s < %[4]d; %[2]s++ {
+		%[3]s
+	}
+	}
+	`, name, elemCounterName, elemSection, length)
+}
+
+func buildSkipSlice(name, elemCounterName, elemSection string, elemSize uint64, options *Options) string {
+	// Elements of a fixed size are skipped all at once
+	skipElems := fmt.Sprintf(`for %[1]s := 0; %[1]s < length; %[1]s++ {
+		%[2]s
+	}`, elemCounterName, elemSection)
+	if elemSize != 0 {
+		skipElems = fmt.Sprintf(`if uint64(length)*%[1]d > uint64(len(d.Buffer)) {
+		return 0, encoder.ErrBufferUnderflow
+	}
+	d.Buffer = d.Buffer[uint64(length)*%[1]d:]`, elemSize)
+	}
+
+	return fmt.Sprintf(`{
+	// skip %[1]s
+
+	%[3]s
+
+	%[4]s
+
+	length := int(ul)
+	if length < 0 || length > len(d.Buffer) {
+		return 0, encoder.ErrBufferUnderflow
+	}
+
+	%[2]s
+
+	%[5]s
+	}`, name, decodeMaxLengthCheck(options), decodeOmitEmptyCheck(options), decodeInt("ul", "Uint32", options), skipElems)
+}
+
+func buildSkipMap(name, counterName, keySection, elemSection string, options *Options) string {
+	return fmt.Sprintf(`{
+	// skip %[1]s
+
+	%[6]s
+
+	%[7]s
+
+	length := int(ul)
+	if length < 0 || length > len(d.Buffer) {
+		return 0, encoder.ErrBufferUnderflow
+	}
+
+	%[5]s
+
+	for %[2]s := 0; %[2]s < length; %[2]s++ {
+		%[3]s
+
+		%[4]s
+	}
+	}`, name, counterName, keySection, elemSection, decodeMaxLengthCheck(options), decodeOmitEmptyCheck(options), decodeInt("ul", "Uint32", options))
+}
+
+/* Validate */
+
+func wrapValidateFunc(typeName, typePackageName, funcBody string, exported bool) []byte {
+	titledTypeName := strings.Title(typeName)
+	fullTypeName := typeName
+	if typePackageName != "" {
+		fullTypeName = fmt.Sprintf("%s.%s", typePackageName, typeName)
+	}
+
+	exportChar := "V"
+	decode := "Decode"
+	if !exported {
+		exportChar = "v"
+		decode = "decode"
+	}
+
+	return []byte(fmt.Sprintf(`
+// %[4]salidate%[5]s checks that a buffer starts with a valid encoding of an object of type %[1]s,
+// with the same checks as %[6]s%[5]s, but without decoding the object.
+// Returns the number of bytes used from the buffer by the encoded object.
+// If the buffer not long enough to decode the object, returns encoder.ErrBufferUnderflow.
+func %[4]salidate%[5]s(buf []byte) (uint64, error) {
+	d := &encoder.Decoder{
+		Buffer: buf[:],
+	}
+
+	%[2]s
+
+	return uint64(len(buf) - len(d.Buffer)), nil
+}
+
+// %[4]salidate%[5]sExact checks that a buffer is a valid encoding of an object of type %[1]s,
+// with the same checks as %[6]s%[5]sExact, but without decoding the object.
+// If the buffer not long enough to decode the object, returns encoder.ErrBufferUnderflow.
+// If the buffer is longer than required to decode the object, returns encoder.ErrRemainingBytes.
+func %[4]salidate%[5]sExact(buf []byte) error {
+	if n, err := %[4]salidate%[5]s(buf); err != nil {
+		return err
+	} else if n != uint64(len(buf)) {
+		return encoder.ErrRemainingBytes
+	}
+
+	return nil
+}
+`, fullTypeName, funcBody, fullTypeName, exportChar, titledTypeName, decode))
+}
+
+// buildValidateMap skips a map, checking for duplicate keys with a set of the encoded keys,
+// in the same order as the decoder checks them
+func buildValidateMap(name, counterName, keySection, elemSection, keySetType, setKey string, options *Options) string {
+	return fmt.Sprintf(`{
+	// skip %[1]s
+
+	%[6]s
+
+	%[7]s
+
+	length := int(ul)
+	if length < 0 || length > len(d.Buffer) {
+		return 0, encoder.ErrBufferUnderflow
+	}
+
+	%[5]s
+
+	// %[1]s duplicate key check
+	seen := make(%[8]s)
+	for %[2]s := 0; %[2]s < length; %[2]s++ {
+		keyStart := d.Buffer
+
+		%[3]s
+
+		key := keyStart[:len(keyStart)-len(d.Buffer)]
+		if _, ok := seen[%[9]s]; ok {
+			return 0, encoder.ErrMapDuplicateKeys
+		}
+		seen[%[9]s] = struct{}{}
+
+		%[4]s
+	}
+	}`, name, counterName, keySection, elemSection, decodeMaxLengthCheck(options), decodeOmitEmptyCheck(options), decodeInt("ul", "Uint32", options), keySetType, setKey)
+}
+
+/* Format */
+
+func wrapFormatFunc(typeName, typePackageName, funcBody string, exported bool) []byte {
+	titledTypeName := strings.Title(typeName)
+	fullTypeName := typeName
+	if typePackageName != "" {
+		fullTypeName = fmt.Sprintf("%s.%s", typePackageName, typeName)
+	}
+
+	exportChar := "F"
+	if !exported {
+		exportChar = "f"
+	}
+
+	src := fmt.Sprintf(`
+// %[4]sormat%[5]s formats an object of type %[1]s for debugging, printing its fields in encoded order.
+// Byte arrays and byte slices are printed in hex, and strings, slices and maps are printed with their length.
+// Map entries are printed in iteration order, like they are encoded.
+func %[4]sormat%[5]s(obj *%[3]s) string {
+	var w strings.Builder
+
+	w.WriteString(%[6]q)
+
+	%[2]s
+
+	return w.String()
+}
+`, typeName, funcBody, fullTypeName, exportChar, titledTypeName, typeName)
+
+	// Methods can only be defined in the type's package
+	if typePackageName == "" {
+		src += fmt.Sprintf(`
+// GoString formats an object of type %[1]s like %[2]sormat%[3]s, when it is printed with %%#v
+func (obj %[1]s) GoString() string {
+	return %[2]sormat%[3]s(&obj)
+}
+`, typeName, exportChar, titledTypeName)
+	}
+
+	return []byte(src)
+}
+
+func buildFormatBool(name string) string {
+	return fmt.Sprintf(`
+	// %[1]s
+	w.WriteString(strconv.FormatBool(bool(%[1]s)))
+	`, name)
+}
+
+func buildFormatInt(name string) string {
+	return fmt.Sprintf(`
+	// %[1]s
+	w.WriteString(strconv.FormatInt(int64(%[1]s), 10))
+	`, name)
+}
+
+func buildFormatUint(name string) string {
+	return fmt.Sprintf(`
+	// %[1]s
+	w.WriteString(strconv.FormatUint(uint64(%[1]s), 10))
+	`, name)
+}
+
+func buildFormatFloat(name string, bitSize int) string {
+	return fmt.Sprintf(`
+	// %[1]s
+	w.WriteString(strconv.FormatFloat(float64(%[1]s), 'g', -1, %[2]d))
+	`, name, bitSize)
+}
+
+func buildFormatString(name string, options *Options) string {
+	return formatOmitEmpty(name, fmt.Sprintf(`
+	// %[1]s
+	fmt.Fprintf(&w, "(len=%%d)", len(%[1]s))
+	w.WriteString(strconv.Quote(string(%[1]s)))
+	`, name), options)
+}
+
+func buildFormatByteArray(name string) string {
+	return fmt.Sprintf(`
+	// %[1]s
+	w.WriteString(hex.EncodeToString(%[1]s[:]))
+	`, name)
+}
+
+func buildFormatByteSlice(name string, options *Options) string {
+	return formatOmitEmpty(name, fmt.Sprintf(`
+	// %[1]s
+	fmt.Fprintf(&w, "(len=%%d)", len(%[1]s))
+	w.WriteString(hex.EncodeToString(%[1]s))
+	`, name), options)
+}
+
+// sectionUsesVar returns true if a code section refers to the variable varName.
+// The element sections of empty structs don't refer to the element, which must then not be declared.
+func sectionUsesVar(section, varName string) bool {
+	src := []byte(section)
+	fset := token.NewFileSet()
+
+	var s scanner.Scanner
+	s.Init(fset.AddFile("", fset.Base(), len(src)), src, nil, 0)
+	for {
+		_, tok, lit := s.Scan()
+		switch {
+		case tok == token.EOF:
+			return false
+		case tok == token.IDENT && lit == varName:
+			return true
+		}
+	}
+}
+
+// formatRangeVars returns the variables of a range clause over a map, omitting those not used by the loop
+func formatRangeVars(keyVarName, elemVarName, keySection, elemSection string) string {
+	keyUsed := sectionUsesVar(keySection, keyVarName)
+	elemUsed := sectionUsesVar(elemSection, elemVarName)
+
+	switch {
+	case elemUsed && keyUsed:
+		return keyVarName + ", " + elemVarName + " :="
+	case elemUsed:
+		return "_, " + elemVarName + " :="
+	case keyUsed:
+		return keyVarName + " :="
+	default:
+		return ""
+	}
+}
+
+func buildFormatArray(name, elemVarName, elemSection string) string {
+	rangeVars := "i, " + elemVarName
+	if !sectionUsesVar(elemSection, elemVarName) {
+		rangeVars = "i"
+	}
+
+	return fmt.Sprintf(`
+	// %[1]s
+	w.WriteString("[")
+	for %[2]s := range %[1]s {
+		if i != 0 {
+			w.WriteString(" ")
+		}
+
+		%[3]s
+	}
+	w.WriteString("]")
+	`, name, rangeVars, elemSection)
+}
+
+func buildFormatSlice(name, elemVarName, elemSection string, options *Options) string {
+	return formatOmitEmpty(name, fmt.Sprintf(`
+	// %[1]s length
+	fmt.Fprintf(&w, "(len=%%d)", len(%[1]s))
+	`, name)+buildFormatArray(name, elemVarName, elemSection), options)
+}
+
+func buildFormatMap(name, keyVarName, elemVarName, keySection, elemSection string, options *Options) string {
+	return formatOmitEmpty(name, fmt.Sprintf(`{
+	// %[1]s
+	fmt.Fprintf(&w, "(len=%%d)map[", len(%[1]s))
+	i := 0
+	for %[2]s range %[1]s {
+		if i != 0 {
+			w.WriteString(" ")
+		}
+		i++
+
+		%[3]s
+
+		w.WriteString(":")
+
+		%[4]s
+	}
+	w.WriteString("]")
+	}`, name, formatRangeVars(keyVarName, elemVarName, keySection, elemSection), keySection, elemSection), options)
+}
+
+// buildFormatStruct formats the fields of a struct, given the field names and the code sections formatting their values
+func buildFormatStruct(fieldNames, sections []string) string {
+	fields := make([]string, len(sections))
+	for i, section := range sections {
+		label := fieldNames[i] + ":"
+		if i == 0 {
+			label = "{" + label
+		} else {
+			label = " " + label
+		}
+
+		fields[i] = fmt.Sprintf(`
+		w.WriteString(%[1]q)
+		%[2]s
+		`, label, section)
+	}
+
+	if len(fields) == 0 {
+		return `
+		w.WriteString("{}")
+		`
+	}
+
+	return fmt.Sprintf(`
+	%[1]s
+	w.WriteString("}")
+	`, strings.Join(fields, "\n"))
+}
+
+// formatOmitEmpty prints an empty omitempty field as (omitted), because it is not encoded
+func formatOmitEmpty(name, body string, options *Options) string {
+	if options != nil && options.OmitEmpty {
+		return fmt.Sprintf(`
+			// omitempty
+			if len(%[1]s) == 0 {
+				w.WriteString("(omitted)")
+			} else {
+				%[2]s
+			}
+		`, name, body)
+	}
+
+	return body
+}
+
+/* Recursive types */
+
+// wrapRecursiveFuncs declares the function literals of recursive types, before defining them so that they can call each other.
+// The literals return the same values as the generated function declaring them, so that they share its code sections.
+func wrapRecursiveFuncs(names, signatures, bodies []string, ret string, depth bool) string {
+	decls := make([]string, len(names))
+	defs := make([]string, len(names))
+	for i, name := range names {
+		decls[i] = fmt.Sprintf("var %s %s", name, signatures[i])
+		defs[i] = fmt.Sprintf(`%[1]s = %[2]s {
+		%[3]s
+
+		%[4]s
+	}`, name, signatures[i], bodies[i], ret)
+	}
+
+	depthDecl := ""
+	if depth {
+		depthDecl = `
+		// depth is the depth in recursive types of the values outside of their function literals
+		const depth = 0
+		`
+	}
+
+	return fmt.Sprintf(`
+	// Recursive types are handled by function literals which call themselves
+	%[1]s
+
+	%[2]s
+	%[3]s
+	`, strings.Join(decls, "\n"), strings.Join(defs, "\n\n"), depthDecl)
+}
+
+// maxDepthCheck checks the depth of a value in recursive types before decoding it
+func maxDepthCheck(options *Options) string {
+	if options != nil && options.MaxDepth > 0 {
+		return fmt.Sprintf(`if depth >= %d {
+			return 0, runtime.ErrMaxDepthExceeded
+		}`, options.MaxDepth)
+	}
+
+	return ""
+}
+
+func buildEncodeRecursive(name, funcName string) string {
+	return fmt.Sprintf(`
+	// %[1]s
+	if err := %[2]s(&%[1]s); err != nil {
+		return err
+	}
+	`, name, funcName)
+}
+
+func buildDecodeRecursive(name, funcName string, options *Options) string {
+	return fmt.Sprintf(`{
+	// %[1]s
+	%[3]s
+	if _, err := %[2]s(&%[1]s, depth+1); err != nil {
+		return 0, err
+	}
+	}
+	`, name, funcName, maxDepthCheck(options))
+}
+
+func buildSkipRecursive(name, funcName string, options *Options) string {
+	return fmt.Sprintf(`{
+	// skip %[1]s
+	%[3]s
+	if _, err := %[2]s(depth+1); err != nil {
+		return 0, err
+	}
+	}
+	`, name, funcName, maxDepthCheck(options))
+}
+
+func buildFormatRecursive(name, funcName string) string {
+	return fmt.Sprintf(`%[2]s(&%[1]s)`, name, funcName)
+}
+
+/* Unions */
+
+// buildEncodeUnion encodes a value of an interface type as the tag of its type, followed by the value.
+// A nil value is encoded as tag 0. names are the type names of the members in the type switch.
+func buildEncodeUnion(name string, members []unionMember, names, sections []string) string {
+	// x is only bound in the type switch if a member's section uses it, or a nil pointer member is checked
+	bind := ""
+	cases := make([]string, len(members))
+	for i, m := range members {
+		check := ""
+		if m.pointer {
+			check = `if x == nil {
+				return runtime.ErrUnknownUnionType
+			}
+			`
+		}
+
+		if check != "" || strings.TrimSpace(sections[i]) != "" {
+			bind = "x := "
+		}
+
+		cases[i] = fmt.Sprintf(`case %[1]s:
+		%[3]se.Uint8(%[2]d)
+		%[4]s`, names[i], m.tag, check, sections[i])
+	}
+
+	return fmt.Sprintf(`
+	// %[1]s
+	switch %[2]s%[1]s.(type) {
+	case nil:
+		e.Uint8(0)
+	%[3]s
+	default:
+		return runtime.ErrUnknownUnionType
+	}
+	`, name, bind, strings.Join(cases, "\n"))
+}
+
+// buildDecodeUnion decodes the tag of a value of an interface type, then the value of the tag's type into memberVarName.
+// typeNames are the names of the member types, without pointers.
+func buildDecodeUnion(name, memberVarName string, members []unionMember, typeNames, sections []string) string {
+	cases := make([]string, len(members))
+	for i, m := range members {
+		value := memberVarName
+		if m.pointer {
+			value = "&" + memberVarName
+		}
+
+		cases[i] = fmt.Sprintf(`case %[1]d:
+		var %[3]s %[2]s
+		%[5]s
+		%[6]s = %[4]s`, m.tag, typeNames[i], memberVarName, value, sections[i], name)
+	}
+
+	return fmt.Sprintf(`{
+	// %[1]s
+	tag, err := d.Uint8()
+	if err != nil {
+		return 0, err
+	}
+
+	switch tag {
+	case 0:
+		%[1]s = nil
+	%[2]s
+	default:
+		return 0, runtime.ErrUnknownUnionTag
+	}
+	}`, name, strings.Join(cases, "\n"))
+}
+
+func buildSkipUnion(name string, members []unionMember, sections []string) string {
+	cases := make([]string, len(members))
+	for i, m := range members {
+		cases[i] = fmt.Sprintf(`case %[1]d:
+		%[2]s`, m.tag, sections[i])
+	}
+
+	return fmt.Sprintf(`{
+	// skip %[1]s
+	tag, err := d.Uint8()
+	if err != nil {
+		return 0, err
+	}
+
+	switch tag {
+	case 0:
+	%[2]s
+	default:
+		return 0, runtime.ErrUnknownUnionTag
+	}
+	}
+	`, name, strings.Join(cases, "\n"))
+}
+
+// buildFormatUnion prints the type of a value of an interface type before the value
+func buildFormatUnion(name string, members []unionMember, names, sections []string) string {
+	cases := make([]string, len(members))
+	for i, m := range members {
+		section := fmt.Sprintf(`w.WriteString(%[1]q)
+		%[2]s`, names[i], sections[i])
+		if m.pointer {
+			section = fmt.Sprintf(`if x == nil {
+			w.WriteString(%[1]q)
+		} else {
+			%[2]s
+		}`, fmt.Sprintf("(%s)(nil)", names[i]), section)
+		}
+
+		cases[i] = fmt.Sprintf(`case %[1]s:
+		%[2]s`, names[i], section)
+	}
+
+	return fmt.Sprintf(`
+	// %[1]s
+	switch x := %[1]s.(type) {
+	case nil:
+		w.WriteString("nil")
+	%[2]s
+	default:
+		fmt.Fprintf(&w, "(not in union)%%T", x)
+	}
+	`, name, strings.Join(cases, "\n"))
+}
+
+/* Constants */
+
+// buildEncodeConst writes the encoded bytes of the value of a const option,
+// if the value to encode is the constant or the zero value
+func buildEncodeConst(name, zero, value, encoded string) string {
+	return fmt.Sprintf(`
+	// %[1]s const
+	if %[1]s != %[2]s && %[1]s != %[3]s {
+		return runtime.ErrConstMismatch
+	}
+	e.CopyBytes([]byte{%[4]s})
+	`, name, wrapCompositeLiteral(zero), wrapCompositeLiteral(value), encoded)
+}
+
+// buildDecodeConst checks that the encoded bytes of a value are those of the value of its const option,
+// and sets the value to the constant, unless value is empty
+func buildDecodeConst(name, value, encoded string, size int) string {
+	set := ""
+	if value != "" {
+		set = fmt.Sprintf(`
+	%[1]s = %[2]s`, name, value)
+	}
+
+	return fmt.Sprintf(`{
+	// %[1]s const
+	if len(d.Buffer) < %[4]d {
+		return 0, encoder.ErrBufferUnderflow
+	}
+	if !bytes.Equal(d.Buffer[:%[4]d], []byte{%[3]s}) {
+		return 0, runtime.ErrConstMismatch
+	}%[2]s
+	d.Buffer = d.Buffer[%[4]d:]
+	}
+	`, name, set, encoded, size)
+}
+
+// wrapCompositeLiteral parenthesizes a composite literal, which is ambiguous in the condition of an if statement
+func wrapCompositeLiteral(x string) string {
+	if strings.HasSuffix(x, "}") {
+		return "(" + x + ")"
+	}
+	return x
+}
+
+/* Checksums */
+
+// buildEncodeChecksum appends the checksum of the encoded object, which precedes it in the buffer
+func buildEncodeChecksum(funcName string) string {
+	return fmt.Sprintf(`
+	{
+	// checksum
+	sum := runtime.%[1]s(buf[:len(buf)-len(e.Buffer)])
+	e.CopyBytes(sum[:])
+	}
+	`, funcName)
+}
+
+// buildDecodeChecksum checks that the checksum following the decoded object is the checksum of its encoding
+func buildDecodeChecksum(funcName string) string {
+	return fmt.Sprintf(`
+	{
+	// checksum
+	if len(d.Buffer) < runtime.ChecksumSize {
+		return 0, encoder.ErrBufferUnderflow
+	}
+	sum := runtime.%[1]s(buf[:len(buf)-len(d.Buffer)])
+	if !bytes.Equal(d.Buffer[:runtime.ChecksumSize], sum[:]) {
+		return 0, runtime.ErrChecksumMismatch
+	}
+	d.Buffer = d.Buffer[runtime.ChecksumSize:]
+	}
+	`, funcName)
+}
+
+/* Test snippets */
+
+func buildTest(typeName, typePackageName, packageName string, hasMap, exported, reflectCompatible, hasFixedLength, hasConst, recursive, ordered bool, unionNames []string, unionMemberNames [][]string) string {
+	titledTypeName := strings.Title(typeName)
+	fullTypeName := typeName
+	if typePackageName != "" {
+		fullTypeName = fmt.Sprintf("%s.%s", typePackageName, typeName)
+	}
+
+	encode := "Encode"
+	if !exported {
+		encode = "encode"
+	}
+
+	decode := "Decode"
+	if !exported {
+		decode = "decode"
+	}
+
+	checkBytesEqual := ""
+	if !hasMap {
+		checkBytesEqual = fmt.Sprintf(`if !bytes.Equal(data1, data2) {
+			t.Fatal("encoder.Serialize() != %[2]s[1]s()")
+		}
+		`, typeName, encode)
+	}
+
+	// Objects using encoding options that the reflect-based encoder does not support
+	// are checked by round tripping through the generated encoder instead
+	testFunc := buildTestFuncReflect(titledTypeName, fullTypeName, checkBytesEqual, encode, decode, ordered)
+	if !reflectCompatible {
+		testFunc = buildTestFuncRoundTrip(titledTypeName, fullTypeName, hasMap, encode, decode)
+	}
+
+	// Random values of recursive types have at most one element in each slice and map,
+	// so that they are finite with probability 1, and usually small
+	randLen := `MaxRandLen: 4,
+		MinRandLen: 1,`
+	if recursive {
+		randLen = `MaxRandLen: 1,
+		MinRandLen: 0,`
+	}
+
+	normalize := ""
+	helpers := ""
+	if hasFixedLength {
+		normalize = fmt.Sprintf(`
+	resizeFixedLength%[1]sForEncodeTest(reflect.ValueOf(&obj))`, titledTypeName)
+		helpers = buildTestResizeFixedLength(titledTypeName)
+	}
+
+	if hasConst {
+		normalize += fmt.Sprintf(`
+	setConst%[1]sForEncodeTest(reflect.ValueOf(&obj))`, titledTypeName)
+		helpers += buildTestSetConst(titledTypeName)
+	}
+
+	if ordered && reflectCompatible {
+		helpers += buildTestOrdered(titledTypeName)
+	}
+
+	helpers += buildTestFieldHelpers(titledTypeName)
+
+	// Values of interface types are left nil by encodertest.PopulateRandom,
+	// and are populated with the same options afterwards
+	populateUnions := func(opts string) string {
+		return ""
+	}
+	if len(unionNames) != 0 {
+		populateUnions = func(opts string) string {
+			return fmt.Sprintf(`
+	populateUnions%[1]sForEncodeTest(t, reflect.ValueOf(&obj).Elem(), rand, encodertest.PopulateRandomOptions{
+		%[2]s
+	})`, titledTypeName, opts)
+		}
+		helpers += buildTestPopulateUnions(titledTypeName, fullTypeName, unionNames, unionMemberNames)
+	}
+
+	return fmt.Sprintf(`// Code generated by github.com/skycoin/skyencoder. DO NOT EDIT.
+
+package %[3]s
+
+import (
+	"bytes"
+	"fmt"
+	mathrand "math/rand"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/skycoin/encodertest"
+	"github.com/skycoin/skyencoder/runtime"
+)
+
+func newEmpty%[1]sForEncodeTest() *%[2]s {
+	var obj %[2]s%[8]s
+	return &obj
+}
+
+func newRandom%[1]sForEncodeTest(t *testing.T, rand *mathrand.Rand) *%[2]s {
+	var obj %[2]s
+	err := encodertest.PopulateRandom(&obj, rand, encodertest.PopulateRandomOptions{
+		%[10]s
+	})
+	if err != nil {
+		t.Fatalf("encodertest.PopulateRandom failed: %%v", err)
+	}%[11]s%[8]s
+	return &obj
+}
+
+func newRandomZeroLen%[1]sForEncodeTest(t *testing.T, rand *mathrand.Rand) *%[2]s {
+	var obj %[2]s
+	err := encodertest.PopulateRandom(&obj, rand, encodertest.PopulateRandomOptions{
+		MaxRandLen:    0,
+		MinRandLen:    0,
+		EmptySliceNil: false,
+		EmptyMapNil:   false,
+	})
+	if err != nil {
+		t.Fatalf("encodertest.PopulateRandom failed: %%v", err)
+	}%[12]s%[8]s
+	return &obj
+}
+
+func newRandomZeroLenNil%[1]sForEncodeTest(t *testing.T, rand *mathrand.Rand) *%[2]s {
+	var obj %[2]s
+	err := encodertest.PopulateRandom(&obj, rand, encodertest.PopulateRandomOptions{
+		MaxRandLen:    0,
+		MinRandLen:    0,
+		EmptySliceNil: true,
+		EmptyMapNil:   true,
+	})
+	if err != nil {
+		t.Fatalf("encodertest.PopulateRandom failed: %%v", err)
+	}%[13]s%[8]s
+	return &obj
+}
+
+
+%[7]s
+
+func TestSkyencoder%[1]s(t *testing.T) {
+	rand := mathrand.New(mathrand.NewSource(time.Now().Unix()))
+
+	type testCase struct {
+		name string
+		obj  *%[2]s
+	}
+
+	cases := []testCase{
+		{
+			name: "empty object",
+			obj:  newEmpty%[1]sForEncodeTest(),
+		},
+	}
+
+	nRandom := 10
+
+	for i := 0; i < nRandom; i++ {
+		cases = append(cases, testCase{
+			name: fmt.Sprintf("randomly populated object %%d", i),
+			obj:  newRandom%[1]sForEncodeTest(t, rand),
+		})
+		cases = append(cases, testCase{
+			name: fmt.Sprintf("randomly populated object %%d with zero length variable length contents", i),
+			obj:  newRandomZeroLen%[1]sForEncodeTest(t, rand),
+		})
+		cases = append(cases, testCase{
+			name: fmt.Sprintf("randomly populated object %%d with zero length variable length contents set to nil", i),
+			obj:  newRandomZeroLenNil%[1]sForEncodeTest(t, rand),
+		})
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			testSkyencoder%[1]s(t, tc.obj)
+		})
+	}
+}
+
+func decode%[1]sExpectError(t *testing.T, buf []byte, expectedErr error) {
+	var obj %[2]s
+	if _, err := %[6]s%[1]s(buf, &obj); err == nil {
+		t.Fatal("%[6]s%[1]s: expected error, got nil")
+	} else if err != expectedErr {
+		t.Fatalf("%[6]s%[1]s: expected error %%q, got %%q", expectedErr, err)
+	}
+}
+
+func decode%[1]sExactExpectError(t *testing.T, buf []byte, expectedErr error) {
+	var obj %[2]s
+	if err := %[6]s%[1]sExact(buf, &obj); err == nil {
+		t.Fatal("%[6]s%[1]sExact: expected error, got nil")
+	} else if err != expectedErr {
+		t.Fatalf("%[6]s%[1]sExact: expected error %%q, got %%q", expectedErr, err)
+	}
+}
+
+func testSkyencoder%[1]sDecodeErrors(t *testing.T, k int, tag string, obj *%[2]s) {
+	n := %[5]sSize%[1]s(obj)
+	buf, err := %[5]s%[1]s(obj);
+	if err != nil {
+		t.Fatalf("%[5]s%[1]s failed: %%v", err)
+	}
+
+	// A nil buffer cannot decode, unless the object is a struct with a single omitempty field
+	if hasOmitEmptyField%[1]sForEncodeTest(obj) && numEncodableFields%[1]sForEncodeTest(obj) > 1 {
+		t.Run(fmt.Sprintf("%%d %%s buffer underflow nil", k, tag), func(t *testing.T) {
+			decode%[1]sExpectError(t, nil, encoder.ErrBufferUnderflow)
+		})
+
+		t.Run(fmt.Sprintf("%%d %%s exact buffer underflow nil", k, tag), func(t *testing.T) {
+			decode%[1]sExactExpectError(t, nil, encoder.ErrBufferUnderflow)
+		})
+	}
+
+	// Test all possible truncations of the encoded byte array, but skip
+	// a truncation that would be valid where omitempty is removed
+	skipN := n - omitEmptyLen%[1]sForEncodeTest(obj)
+	for i := uint64(0); i < n; i++ {
+		if i == skipN {
+			continue
+		}
+
+		t.Run(fmt.Sprintf("%%d %%s buffer underflow bytes=%%d", k, tag, i), func(t *testing.T) {
+			decode%[1]sExpectError(t, buf[:i], encoder.ErrBufferUnderflow)
+		})
+
+		t.Run(fmt.Sprintf("%%d %%s exact buffer underflow bytes=%%d", k, tag, i), func(t *testing.T) {
+			decode%[1]sExactExpectError(t, buf[:i], encoder.ErrBufferUnderflow)
+		})
+	}
+
+	// Append 5 bytes for omit empty with a 0 length prefix, to cause an ErrRemainingBytes.
+	// If only 1 byte is appended, the decoder will try to read the 4-byte length prefix,
+	// and return an ErrBufferUnderflow instead
+	if hasOmitEmptyField%[1]sForEncodeTest(obj) {
+		buf = append(buf, []byte{0, 0, 0, 0, 0}...)
+	} else {
+		buf = append(buf, 0)
+	}
+
+	t.Run(fmt.Sprintf("%%d %%s exact buffer remaining bytes", k, tag), func(t *testing.T) {
+		decode%[1]sExactExpectError(t, buf, encoder.ErrRemainingBytes)
+	})
+}
+
+func TestSkyencoder%[1]sDecodeErrors(t *testing.T) {
+	rand := mathrand.New(mathrand.NewSource(time.Now().Unix()))
+	n := 10
+
+	for i := 0; i < n; i++ {
+		emptyObj := newEmpty%[1]sForEncodeTest()
+		fullObj := newRandom%[1]sForEncodeTest(t, rand)
+		testSkyencoder%[1]sDecodeErrors(t, i, "empty", emptyObj)
+		testSkyencoder%[1]sDecodeErrors(t, i, "full", fullObj)
+	}
+}
+%[9]s
+`, titledTypeName, fullTypeName, packageName, checkBytesEqual, encode, decode, testFunc, normalize, helpers, randLen,
+		populateUnions(randLen), populateUnions(`MaxRandLen: 0,
+		MinRandLen: 0,`), populateUnions(`MaxRandLen: 0,
+		MinRandLen: 0,
+		EmptySliceNil: true,
+		EmptyMapNil: true,`))
+}
+
+func buildTestFuncReflect(titledTypeName, fullTypeName, checkBytesEqual, encode, decode string, ordered bool) string {
+	// Objects with ordered fields are compared to the reflect-based encoding of a copy
+	// whose fields are declared in encoding order
+	reference := ""
+	encoderObj := "obj"
+	decodeReference := ""
+	decoderObj := "&obj2"
+	copyReference := ""
+	if ordered {
+		reference = fmt.Sprintf(`// The reflect-based encoder encodes fields in declaration order,
+	// so it encodes a copy of the object whose fields are declared in encoding order
+	ref := reflect.New(orderedType%[1]sForEncodeTest(reflect.TypeOf(obj).Elem()))
+	copyOrdered%[1]sForEncodeTest(ref.Elem(), reflect.ValueOf(obj).Elem())
+
+	`, titledTypeName)
+		encoderObj = "ref.Interface()"
+		decodeReference = fmt.Sprintf(`ref2 := reflect.New(orderedType%[1]sForEncodeTest(reflect.TypeOf(obj2)))
+	`, titledTypeName)
+		decoderObj = "ref2.Interface()"
+		copyReference = fmt.Sprintf(`copyOrdered%[1]sForEncodeTest(reflect.ValueOf(&obj2).Elem(), ref2.Elem())
+	`, titledTypeName)
+	}
+
+	return fmt.Sprintf(`func testSkyencoder%[1]s(t *testing.T, obj *%[2]s) {
+	%[7]s// %[5]sSize
+
+	n1 := encoder.Size(%[8]s)
+	n2 := %[5]sSize%[1]s(obj)
+
+	if uint64(n1) != n2 {
+		t.Fatalf("encoder.Size() != %[5]sSize%[1]s() (%%d != %%d)", n1, n2)
+	}
+
+	// Encode
+
+	// encoder.Serialize
+	data1 := encoder.Serialize(%[8]s)
+
+	// Encode
+	data2, err := %[5]s%[1]s(obj)
+	if err != nil {
+		t.Fatalf("%[5]s%[1]s failed: %%v", err)
+	}
+	if uint64(len(data2)) != n2 {
+		t.Fatal("%[5]s%[1]s produced bytes of unexpected length")
+	}
+	if len(data1) != len(data2) {
+		t.Fatalf("len(encoder.Serialize()) != len(%[5]s%[1]s()) (%%d != %%d)", len(data1), len(data2))
+	}
+
+	// EncodeToBuffer
+	data3 := make([]byte, n2+5)
+	if err := %[5]s%[1]sToBuffer(data3, obj); err != nil {
+		t.Fatalf("%[5]s%[1]sToBuffer failed: %%v", err)
+	}
+
+	%[4]s
+
+	// Decode
+
+	// encoder.DeserializeRaw
+	var obj2 %[2]s
+	%[9]sif n, err := encoder.DeserializeRaw(data1, %[10]s); err != nil {
+		t.Fatalf("encoder.DeserializeRaw failed: %%v", err)
+	} else if n != uint64(len(data1)) {
+		t.Fatalf("encoder.DeserializeRaw failed: %%v", encoder.ErrRemainingBytes)
+	}
+	%[11]sif !cmp.Equal(*obj, obj2, cmpopts.EquateEmpty(), encodertest.IgnoreAllUnexported()) {
+		t.Fatal("encoder.DeserializeRaw result wrong")
+	}
+
+	// Decode
+	var obj3 %[2]s
+	if n, err := %[6]s%[1]s(data2, &obj3); err != nil {
+		t.Fatalf("%[6]s%[1]s failed: %%v", err)
+	} else if n != uint64(len(data2)) {
+		t.Fatalf("%[6]s%[1]s bytes read length should be %%d, is %%d", len(data2), n)
+	}
+	if !cmp.Equal(obj2, obj3, cmpopts.EquateEmpty(), encodertest.IgnoreAllUnexported()) {
+		t.Fatal("encoder.DeserializeRaw() != %[6]s%[1]s()")
+	}
+
+	// Decode, excess buffer
+	var obj4 %[2]s
+	n, err := %[6]s%[1]s(data3, &obj4);
+	if err != nil {
+		t.Fatalf("%[6]s%[1]s failed: %%v", err)
+	}
+
+	if hasOmitEmptyField%[1]sForEncodeTest(&obj4) && omitEmptyLen%[1]sForEncodeTest(&obj4) == 0 {
+		// 4 bytes read for the omitEmpty length, which should be zero (see the 5 bytes added above)
+		if n != n2+4 {
+			t.Fatalf("%[6]s%[1]s bytes read length should be %%d, is %%d", n2+4, n)
+		}
+	} else {
+		if n != n2 {
+			t.Fatalf("%[6]s%[1]s bytes read length should be %%d, is %%d", n2, n)
+		}
+	}
+	if !cmp.Equal(obj2, obj4, cmpopts.EquateEmpty(), encodertest.IgnoreAllUnexported()) {
+		t.Fatal("encoder.DeserializeRaw() != %[6]s%[1]s()")
+	}
+
+	// DecodeExact
+	var obj5 %[2]s
+	if err := %[6]s%[1]sExact(data2, &obj5); err != nil {
+		t.Fatalf("%[6]s%[1]s failed: %%v", err)
+	}
+	if !cmp.Equal(obj2, obj5, cmpopts.EquateEmpty(), encodertest.IgnoreAllUnexported()) {
+		t.Fatal("encoder.DeserializeRaw() != %[6]s%[1]s()")
+	}
+
+	// Check that the bytes read value is correct when providing an extended buffer
+	if !hasOmitEmptyField%[1]sForEncodeTest(&obj3) || omitEmptyLen%[1]sForEncodeTest(&obj3) > 0 {
+		padding := []byte{0xFF, 0xFE, 0xFD, 0xFC}
+		data4 := append(data2[:], padding...)
+		if n, err := %[6]s%[1]s(data4, &obj3); err != nil {
+			t.Fatalf("%[6]s%[1]s failed: %%v", err)
+		} else if n != uint64(len(data2)) {
+			t.Fatalf("%[6]s%[1]s bytes read length should be %%d, is %%d", len(data2), n)
+		}
+	}
+}`, titledTypeName, fullTypeName, "", checkBytesEqual, encode, decode, reference, encoderObj, decodeReference, decoderObj, copyReference)
+}
+
+func buildTestVersion(typeName, typePackageName string, version uint64, hasMap, exported bool) string {
+	titledTypeName := strings.Title(typeName)
+	fullTypeName := typeName
+	if typePackageName != "" {
+		fullTypeName = fmt.Sprintf("%s.%s", typePackageName, typeName)
+	}
+
+	encode := "Encode"
+	if !exported {
+		encode = "encode"
+	}
+
+	decode := "Decode"
+	if !exported {
+		decode = "decode"
+	}
+
+	checkBytesEqual := ""
+	if !hasMap {
+		checkBytesEqual = fmt.Sprintf(`if !bytes.Equal(data1, data2) {
+			t.Fatal("%[1]s%[2]s() != %[1]s%[2]sVersion()")
+		}
+		`, encode, typeName)
+	}
+
+	checkVersionBytesEqual := ""
+	if !hasMap {
+		checkVersionBytesEqual = fmt.Sprintf(`if !bytes.Equal(data, data3) {
+				t.Fatalf("%[1]s%[2]sVersion() round trip mismatch at version %%d", version)
+			}
+			`, encode, typeName)
+	}
+
+	return fmt.Sprintf(`
+
+func testSkyencoder%[1]sVersion(t *testing.T, obj *%[2]s) {
+	// At the latest version, the versioned encoding matches the unversioned encoding
+	if n1, n2 := %[5]sSize%[1]s(obj), %[5]sSize%[1]sVersion(obj, %[4]d); n1 != n2 {
+		t.Fatalf("%[5]sSize%[1]s() != %[5]sSize%[1]sVersion() (%%d != %%d)", n1, n2)
+	}
+
+	data1, err := %[5]s%[1]s(obj)
+	if err != nil {
+		t.Fatalf("%[5]s%[1]s failed: %%v", err)
+	}
+
+	data2, err := %[5]s%[1]sVersion(obj, %[4]d)
+	if err != nil {
+		t.Fatalf("%[5]s%[1]sVersion failed: %%v", err)
+	}
+
+	if len(data1) != len(data2) {
+		t.Fatalf("len(%[5]s%[1]s()) != len(%[5]s%[1]sVersion()) (%%d != %%d)", len(data1), len(data2))
+	}
+
+	%[3]s
+
+	// Every version round trips through its own encoding
+	for version := uint64(1); version <= %[4]d; version++ {
+		n := %[5]sSize%[1]sVersion(obj, version)
+
+		data, err := %[5]s%[1]sVersion(obj, version)
+		if err != nil {
+			t.Fatalf("%[5]s%[1]sVersion failed at version %%d: %%v", version, err)
+		}
+		if uint64(len(data)) != n {
+			t.Fatalf("%[5]s%[1]sVersion produced bytes of unexpected length at version %%d", version)
+		}
+
+		var obj2 %[2]s
+		if err := %[6]s%[1]sVersionExact(data, &obj2, version); err != nil {
+			t.Fatalf("%[6]s%[1]sVersionExact failed at version %%d: %%v", version, err)
+		}
+
+		data3, err := %[5]s%[1]sVersion(&obj2, version)
+		if err != nil {
+			t.Fatalf("%[5]s%[1]sVersion failed at version %%d: %%v", version, err)
+		}
+		if len(data) != len(data3) {
+			t.Fatalf("%[5]s%[1]sVersion() round trip length mismatch at version %%d", version)
+		}
+
+		%[7]s
+	}
+}
+
+func TestSkyencoder%[1]sVersion(t *testing.T) {
+	rand := mathrand.New(mathrand.NewSource(time.Now().Unix()))
+
+	testSkyencoder%[1]sVersion(t, newEmpty%[1]sForEncodeTest())
+
+	for i := 0; i < 10; i++ {
+		testSkyencoder%[1]sVersion(t, newRandom%[1]sForEncodeTest(t, rand))
+		testSkyencoder%[1]sVersion(t, newRandomZeroLen%[1]sForEncodeTest(t, rand))
+	}
+}
+`, titledTypeName, fullTypeName, checkBytesEqual, version, encode, decode, checkVersionBytesEqual)
+}
+
+func buildTestFuncRoundTrip(titledTypeName, fullTypeName string, hasMap bool, encode, decode string) string {
+	checkBytesEqual := ""
+	if !hasMap {
+		checkBytesEqual = fmt.Sprintf(`if !bytes.Equal(data1, data2[:n1]) {
+			t.Fatal("%[1]s%[2]s() != %[1]s%[2]sToBuffer()")
+		}
+		`, encode, titledTypeName)
+	}
+
+	checkReencodeEqual := fmt.Sprintf(`if len(data1) != len(data3) {
+		t.Fatal("%[1]s%[2]s() round trip produced bytes of unexpected length")
+	}`, encode, titledTypeName)
+	if !hasMap {
+		checkReencodeEqual = fmt.Sprintf(`if !bytes.Equal(data1, data3) {
+		t.Fatal("%[1]s%[2]s() round trip produced different bytes")
+	}`, encode, titledTypeName)
+	}
+
+	return fmt.Sprintf(`func testSkyencoder%[1]s(t *testing.T, obj *%[2]s) {
+	// %[4]sSize
+
+	n1 := %[4]sSize%[1]s(obj)
+
+	// Encode
+	data1, err := %[4]s%[1]s(obj)
+	if err != nil {
+		t.Fatalf("%[4]s%[1]s failed: %%v", err)
+	}
+	if uint64(len(data1)) != n1 {
+		t.Fatal("%[4]s%[1]s produced bytes of unexpected length")
+	}
+
+	// EncodeToBuffer
+	data2 := make([]byte, n1+5)
+	if err := %[4]s%[1]sToBuffer(data2, obj); err != nil {
+		t.Fatalf("%[4]s%[1]sToBuffer failed: %%v", err)
+	}
+
+	%[3]s
+
+	// Decode
+	var obj2 %[2]s
+	if n, err := %[5]s%[1]s(data1, &obj2); err != nil {
+		t.Fatalf("%[5]s%[1]s failed: %%v", err)
+	} else if n != uint64(len(data1)) {
+		t.Fatalf("%[5]s%[1]s bytes read length should be %%d, is %%d", len(data1), n)
+	}
+	if !cmp.Equal(*obj, obj2, cmpopts.EquateEmpty(), encodertest.IgnoreAllUnexported()) {
+		t.Fatal("%[5]s%[1]s() result wrong")
+	}
+
+	// Decode, excess buffer
+	var obj3 %[2]s
+	n, err := %[5]s%[1]s(data2, &obj3)
+	if err != nil {
+		t.Fatalf("%[5]s%[1]s failed: %%v", err)
+	}
+
+	if hasOmitEmptyField%[1]sForEncodeTest(&obj3) && omitEmptyLen%[1]sForEncodeTest(&obj3) == 0 {
+		// 4 bytes read for the omitEmpty length, which should be zero (see the 5 bytes added above)
+		if n != n1+4 {
+			t.Fatalf("%[5]s%[1]s bytes read length should be %%d, is %%d", n1+4, n)
+		}
+	} else {
+		if n != n1 {
+			t.Fatalf("%[5]s%[1]s bytes read length should be %%d, is %%d", n1, n)
+		}
+	}
+	if !cmp.Equal(obj2, obj3, cmpopts.EquateEmpty(), encodertest.IgnoreAllUnexported()) {
+		t.Fatal("%[5]s%[1]s() with excess buffer result wrong")
+	}
+
+	// DecodeExact
+	var obj4 %[2]s
+	if err := %[5]s%[1]sExact(data1, &obj4); err != nil {
+		t.Fatalf("%[5]s%[1]sExact failed: %%v", err)
+	}
+	if !cmp.Equal(obj2, obj4, cmpopts.EquateEmpty(), encodertest.IgnoreAllUnexported()) {
+		t.Fatal("%[5]s%[1]sExact() result wrong")
+	}
+
+	// Encode the decoded object again
+	data3, err := %[4]s%[1]s(&obj2)
+	if err != nil {
+		t.Fatalf("%[4]s%[1]s failed: %%v", err)
+	}
+	%[6]s
+
+	// Check that the bytes read value is correct when providing an extended buffer
+	if !hasOmitEmptyField%[1]sForEncodeTest(&obj2) || omitEmptyLen%[1]sForEncodeTest(&obj2) > 0 {
+		padding := []byte{0xFF, 0xFE, 0xFD, 0xFC}
+		data4 := append(data1[:], padding...)
+		if n, err := %[5]s%[1]s(data4, &obj2); err != nil {
+			t.Fatalf("%[5]s%[1]s failed: %%v", err)
+		} else if n != uint64(len(data1)) {
+			t.Fatalf("%[5]s%[1]s bytes read length should be %%d, is %%d", len(data1), n)
+		}
+	}
+}`, titledTypeName, fullTypeName, checkBytesEqual, encode, decode, checkReencodeEqual)
+}
+
+// buildTestFieldHelpers builds the helpers inspecting the encoded fields of an object, shared by the test functions
+func buildTestFieldHelpers(titledTypeName string) string {
+	return fmt.Sprintf(`
+// isEncodableField%[1]sForEncodeTest returns true if a struct field is encoded
+func isEncodableField%[1]sForEncodeTest(f reflect.StructField) bool {
+	// Skip unexported fields
+	if f.PkgPath != "" {
+		return false
+	}
+
+	// Skip fields disabled with and enc:"- struct tag
+	tag := f.Tag.Get("enc")
+	return !strings.HasPrefix(tag, "-,") && tag != "-"
+}
+
+// numEncodableFields%[1]sForEncodeTest returns the number of encoded fields of a struct
+func numEncodableFields%[1]sForEncodeTest(obj interface{}) int {
+	v := reflect.ValueOf(obj)
+	switch v.Kind() {
+	case reflect.Ptr:
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+
+		n := 0
+		for i := 0; i < v.NumField(); i++ {
+			f := t.Field(i)
+			if !isEncodableField%[1]sForEncodeTest(f) {
+				continue
+			}
+			n++
+		}
+		return n
+	default:
+		return 0
+	}
+}
+
+// hasOmitEmptyField%[1]sForEncodeTest returns true if a struct has an omitempty field
+func hasOmitEmptyField%[1]sForEncodeTest(obj interface{}) bool {
+	v := reflect.ValueOf(obj)
+	switch v.Kind() {
+	case reflect.Ptr:
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		// The omitempty field is the last encoded field, which is declared last unless the fields are ordered
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			f := t.Field(i)
+			tag := f.Tag.Get("enc")
+			if isEncodableField%[1]sForEncodeTest(f) && strings.Contains(tag, ",omitempty") {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// omitEmptyLen%[1]sForEncodeTest returns the number of bytes encoded by an omitempty field on a given object
+func omitEmptyLen%[1]sForEncodeTest(obj interface{}) uint64 {
+	if !hasOmitEmptyField%[1]sForEncodeTest(obj) {
+		return 0
+	}
+
+	v := reflect.ValueOf(obj)
+	switch v.Kind() {
+	case reflect.Ptr:
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			if !strings.Contains(t.Field(i).Tag.Get("enc"), ",omitempty") {
+				continue
+			}
+			f := v.Field(i)
+			if f.Len() == 0 {
+				return 0
+			}
+			return uint64(4 + f.Len())
+		}
+		return 0
+
+	default:
+		return 0
+	}
+}
+`, titledTypeName)
+}
+
+// buildTestPopulateUnions builds the helper populating the values of interface types with random member types of their unions.
+// unionNames are the names of the interface types, and unionMemberNames the names of the member types of each.
+func buildTestPopulateUnions(titledTypeName, fullTypeName string, unionNames []string, unionMemberNames [][]string) string {
+	unions := make([]string, len(unionNames))
+	for i, name := range unionNames {
+		members := make([]string, len(unionMemberNames[i]))
+		for j, m := range unionMemberNames[i] {
+			members[j] = fmt.Sprintf("reflect.TypeOf((*%s)(nil)).Elem(),", m)
+		}
+
+		unions[i] = fmt.Sprintf(`reflect.TypeOf((*%[1]s)(nil)).Elem(): {
+			%[2]s
+		},`, name, strings.Join(members, "\n"))
+	}
+
+	return fmt.Sprintf(`
+// unionMembers%[1]sForEncodeTest are the member types of the unions in %[2]s, by interface type
+var unionMembers%[1]sForEncodeTest = map[reflect.Type][]reflect.Type{
+	%[3]s
+}
+
+// populateUnions%[1]sForEncodeTest sets the nil values of interface types in an object to randomly populated values
+// of random member types of their unions, or leaves them nil
+func populateUnions%[1]sForEncodeTest(t *testing.T, v reflect.Value, rand *mathrand.Rand, opts encodertest.PopulateRandomOptions) {
+	switch v.Kind() {
+	case reflect.Interface:
+		members := unionMembers%[1]sForEncodeTest[v.Type()]
+		i := rand.Intn(len(members) + 1)
+		if i == len(members) {
+			return
+		}
+
+		m := members[i]
+		if m.Kind() == reflect.Ptr {
+			m = m.Elem()
+		}
+
+		x := reflect.New(m)
+		if err := encodertest.PopulateRandom(x.Interface(), rand, opts); err != nil {
+			t.Fatalf("encodertest.PopulateRandom failed: %%v", err)
+		}
+
+		if members[i].Kind() == reflect.Ptr {
+			v.Set(x)
+		} else {
+			v.Set(x.Elem())
+		}
+
+	case reflect.Struct:
+		vt := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			if vt.Field(i).PkgPath == "" {
+				populateUnions%[1]sForEncodeTest(t, v.Field(i), rand, opts)
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			populateUnions%[1]sForEncodeTest(t, v.Index(i), rand, opts)
+		}
+
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(v.MapIndex(k))
+			populateUnions%[1]sForEncodeTest(t, elem, rand, opts)
+			v.SetMapIndex(k, elem)
+		}
+	}
+}
+`, titledTypeName, fullTypeName, strings.Join(unions, "\n"))
+}
+
+func buildTestOrdered(titledTypeName string) string {
+	return fmt.Sprintf(`
+// orderedType%[1]sForEncodeTest returns a type like t, except that its structs declare their encoded fields in encoding order
+// (enc:",order=N"), so that its values can be encoded by the reflect-based encoder, which encodes fields in declaration order
+func orderedType%[1]sForEncodeTest(t reflect.Type) reflect.Type {
+	switch t.Kind() {
+	case reflect.Array:
+		return reflect.ArrayOf(t.Len(), orderedType%[1]sForEncodeTest(t.Elem()))
+
+	case reflect.Slice:
+		return reflect.SliceOf(orderedType%[1]sForEncodeTest(t.Elem()))
+
+	case reflect.Map:
+		return reflect.MapOf(orderedType%[1]sForEncodeTest(t.Key()), orderedType%[1]sForEncodeTest(t.Elem()))
+
+	case reflect.Struct:
+		var fields []reflect.StructField
+		var orders []int
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			tag := f.Tag.Get("enc")
+			if f.PkgPath != "" || tag == "-" {
+				continue
+			}
+
+			order := 0
+			var options []string
+			for _, o := range strings.Split(tag, ",") {
+				if !strings.HasPrefix(o, "order=") {
+					options = append(options, o)
+					continue
+				}
+
+				n, err := strconv.Atoi(o[len("order="):])
+				if err != nil {
+					panic(err)
+				}
+				order = n
+			}
+
+			fields = append(fields, reflect.StructField{
+				Name: f.Name,
+				Type: orderedType%[1]sForEncodeTest(f.Type),
+				Tag:  reflect.StructTag(fmt.Sprintf("enc:%%q", strings.Join(options, ","))),
+			})
+			orders = append(orders, order)
+		}
+
+		// Either every encoded field of a struct has an order, from 1 to the number of encoded fields, or none does
+		if len(orders) != 0 && orders[0] != 0 {
+			ordered := make([]reflect.StructField, len(fields))
+			for i, f := range fields {
+				ordered[orders[i]-1] = f
+			}
+			fields = ordered
+		}
+
+		return reflect.StructOf(fields)
+
+	default:
+		return t
+	}
+}
+
+// copyOrdered%[1]sForEncodeTest copies src to dst, where the type of one is the ordered type of the other,
+// as returned by orderedType%[1]sForEncodeTest
+func copyOrdered%[1]sForEncodeTest(dst, src reflect.Value) {
+	switch dst.Kind() {
+	case reflect.Array:
+		for i := 0; i < dst.Len(); i++ {
+			copyOrdered%[1]sForEncodeTest(dst.Index(i), src.Index(i))
+		}
+
+	case reflect.Slice:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return
+		}
+
+		dst.Set(reflect.MakeSlice(dst.Type(), src.Len(), src.Len()))
+		for i := 0; i < src.Len(); i++ {
+			copyOrdered%[1]sForEncodeTest(dst.Index(i), src.Index(i))
+		}
+
+	case reflect.Map:
+		if src.IsNil() {
+			dst.Set(reflect.Zero(dst.Type()))
+			return
+		}
+
+		dst.Set(reflect.MakeMapWithSize(dst.Type(), src.Len()))
+		for _, k := range src.MapKeys() {
+			key := reflect.New(dst.Type().Key()).Elem()
+			copyOrdered%[1]sForEncodeTest(key, k)
+			elem := reflect.New(dst.Type().Elem()).Elem()
+			copyOrdered%[1]sForEncodeTest(elem, src.MapIndex(k))
+			dst.SetMapIndex(key, elem)
+		}
+
+	case reflect.Struct:
+		t := dst.Type()
+		for i := 0; i < dst.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" {
+				continue
+			}
+
+			// Fields which are not encoded are not in the ordered type
+			if sf, ok := src.Type().FieldByName(f.Name); ok && len(sf.Index) == 1 {
+				copyOrdered%[1]sForEncodeTest(dst.Field(i), src.Field(sf.Index[0]))
+			}
+		}
+
+	default:
+		dst.Set(src.Convert(dst.Type()))
+	}
+}
+`, titledTypeName)
+}
+
+func buildTestSetConst(titledTypeName string) string {
+	return fmt.Sprintf(`
+// setConst%[1]sForEncodeTest sets the fields of an object tagged with a constant (enc:",const=X") to the constant,
+// so that randomly populated objects are decoded to themselves
+func setConst%[1]sForEncodeTest(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		setConst%[1]sForEncodeTest(v.Elem())
+
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" {
+				continue
+			}
+
+			fv := v.Field(i)
+			for _, o := range strings.Split(f.Tag.Get("enc"), ",") {
+				if !strings.HasPrefix(o, "const=") {
+					continue
+				}
+				c := o[len("const="):]
+
+				switch fv.Kind() {
+				case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+					n, err := strconv.ParseInt(c, 0, 64)
+					if err != nil {
+						panic(err)
+					}
+					fv.SetInt(n)
+				case reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+					n, err := strconv.ParseUint(c, 0, 64)
+					if err != nil {
+						panic(err)
+					}
+					fv.SetUint(n)
+				case reflect.Array:
+					b, err := hex.DecodeString(strings.TrimPrefix(c, "0x"))
+					if err != nil {
+						panic(err)
+					}
+					for j, x := range b {
+						fv.Index(j).SetUint(uint64(x))
+					}
+				}
+			}
+
+			setConst%[1]sForEncodeTest(fv)
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			setConst%[1]sForEncodeTest(v.Index(i))
+		}
+
+	case reflect.Map:
+		if v.IsNil() {
+			return
+		}
+
+		m := reflect.MakeMap(v.Type())
+		for _, k := range v.MapKeys() {
+			key := reflect.New(v.Type().Key()).Elem()
+			key.Set(k)
+			setConst%[1]sForEncodeTest(key)
+
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(v.MapIndex(k))
+			setConst%[1]sForEncodeTest(elem)
+
+			m.SetMapIndex(key, elem)
+		}
+		v.Set(m)
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+
+		elem := reflect.New(v.Elem().Type()).Elem()
+		elem.Set(v.Elem())
+		setConst%[1]sForEncodeTest(elem)
+		v.Set(elem)
+	}
+}
+`, titledTypeName)
+}
+
+func buildTestResizeFixedLength(titledTypeName string) string {
+	return fmt.Sprintf(`
+// resizeFixedLength%[1]sForEncodeTest resizes the fields of an object tagged with a fixed length (enc:",len=N")
+// to their required length, so that randomly populated objects can be encoded
+func resizeFixedLength%[1]sForEncodeTest(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Ptr:
+		resizeFixedLength%[1]sForEncodeTest(v.Elem())
+
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			f := t.Field(i)
+			if f.PkgPath != "" {
+				continue
+			}
+
+			fv := v.Field(i)
+			for _, o := range strings.Split(f.Tag.Get("enc"), ",") {
+				if !strings.HasPrefix(o, "len=") {
+					continue
+				}
+
+				n, err := strconv.Atoi(o[len("len="):])
+				if err != nil {
+					panic(err)
+				}
+
+				switch fv.Kind() {
+				case reflect.String:
+					fv.SetString((fv.String() + strings.Repeat("x", n))[:n])
+				case reflect.Slice:
+					s := reflect.MakeSlice(fv.Type(), n, n)
+					reflect.Copy(s, fv)
+					fv.Set(s)
+				}
+			}
+
+			resizeFixedLength%[1]sForEncodeTest(fv)
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			resizeFixedLength%[1]sForEncodeTest(v.Index(i))
+		}
+
+	case reflect.Map:
+		if v.IsNil() {
+			return
+		}
+
+		m := reflect.MakeMap(v.Type())
+		for _, k := range v.MapKeys() {
+			key := reflect.New(v.Type().Key()).Elem()
+			key.Set(k)
+			resizeFixedLength%[1]sForEncodeTest(key)
+
+			elem := reflect.New(v.Type().Elem()).Elem()
+			elem.Set(v.MapIndex(k))
+			resizeFixedLength%[1]sForEncodeTest(elem)
+
+			m.SetMapIndex(key, elem)
+		}
+		v.Set(m)
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return
+		}
+
+		elem := reflect.New(v.Elem().Type()).Elem()
+		elem.Set(v.Elem())
+		resizeFixedLength%[1]sForEncodeTest(elem)
+		v.Set(elem)
+	}
+}
+`, titledTypeName)
+}
+
+func buildTestVectors(typeName, typePackageName, packageName, vectorsFilename string, hasMap, exported bool) string {
+	titledTypeName := strings.Title(typeName)
 	fullTypeName := typeName
 	if typePackageName != "" {
 		fullTypeName = fmt.Sprintf("%s.%s", typePackageName, typeName)
@@ -1001,12 +3171,24 @@ func buildTest(typeName, typePackageName, packageName string, hasMap, exported b
 		decode = "decode"
 	}
 
-	checkBytesEqual := ""
-	if !hasMap {
-		checkBytesEqual = fmt.Sprintf(`if !bytes.Equal(data1, data2) {
-			t.Fatal("encoder.Serialize() != %[2]s[1]s()")
+	// Map iteration order is random, so an object with maps is only re-encoded to the same bytes up to
+	// the order of its map entries. In that case, the re-encoded bytes are decoded and compared instead.
+	checkBytesEqual := fmt.Sprintf(`if !bytes.Equal(data, data2) {
+			t.Fatalf("vector %%d: %[1]s%[2]s() != vector encoding\n%%x\n%%x", i, data2, data)
+		}`, encode, titledTypeName)
+	if hasMap {
+		checkBytesEqual = fmt.Sprintf(`if len(data) != len(data2) {
+			t.Fatalf("vector %%d: len(%[1]s%[2]s()) != len(vector encoding) (%%d != %%d)", i, len(data2), len(data))
 		}
-		`, typeName, encode)
+
+		var obj2 %[4]s
+		if err := %[3]s%[2]sExact(data2, &obj2); err != nil {
+			t.Fatalf("vector %%d: %[3]s%[2]sExact failed: %%v", i, err)
+		}
+
+		if !cmp.Equal(obj, obj2, cmpopts.EquateEmpty(), encodertest.IgnoreAllUnexported()) {
+			t.Fatalf("vector %%d: %[3]s%[2]sExact(%[1]s%[2]s()) result wrong", i)
+		}`, encode, titledTypeName, decode, fullTypeName)
 	}
 
 	return fmt.Sprintf(`// Code generated by github.com/skycoin/skyencoder. DO NOT EDIT.
@@ -1015,417 +3197,620 @@ package %[3]s
 
 import (
 	"bytes"
-	"fmt"
-	mathrand "math/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
 	"testing"
-	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/skycoin/encodertest"
 )
 
-func newEmpty%[1]sForEncodeTest() *%[2]s {
-	var obj %[2]s
-	return &obj
-}
-
-func newRandom%[1]sForEncodeTest(t *testing.T, rand *mathrand.Rand) *%[2]s {
-	var obj %[2]s
-	err := encodertest.PopulateRandom(&obj, rand, encodertest.PopulateRandomOptions{
-		MaxRandLen: 4,
-		MinRandLen: 1,
-	})
+// TestSkyencoder%[1]sVectors decodes and re-encodes the golden test vectors of %[2]s, pinning its wire format.
+// The vectors are written by "skyencoder vectors"; only regenerate them when the wire format is changed on purpose.
+func TestSkyencoder%[1]sVectors(t *testing.T) {
+	b, err := ioutil.ReadFile(filepath.FromSlash(%[6]q))
 	if err != nil {
-		t.Fatalf("encodertest.PopulateRandom failed: %%v", err)
+		t.Fatalf("ioutil.ReadFile failed: %%v", err)
 	}
-	return &obj
+
+	var vf struct {
+		Struct  string `+"`json:\"struct\"`"+`
+		Vectors []struct {
+			Encoded string `+"`json:\"encoded\"`"+`
+		} `+"`json:\"vectors\"`"+`
+	}
+	if err := json.Unmarshal(b, &vf); err != nil {
+		t.Fatalf("json.Unmarshal failed: %%v", err)
+	}
+
+	if vf.Struct != %[7]q {
+		t.Fatalf("vectors are for struct %%q, not %[7]s", vf.Struct)
+	}
+
+	if len(vf.Vectors) == 0 {
+		t.Fatal("no vectors")
+	}
+
+	for i, v := range vf.Vectors {
+		data, err := hex.DecodeString(v.Encoded)
+		if err != nil {
+			t.Fatalf("vector %%d: hex.DecodeString failed: %%v", i, err)
+		}
+
+		var obj %[2]s
+		if err := %[5]s%[1]sExact(data, &obj); err != nil {
+			t.Fatalf("vector %%d: %[5]s%[1]sExact failed: %%v", i, err)
+		}
+
+		if n := %[4]sSize%[1]s(&obj); n != uint64(len(data)) {
+			t.Fatalf("vector %%d: %[4]sSize%[1]s() != len(vector encoding) (%%d != %%d)", i, n, len(data))
+		}
+
+		data2, err := %[4]s%[1]s(&obj)
+		if err != nil {
+			t.Fatalf("vector %%d: %[4]s%[1]s failed: %%v", i, err)
+		}
+
+		%[8]s
+	}
+}
+`, titledTypeName, fullTypeName, packageName, encode, decode, vectorsFilename, typeName, checkBytesEqual)
 }
 
-func newRandomZeroLen%[1]sForEncodeTest(t *testing.T, rand *mathrand.Rand) *%[2]s {
-	var obj %[2]s
-	err := encodertest.PopulateRandom(&obj, rand, encodertest.PopulateRandomOptions{
-		MaxRandLen:    0,
-		MinRandLen:    0,
-		EmptySliceNil: false,
-		EmptyMapNil:   false,
-	})
+// buildTestHash builds the test of the hash function of a struct. fields are the encoded fields of a struct with
+// nohash fields, whose sizes are used to omit the nohash fields from the encoding.
+func buildTestHash(typeName, typePackageName string, noHashFields []string, fields []hashTestField, exported, hasChecksum bool) string {
+	titledTypeName := strings.Title(typeName)
+	fullTypeName := typeName
+	if typePackageName != "" {
+		fullTypeName = fmt.Sprintf("%s.%s", typePackageName, typeName)
+	}
+
+	encode := "Encode"
+	hash := "Hash"
+	if !exported {
+		encode = "encode"
+		hash = "hash"
+	}
+
+	// The checksum of the checksum directive is not hashed
+	trimChecksum := ""
+	if hasChecksum {
+		trimChecksum = fmt.Sprintf(`
+	data = data[:len(data)-%d]
+	`, runtime.ChecksumSize)
+	}
+
+	// Without nohash fields, the hash is the hash of the encoding.
+	// Otherwise, it is the hash of the encoding without the nohash fields, and must not depend on them.
+	checkHash := fmt.Sprintf(`data, err := %[1]s%[2]s(obj)
 	if err != nil {
-		t.Fatalf("encodertest.PopulateRandom failed: %%v", err)
+		t.Fatalf("%[1]s%[2]s failed: %%v", err)
 	}
-	return &obj
-}
+	%[4]s
+	if h := %[3]s%[2]s(obj); h != cipher.SumSHA256(data) {
+		t.Fatal("%[3]s%[2]s() != cipher.SumSHA256(%[1]s%[2]s())")
+	}`, encode, titledTypeName, hash, trimChecksum)
+	sizeFuncs := ""
+	if len(noHashFields) != 0 {
+		zeroFields := make([]string, len(noHashFields))
+		for i, f := range noHashFields {
+			zeroFields[i] = fmt.Sprintf(`v.FieldByName(%[1]q).Set(reflect.Zero(v.FieldByName(%[1]q).Type()))`, f)
+		}
 
-func newRandomZeroLenNil%[1]sForEncodeTest(t *testing.T, rand *mathrand.Rand) *%[2]s {
-	var obj %[2]s
-	err := encodertest.PopulateRandom(&obj, rand, encodertest.PopulateRandomOptions{
-		MaxRandLen:    0,
-		MinRandLen:    0,
-		EmptySliceNil: true,
-		EmptyMapNil:   true,
-	})
+		fieldSizes := make([]string, len(fields))
+		for i, f := range fields {
+			funcName := fmt.Sprintf("encodeSize%s%sForHashTest", titledTypeName, f.name)
+			fieldSizes[i] = fmt.Sprintf(`{%q, %s(obj), %t},`, f.name, funcName, f.hashed)
+			sizeFuncs += fmt.Sprintf(`
+
+// %s computes the encoded size of the %s field of an object of type %s
+%s`, funcName, f.name, typeName, f.sizeFunc)
+		}
+
+		checkHash = fmt.Sprintf(`data, err := %[1]s%[2]s(obj)
 	if err != nil {
-		t.Fatalf("encodertest.PopulateRandom failed: %%v", err)
+		t.Fatalf("%[1]s%[2]s failed: %%v", err)
 	}
-	return &obj
+	%[4]s
+	// The hash is the hash of the encoding without the nohash fields
+	fields := []struct {
+		name   string
+		size   uint64
+		hashed bool
+	}{
+		%[5]s
+	}
+
+	var hashed []byte
+	for _, f := range fields {
+		if f.size > uint64(len(data)) {
+			t.Fatalf("Field %%s is truncated in %[1]s%[2]s()", f.name)
+		}
+		if f.hashed {
+			hashed = append(hashed, data[:f.size]...)
+		}
+		data = data[f.size:]
+	}
+	if len(data) != 0 {
+		t.Fatalf("%%d bytes remain after the fields of %[1]s%[2]s()", len(data))
+	}
+
+	if h := %[3]s%[2]s(obj); h != cipher.SumSHA256(hashed) {
+		t.Fatal("%[3]s%[2]s() != cipher.SumSHA256() of %[1]s%[2]s() without the nohash fields")
+	}
+
+	// The nohash fields are excluded from the hash
+	obj2 := *obj
+	v := reflect.ValueOf(&obj2).Elem()
+	%[6]s
+
+	if %[3]s%[2]s(obj) != %[3]s%[2]s(&obj2) {
+		t.Fatal("%[3]s%[2]s() depends on nohash fields")
+	}`, encode, titledTypeName, hash, trimChecksum, strings.Join(fieldSizes, "\n\t\t"), strings.Join(zeroFields, "\n\t"))
+	}
+
+	return sizeFuncs + fmt.Sprintf(`
+
+func testSkyencoder%[1]sHash(t *testing.T, obj *%[2]s) {
+	%[3]s
 }
 
+func TestSkyencoder%[1]sHash(t *testing.T) {
+	rand := mathrand.New(mathrand.NewSource(time.Now().Unix()))
 
-func testSkyencoder%[1]s(t *testing.T, obj *%[2]s) {
-	isEncodableField := func(f reflect.StructField) bool {
-		// Skip unexported fields
-		if f.PkgPath != "" {
-			return false
-		}
+	testSkyencoder%[1]sHash(t, newEmpty%[1]sForEncodeTest())
+
+	for i := 0; i < 10; i++ {
+		testSkyencoder%[1]sHash(t, newRandom%[1]sForEncodeTest(t, rand))
+		testSkyencoder%[1]sHash(t, newRandomZeroLen%[1]sForEncodeTest(t, rand))
+	}
+}
+`, titledTypeName, fullTypeName, checkHash)
+}
+
+func buildTestPeek(typeName, typePackageName string, fieldPaths []string, exported bool) string {
+	titledTypeName := strings.Title(typeName)
+	fullTypeName := typeName
+	if typePackageName != "" {
+		fullTypeName = fmt.Sprintf("%s.%s", typePackageName, typeName)
+	}
 
-		// Skip fields disabled with and enc:"- struct tag
-		tag := f.Tag.Get("enc")
-		return !strings.HasPrefix(tag, "-,") && tag != "-"
+	encode := "Encode"
+	peek := "Peek"
+	if !exported {
+		encode = "encode"
+		peek = "peek"
 	}
 
-	hasOmitEmptyField := func(obj interface{}) bool {
-		v := reflect.ValueOf(obj)
-		switch v.Kind() {
-		case reflect.Ptr:
-			v = v.Elem()
+	checks := make([]string, len(fieldPaths))
+	for i, f := range fieldPaths {
+		checks[i] = fmt.Sprintf(`{
+		v, err := %[1]s%[2]s%[3]s(data)
+		if err != nil {
+			t.Fatalf("%[1]s%[2]s%[3]s failed: %%v", err)
+		}
+		if !cmp.Equal(v, obj.%[4]s, cmpopts.EquateEmpty(), encodertest.IgnoreAllUnexported()) {
+			t.Fatal("%[1]s%[2]s%[3]s() != obj.%[4]s")
 		}
 
-		switch v.Kind() {
-		case reflect.Struct:
-			t := v.Type()
-			n := v.NumField()
-			f := t.Field(n - 1)
-			tag := f.Tag.Get("enc")
-			return isEncodableField(f) && strings.Contains(tag, ",omitempty")
-		default:
-			return false
+		if _, err := %[1]s%[2]s%[3]s(data[:0]); err != encoder.ErrBufferUnderflow {
+			t.Fatalf("%[1]s%[2]s%[3]s() with empty buffer expected encoder.ErrBufferUnderflow, got %%v", err)
 		}
+	}`, peek, titledTypeName, strings.Replace(f, ".", "", -1), f)
+	}
+
+	return fmt.Sprintf(`
+
+func testSkyencoder%[1]sPeek(t *testing.T, obj *%[2]s) {
+	data, err := %[3]s%[1]s(obj)
+	if err != nil {
+		t.Fatalf("%[3]s%[1]s failed: %%v", err)
+	}
+
+	%[4]s
+}
+
+func TestSkyencoder%[1]sPeek(t *testing.T) {
+	rand := mathrand.New(mathrand.NewSource(time.Now().Unix()))
+
+	testSkyencoder%[1]sPeek(t, newEmpty%[1]sForEncodeTest())
+
+	for i := 0; i < 10; i++ {
+		testSkyencoder%[1]sPeek(t, newRandom%[1]sForEncodeTest(t, rand))
+		testSkyencoder%[1]sPeek(t, newRandomZeroLen%[1]sForEncodeTest(t, rand))
+	}
+}
+`, titledTypeName, fullTypeName, encode, strings.Join(checks, "\n\n\t"))
+}
+
+func buildTestValidate(typeName, typePackageName string, exported bool) string {
+	titledTypeName := strings.Title(typeName)
+	fullTypeName := typeName
+	if typePackageName != "" {
+		fullTypeName = fmt.Sprintf("%s.%s", typePackageName, typeName)
+	}
+
+	encode := "Encode"
+	decode := "Decode"
+	validate := "Validate"
+	if !exported {
+		encode = "encode"
+		decode = "decode"
+		validate = "validate"
+	}
+
+	return fmt.Sprintf(`
+
+func testSkyencoder%[1]sValidate(t *testing.T, obj *%[2]s) {
+	data, err := %[3]s%[1]s(obj)
+	if err != nil {
+		t.Fatalf("%[3]s%[1]s failed: %%v", err)
+	}
+
+	n, err := %[5]s%[1]s(data)
+	if err != nil {
+		t.Fatalf("%[5]s%[1]s failed: %%v", err)
+	}
+	if n != uint64(len(data)) {
+		t.Fatalf("%[5]s%[1]s bytes used != len(data) (%%d != %%d)", n, len(data))
+	}
+
+	if err := %[5]s%[1]sExact(data); err != nil {
+		t.Fatalf("%[5]s%[1]sExact failed: %%v", err)
 	}
 
-	// returns the number of bytes encoded by an omitempty field on a given object
-	omitEmptyLen := func(obj interface{}) uint64 {
-		if !hasOmitEmptyField(obj) {
-			return 0
+	// %[5]s%[1]s agrees with %[4]s%[1]s on every truncated or extended buffer
+	for i := 0; i <= len(data); i++ {
+		var obj2 %[2]s
+		n1, err1 := %[4]s%[1]s(data[:i], &obj2)
+		n2, err2 := %[5]s%[1]s(data[:i])
+		if err1 != err2 || n1 != n2 {
+			t.Fatalf("%[5]s%[1]s(data[:%%d]) = (%%d, %%v), %[4]s%[1]s returned (%%d, %%v)", i, n2, err2, n1, err1)
 		}
 
-		v := reflect.ValueOf(obj)
-		switch v.Kind() {
-		case reflect.Ptr:
-			v = v.Elem()
+		err1 = %[4]s%[1]sExact(data[:i], &obj2)
+		err2 = %[5]s%[1]sExact(data[:i])
+		if err1 != err2 {
+			t.Fatalf("%[5]s%[1]sExact(data[:%%d]) = %%v, %[4]s%[1]sExact returned %%v", i, err2, err1)
 		}
+	}
+
+	// A trailing byte is either remaining or, after an omitempty field, a truncated length prefix
+	extended := append(data[:len(data):len(data)], 0)
+	var obj2 %[2]s
+	err1 := %[4]s%[1]sExact(extended, &obj2)
+	err2 := %[5]s%[1]sExact(extended)
+	if err2 == nil || err1 != err2 {
+		t.Fatalf("%[5]s%[1]sExact with extra bytes = %%v, %[4]s%[1]sExact returned %%v", err2, err1)
+	}
+}
+
+func TestSkyencoder%[1]sValidate(t *testing.T) {
+	rand := mathrand.New(mathrand.NewSource(time.Now().Unix()))
 
-		switch v.Kind() {
-		case reflect.Struct:
-			n := v.NumField()
-			f := v.Field(n - 1)
-			if f.Len() == 0 {
-				return 0
-			}
-			return uint64(4 + f.Len())
+	testSkyencoder%[1]sValidate(t, newEmpty%[1]sForEncodeTest())
 
-		default:
-			return 0
-		}
+	for i := 0; i < 10; i++ {
+		testSkyencoder%[1]sValidate(t, newRandom%[1]sForEncodeTest(t, rand))
+		testSkyencoder%[1]sValidate(t, newRandomZeroLen%[1]sForEncodeTest(t, rand))
 	}
+}
+`, titledTypeName, fullTypeName, encode, decode, validate)
+}
 
-	// %[5]sSize
-
-	n1 := encoder.Size(obj)
-	n2 := %[5]sSize%[1]s(obj)
+func buildTestDecodeReuse(typeName, typePackageName string, exported bool) string {
+	titledTypeName := strings.Title(typeName)
+	fullTypeName := typeName
+	if typePackageName != "" {
+		fullTypeName = fmt.Sprintf("%s.%s", typePackageName, typeName)
+	}
 
-	if uint64(n1) != n2 {
-		t.Fatalf("encoder.Size() != %[5]sSize%[1]s() (%%d != %%d)", n1, n2)
+	encode := "Encode"
+	decode := "Decode"
+	if !exported {
+		encode = "encode"
+		decode = "decode"
 	}
 
-	// Encode
+	return fmt.Sprintf(`
 
-	// encoder.Serialize
-	data1 := encoder.Serialize(obj)
+func testSkyencoder%[1]sDecodeReuse(t *testing.T, obj, reused *%[2]s) {
+	data, err := %[3]s%[1]s(obj)
+	if err != nil {
+		t.Fatalf("%[3]s%[1]s failed: %%v", err)
+	}
 
-	// Encode
-	data2, err := %[5]s%[1]s(obj)
+	n, err := %[4]s%[1]sReuse(data, reused)
 	if err != nil {
-		t.Fatalf("%[5]s%[1]s failed: %%v", err)
+		t.Fatalf("%[4]s%[1]sReuse failed: %%v", err)
 	}
-	if uint64(len(data2)) != n2 {
-		t.Fatal("%[5]s%[1]s produced bytes of unexpected length")
+	if n != uint64(len(data)) {
+		t.Fatalf("%[4]s%[1]sReuse bytes read length should be %%d, is %%d", len(data), n)
 	}
-	if len(data1) != len(data2) {
-		t.Fatalf("len(encoder.Serialize()) != len(%[5]s%[1]s()) (%%d != %%d)", len(data1), len(data2))
+
+	if !cmp.Equal(*obj, *reused, cmpopts.EquateEmpty(), encodertest.IgnoreAllUnexported()) {
+		t.Fatal("%[4]s%[1]sReuse result wrong")
 	}
 
-	// EncodeToBuffer
-	data3 := make([]byte, n2+5)
-	if err := %[5]s%[1]sToBuffer(data3, obj); err != nil {
-		t.Fatalf("%[5]s%[1]sToBuffer failed: %%v", err)
+	if err := %[4]s%[1]sReuseExact(data, reused); err != nil {
+		t.Fatalf("%[4]s%[1]sReuseExact failed: %%v", err)
 	}
 
-	%[4]s
+	if !cmp.Equal(*obj, *reused, cmpopts.EquateEmpty(), encodertest.IgnoreAllUnexported()) {
+		t.Fatal("%[4]s%[1]sReuseExact result wrong")
+	}
+}
 
-	// Decode
+func TestSkyencoder%[1]sDecodeReuse(t *testing.T) {
+	rand := mathrand.New(mathrand.NewSource(time.Now().Unix()))
 
-	// encoder.DeserializeRaw
-	var obj2 %[2]s
-	if n, err := encoder.DeserializeRaw(data1, &obj2); err != nil {
-		t.Fatalf("encoder.DeserializeRaw failed: %%v", err)
-	} else if n != uint64(len(data1)) {
-		t.Fatalf("encoder.DeserializeRaw failed: %%v", encoder.ErrRemainingBytes)
-	}
-	if !cmp.Equal(*obj, obj2, cmpopts.EquateEmpty(), encodertest.IgnoreAllUnexported()) {
-		t.Fatal("encoder.DeserializeRaw result wrong")
+	// Decode a sequence of objects into the same object, so that each decode starts from the previous object
+	var reused %[2]s
+	testSkyencoder%[1]sDecodeReuse(t, newEmpty%[1]sForEncodeTest(), &reused)
+
+	for i := 0; i < 10; i++ {
+		testSkyencoder%[1]sDecodeReuse(t, newRandom%[1]sForEncodeTest(t, rand), &reused)
+		testSkyencoder%[1]sDecodeReuse(t, newRandomZeroLen%[1]sForEncodeTest(t, rand), &reused)
+		testSkyencoder%[1]sDecodeReuse(t, newEmpty%[1]sForEncodeTest(), &reused)
 	}
+}
+`, titledTypeName, fullTypeName, encode, decode)
+}
 
-	// Decode
-	var obj3 %[2]s
-	if n, err := %[6]s%[1]s(data2, &obj3); err != nil {
-		t.Fatalf("%[6]s%[1]s failed: %%v", err)
-	} else if n != uint64(len(data2)) {
-		t.Fatalf("%[6]s%[1]s bytes read length should be %%d, is %%d", len(data2), n)
+func buildTestFormat(typeName, typePackageName string, hasMap, exported bool, labels []string) string {
+	titledTypeName := strings.Title(typeName)
+	fullTypeName := typeName
+	if typePackageName != "" {
+		fullTypeName = fmt.Sprintf("%s.%s", typePackageName, typeName)
 	}
-	if !cmp.Equal(obj2, obj3, cmpopts.EquateEmpty(), encodertest.IgnoreAllUnexported()) {
-		t.Fatal("encoder.DeserializeRaw() != %[6]s%[1]s()")
+
+	encode := "Encode"
+	decode := "Decode"
+	format := "Format"
+	if !exported {
+		encode = "encode"
+		decode = "decode"
+		format = "format"
 	}
 
-	// Decode, excess buffer
-	var obj4 %[2]s
-	n, err := %[6]s%[1]s(data3, &obj4);
+	// Map iteration order is random, so the entries of a map may be formatted in a different order after decoding
+	checkDecoded := ""
+	if !hasMap {
+		checkDecoded = fmt.Sprintf(`
+	data, err := %[3]s%[1]s(obj)
 	if err != nil {
-		t.Fatalf("%[6]s%[1]s failed: %%v", err)
+		t.Fatalf("%[3]s%[1]s failed: %%v", err)
 	}
 
-	if hasOmitEmptyField(&obj4) && omitEmptyLen(&obj4) == 0 {
-		// 4 bytes read for the omitEmpty length, which should be zero (see the 5 bytes added above)
-		if n != n2+4 {
-			t.Fatalf("%[6]s%[1]s bytes read length should be %%d, is %%d", n2+4, n)
-		}
-	} else {
-		if n != n2 {
-			t.Fatalf("%[6]s%[1]s bytes read length should be %%d, is %%d", n2, n)
-		}
-	}
-	if !cmp.Equal(obj2, obj4, cmpopts.EquateEmpty(), encodertest.IgnoreAllUnexported()) {
-		t.Fatal("encoder.DeserializeRaw() != %[6]s%[1]s()")
+	var obj2 %[2]s
+	if err := %[4]s%[1]sExact(data, &obj2); err != nil {
+		t.Fatalf("%[4]s%[1]sExact failed: %%v", err)
 	}
 
-	// DecodeExact
-	var obj5 %[2]s
-	if err := %[6]s%[1]sExact(data2, &obj5); err != nil {
-		t.Fatalf("%[6]s%[1]s failed: %%v", err)
+	if s2 := %[5]s%[1]s(&obj2); s2 != s {
+		t.Fatalf("%[5]s%[1]s(%[4]s%[1]sExact(%[3]s%[1]s())) != %[5]s%[1]s()\n%%s\n%%s", s2, s)
+	}`, titledTypeName, fullTypeName, encode, decode, format)
 	}
-	if !cmp.Equal(obj2, obj5, cmpopts.EquateEmpty(), encodertest.IgnoreAllUnexported()) {
-		t.Fatal("encoder.DeserializeRaw() != %[6]s%[1]s()")
+
+	checkGoString := ""
+	if typePackageName == "" {
+		notEqual := "s2 != s"
+		if hasMap {
+			notEqual = "len(s2) != len(s)"
+		}
+
+		checkGoString = fmt.Sprintf(`
+	if s2 := fmt.Sprintf("%%#v", *obj); %[3]s {
+		t.Fatalf("GoString() != %[2]s%[1]s()\n%%s\n%%s", s2, s)
+	}`, titledTypeName, format, notEqual)
 	}
 
-	// Check that the bytes read value is correct when providing an extended buffer
-	if !hasOmitEmptyField(&obj3) || omitEmptyLen(&obj3) > 0 {
-		padding := []byte{0xFF, 0xFE, 0xFD, 0xFC}
-		data4 := append(data2[:], padding...)
-		if n, err := %[6]s%[1]s(data4, &obj3); err != nil {
-			t.Fatalf("%[6]s%[1]s failed: %%v", err)
-		} else if n != uint64(len(data2)) {
-			t.Fatalf("%[6]s%[1]s bytes read length should be %%d, is %%d", len(data2), n)
+	checkLabels := ""
+	if len(labels) != 0 {
+		quoted := make([]string, len(labels))
+		for i, l := range labels {
+			quoted[i] = strconv.Quote(l)
+		}
+
+		checkLabels = fmt.Sprintf(`
+	// The fields are formatted in encoded order
+	pos := 0
+	for _, label := range []string{%[3]s} {
+		i := strings.Index(s[pos:], label)
+		if i < 0 {
+			t.Fatalf("%[2]s%[1]s() = %%s, expected %%q after offset %%d", s, label, pos)
 		}
+		pos += i + len(label)
+	}`, titledTypeName, format, strings.Join(quoted, ", "))
+	}
+
+	return fmt.Sprintf(`
+
+func testSkyencoder%[1]sFormat(t *testing.T, obj *%[2]s) {
+	s := %[3]s%[1]s(obj)
+	if !strings.HasPrefix(s, %[4]q) || !strings.HasSuffix(s, "}") {
+		t.Fatalf("%[3]s%[1]s() = %%s", s)
 	}
+	%[7]s
+	%[5]s
+	%[6]s
 }
 
-func TestSkyencoder%[1]s(t *testing.T) {
+func TestSkyencoder%[1]sFormat(t *testing.T) {
 	rand := mathrand.New(mathrand.NewSource(time.Now().Unix()))
 
-	type testCase struct {
-		name string
-		obj  *%[2]s
+	testSkyencoder%[1]sFormat(t, newEmpty%[1]sForEncodeTest())
+
+	for i := 0; i < 10; i++ {
+		testSkyencoder%[1]sFormat(t, newRandom%[1]sForEncodeTest(t, rand))
+		testSkyencoder%[1]sFormat(t, newRandomZeroLen%[1]sForEncodeTest(t, rand))
 	}
+}
+`, titledTypeName, fullTypeName, format, typeName+"{", checkDecoded, checkGoString, checkLabels)
+}
 
-	cases := []testCase{
-		{
-			name: "empty object",
-			obj:  newEmpty%[1]sForEncodeTest(),
-		},
+func buildTestFramed(typeName, typePackageName string, hasMap, exported bool) string {
+	titledTypeName := strings.Title(typeName)
+	fullTypeName := typeName
+	if typePackageName != "" {
+		fullTypeName = fmt.Sprintf("%s.%s", typePackageName, typeName)
 	}
 
-	nRandom := 10
+	encode := "Encode"
+	writeFramed := "WriteFramed"
+	readFramed := "ReadFramed"
+	if !exported {
+		encode = "encode"
+		writeFramed = "writeFramed"
+		readFramed = "readFramed"
+	}
 
-	for i := 0; i < nRandom; i++ {
-		cases = append(cases, testCase{
-			name: fmt.Sprintf("randomly populated object %%d", i),
-			obj:  newRandom%[1]sForEncodeTest(t, rand),
-		})
-		cases = append(cases, testCase{
-			name: fmt.Sprintf("randomly populated object %%d with zero length variable length contents", i),
-			obj:  newRandomZeroLen%[1]sForEncodeTest(t, rand),
-		})
-		cases = append(cases, testCase{
-			name: fmt.Sprintf("randomly populated object %%d with zero length variable length contents set to nil", i),
-			obj:  newRandomZeroLenNil%[1]sForEncodeTest(t, rand),
-		})
+	// Map iteration order is random, so the payload of an object with a map is only compared by length
+	checkPayload := fmt.Sprintf(`if !bytes.Equal(frame[runtime.FrameHeaderSize:], data) {
+		t.Fatal("%[1]s%[2]s() payload != %[3]s%[2]s()")
+	}`, writeFramed, titledTypeName, encode)
+	if hasMap {
+		checkPayload = fmt.Sprintf(`if len(frame)-runtime.FrameHeaderSize != len(data) {
+		t.Fatalf("len(%[1]s%[2]s() payload) != len(%[3]s%[2]s()) (%%d != %%d)", len(frame)-runtime.FrameHeaderSize, len(data))
+	}`, writeFramed, titledTypeName, encode)
 	}
 
-	for _, tc := range cases {
-		t.Run(tc.name, func(t *testing.T) {
-			testSkyencoder%[1]s(t, tc.obj)
-		})
+	return fmt.Sprintf(`
+
+func testSkyencoder%[1]sFramed(t *testing.T, obj *%[2]s) {
+	data, err := %[3]s%[1]s(obj)
+	if err != nil {
+		t.Fatalf("%[3]s%[1]s failed: %%v", err)
 	}
-}
 
-func decode%[1]sExpectError(t *testing.T, buf []byte, expectedErr error) {
-	var obj %[2]s
-	if _, err := %[6]s%[1]s(buf, &obj); err == nil {
-		t.Fatal("%[6]s%[1]s: expected error, got nil")
-	} else if err != expectedErr {
-		t.Fatalf("%[6]s%[1]s: expected error %%q, got %%q", expectedErr, err)
+	var w bytes.Buffer
+	if err := %[4]s%[1]s(&w, obj); err != nil {
+		t.Fatalf("%[4]s%[1]s failed: %%v", err)
 	}
-}
+	frame := w.Bytes()
 
-func decode%[1]sExactExpectError(t *testing.T, buf []byte, expectedErr error) {
-	var obj %[2]s
-	if err := %[6]s%[1]sExact(buf, &obj); err == nil {
-		t.Fatal("%[6]s%[1]sExact: expected error, got nil")
-	} else if err != expectedErr {
-		t.Fatalf("%[6]s%[1]sExact: expected error %%q, got %%q", expectedErr, err)
+	if len(frame) < runtime.FrameHeaderSize || binary.LittleEndian.Uint32(frame) != uint32(len(data)) {
+		t.Fatalf("%[4]s%[1]s() length prefix != len(%[3]s%[1]s()) (%%v != %%d)", frame[:runtime.FrameHeaderSize], len(data))
 	}
-}
 
-func testSkyencoder%[1]sDecodeErrors(t *testing.T, k int, tag string, obj *%[2]s) {
-	isEncodableField := func(f reflect.StructField) bool {
-		// Skip unexported fields
-		if f.PkgPath != "" {
-			return false
-		}
+	%[6]s
 
-		// Skip fields disabled with and enc:"- struct tag
-		tag := f.Tag.Get("enc")
-		return !strings.HasPrefix(tag, "-,") && tag != "-"
-	}
+	// A stream of two frames is read one frame at a time
+	r := bytes.NewReader(append(append([]byte{}, frame...), frame...))
+	for i := 0; i < 2; i++ {
+		var obj2 %[2]s
+		if err := %[5]s%[1]s(r, &obj2, uint32(len(data))); err != nil {
+			t.Fatalf("%[5]s%[1]s failed: %%v", err)
+		}
 
-	numEncodableFields := func(obj interface{}) int {
-		v := reflect.ValueOf(obj)
-		switch v.Kind() {
-		case reflect.Ptr:
-			v = v.Elem()
+		if !cmp.Equal(*obj, obj2, cmpopts.EquateEmpty(), encodertest.IgnoreAllUnexported()) {
+			t.Fatal("%[5]s%[1]s(%[4]s%[1]s()) != obj")
 		}
+	}
 
-		switch v.Kind() {
-		case reflect.Struct:
-			t := v.Type()
+	var obj2 %[2]s
+	if err := %[5]s%[1]s(r, &obj2, uint32(len(data))); err != io.EOF {
+		t.Fatalf("%[5]s%[1]s at the end of the stream expected error %%v, got %%v", io.EOF, err)
+	}
 
-			n := 0
-			for i := 0; i < v.NumField(); i++ {
-				f := t.Field(i)
-				if !isEncodableField(f) {
-					continue
-				}
-				n++
-			}
-			return n
-		default:
-			return 0
+	// The frame length is checked against the limit before the payload is read
+	if len(data) != 0 {
+		if err := %[5]s%[1]s(bytes.NewReader(frame[:runtime.FrameHeaderSize]), &obj2, uint32(len(data)-1)); err != runtime.ErrFrameTooLarge {
+			t.Fatalf("%[5]s%[1]s with a frame larger than maxFrame expected error %%v, got %%v", runtime.ErrFrameTooLarge, err)
 		}
 	}
 
-	hasOmitEmptyField := func(obj interface{}) bool {
-		v := reflect.ValueOf(obj)
-		switch v.Kind() {
-		case reflect.Ptr:
-			v = v.Elem()
-		}
+	// A frame ending early is unexpected
+	if err := %[5]s%[1]s(bytes.NewReader(frame[:len(frame)-1]), &obj2, uint32(len(data))); err != io.ErrUnexpectedEOF {
+		t.Fatalf("%[5]s%[1]s with a truncated frame expected error %%v, got %%v", io.ErrUnexpectedEOF, err)
+	}
 
-		switch v.Kind() {
-		case reflect.Struct:
-			t := v.Type()
-			n := v.NumField()
-			f := t.Field(n - 1)
-			tag := f.Tag.Get("enc")
-			return isEncodableField(f) && strings.Contains(tag, ",omitempty")
-		default:
-			return false
-		}
+	// A frame with bytes after the object is rejected
+	padded := make([]byte, runtime.FrameHeaderSize, len(frame)+1)
+	binary.LittleEndian.PutUint32(padded, uint32(len(data)+1))
+	padded = append(append(padded, data...), 0)
+	if err := %[5]s%[1]s(bytes.NewReader(padded), &obj2, uint32(len(data)+1)); err == nil {
+		t.Fatal("%[5]s%[1]s with extra bytes in the frame succeeded")
 	}
+}
 
-	// returns the number of bytes encoded by an omitempty field on a given object
-	omitEmptyLen := func(obj interface{}) uint64 {
-		if !hasOmitEmptyField(obj) {
-			return 0
-		}
+func TestSkyencoder%[1]sFramed(t *testing.T) {
+	rand := mathrand.New(mathrand.NewSource(time.Now().Unix()))
 
-		v := reflect.ValueOf(obj)
-		switch v.Kind() {
-		case reflect.Ptr:
-			v = v.Elem()
-		}
+	testSkyencoder%[1]sFramed(t, newEmpty%[1]sForEncodeTest())
 
-		switch v.Kind() {
-		case reflect.Struct:
-			n := v.NumField()
-			f := v.Field(n - 1)
-			if f.Len() == 0 {
-				return 0
-			}
-			return uint64(4 + f.Len())
+	for i := 0; i < 10; i++ {
+		testSkyencoder%[1]sFramed(t, newRandom%[1]sForEncodeTest(t, rand))
+		testSkyencoder%[1]sFramed(t, newRandomZeroLen%[1]sForEncodeTest(t, rand))
+	}
+}
+`, titledTypeName, fullTypeName, encode, writeFramed, readFramed, checkPayload)
+}
 
-		default:
-			return 0
-		}
+func buildTestEncodePooled(typeName, typePackageName string, hasMap, exported bool) string {
+	titledTypeName := strings.Title(typeName)
+	fullTypeName := typeName
+	if typePackageName != "" {
+		fullTypeName = fmt.Sprintf("%s.%s", typePackageName, typeName)
 	}
 
-	n := %[5]sSize%[1]s(obj)
-	buf, err := %[5]s%[1]s(obj);
-	if err != nil {
-		t.Fatalf("%[5]s%[1]s failed: %%v", err)
+	encode := "Encode"
+	decode := "Decode"
+	if !exported {
+		encode = "encode"
+		decode = "decode"
 	}
 
-	// A nil buffer cannot decode, unless the object is a struct with a single omitempty field
-	if hasOmitEmptyField(obj) && numEncodableFields(obj) > 1 {
-		t.Run(fmt.Sprintf("%%d %%s buffer underflow nil", k, tag), func(t *testing.T) {
-			decode%[1]sExpectError(t, nil, encoder.ErrBufferUnderflow)
-		})
+	// Map iteration order is random, so an object with a map is compared after decoding
+	checkEqual := fmt.Sprintf(`if !bytes.Equal(data, buf.Bytes()) {
+		t.Fatal("%[1]s%[2]sPooled() != %[1]s%[2]s()")
+	}`, encode, titledTypeName)
+	if hasMap {
+		checkEqual = fmt.Sprintf(`if len(data) != buf.Len() {
+		t.Fatalf("len(%[1]s%[2]sPooled()) != len(%[1]s%[2]s()) (%%d != %%d)", buf.Len(), len(data))
+	}
 
-		t.Run(fmt.Sprintf("%%d %%s exact buffer underflow nil", k, tag), func(t *testing.T) {
-			decode%[1]sExactExpectError(t, nil, encoder.ErrBufferUnderflow)
-		})
+	var obj2 %[4]s
+	if err := %[3]s%[2]sExact(buf.Bytes(), &obj2); err != nil {
+		t.Fatalf("%[3]s%[2]sExact failed: %%v", err)
 	}
 
-	// Test all possible truncations of the encoded byte array, but skip
-	// a truncation that would be valid where omitempty is removed
-	skipN := n - omitEmptyLen(obj)
-	for i := uint64(0); i < n; i++ {
-		if i == skipN {
-			continue
-		}
+	if !cmp.Equal(*obj, obj2, cmpopts.EquateEmpty(), encodertest.IgnoreAllUnexported()) {
+		t.Fatal("%[3]s%[2]sExact(%[1]s%[2]sPooled()) != obj")
+	}`, encode, titledTypeName, decode, fullTypeName)
+	}
 
-		t.Run(fmt.Sprintf("%%d %%s buffer underflow bytes=%%d", k, tag, i), func(t *testing.T) {
-			decode%[1]sExpectError(t, buf[:i], encoder.ErrBufferUnderflow)
-		})
+	return fmt.Sprintf(`
 
-		t.Run(fmt.Sprintf("%%d %%s exact buffer underflow bytes=%%d", k, tag, i), func(t *testing.T) {
-			decode%[1]sExactExpectError(t, buf[:i], encoder.ErrBufferUnderflow)
-		})
+func testSkyencoder%[1]sEncodePooled(t *testing.T, obj *%[2]s) {
+	data, err := %[3]s%[1]s(obj)
+	if err != nil {
+		t.Fatalf("%[3]s%[1]s failed: %%v", err)
 	}
 
-	// Append 5 bytes for omit empty with a 0 length prefix, to cause an ErrRemainingBytes.
-	// If only 1 byte is appended, the decoder will try to read the 4-byte length prefix,
-	// and return an ErrBufferUnderflow instead
-	if hasOmitEmptyField(obj) {
-		buf = append(buf, []byte{0, 0, 0, 0, 0}...)
-	} else {
-		buf = append(buf, 0)
+	buf, err := %[3]s%[1]sPooled(obj)
+	if err != nil {
+		t.Fatalf("%[3]s%[1]sPooled failed: %%v", err)
 	}
 
-	t.Run(fmt.Sprintf("%%d %%s exact buffer remaining bytes", k, tag), func(t *testing.T) {
-		decode%[1]sExactExpectError(t, buf, encoder.ErrRemainingBytes)
-	})
+	%[4]s
+
+	buf.Release()
 }
 
-func TestSkyencoder%[1]sDecodeErrors(t *testing.T) {
+func TestSkyencoder%[1]sEncodePooled(t *testing.T) {
 	rand := mathrand.New(mathrand.NewSource(time.Now().Unix()))
-	n := 10
 
-	for i := 0; i < n; i++ {
-		emptyObj := newEmpty%[1]sForEncodeTest()
-		fullObj := newRandom%[1]sForEncodeTest(t, rand)
-		testSkyencoder%[1]sDecodeErrors(t, i, "empty", emptyObj)
-		testSkyencoder%[1]sDecodeErrors(t, i, "full", fullObj)
+	testSkyencoder%[1]sEncodePooled(t, newEmpty%[1]sForEncodeTest())
+
+	for i := 0; i < 10; i++ {
+		testSkyencoder%[1]sEncodePooled(t, newRandom%[1]sForEncodeTest(t, rand))
+		testSkyencoder%[1]sEncodePooled(t, newRandomZeroLen%[1]sForEncodeTest(t, rand))
 	}
 }
-
-`, titledTypeName, fullTypeName, packageName, checkBytesEqual, encode, decode)
+`, titledTypeName, fullTypeName, encode, checkEqual)
 }
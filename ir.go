@@ -0,0 +1,1032 @@
+package skyencoder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+)
+
+// This file is the intermediate representation (IR) for the encoded size functions and the fixed size runs,
+// which are rendered to go/ast instead of being built by the fmt.Sprintf templates of format.go.
+// Each step can be built, optimized, rendered and tested independently of the others.
+//
+// The encoded size of a type is a list of typed sizeSteps, covering all the types the encoder supports.
+//
+// The encode and decode code of a struct is a list of codeSteps, but only the fixed size fields are typed leaf
+// steps, which optimizeRuns merges into runs. The slices, maps, strings, pointers and unions, and the omitempty
+// and since options, are still code sections built by the templates of format.go, as is the skip code.
+// Moving them to typed steps is left to a follow-up change.
+
+// sizeStep is a step of the code computing the encoded size of an object, which adds to a counter
+type sizeStep interface {
+	// stmts renders the step as statements adding to the counter
+	stmts(counter *ast.Ident) []ast.Stmt
+}
+
+// sizeFixed adds a fixed size
+type sizeFixed struct {
+	size uint64
+}
+
+// sizeElems adds the size of the elements of a string, slice or map, whose elements have a fixed size
+type sizeElems struct {
+	name ast.Expr
+	size uint64
+}
+
+// sizeRange adds the sizes of the elements of an array, slice or map, whose elements have a dynamic size.
+// length is the length of an array, or -1 for a slice or map.
+// key and value are the names of the variables of the elements, nil if they are not used.
+type sizeRange struct {
+	name   ast.Expr
+	length int64
+	key    *ast.Ident
+	value  *ast.Ident
+	body   []sizeStep
+}
+
+// sizeIf adds its steps if a condition is true, for omitempty and versioned fields
+type sizeIf struct {
+	cond ast.Expr
+	body []sizeStep
+}
+
+// sizeCall adds the size of a recursive type, computed by its function literal fn
+type sizeCall struct {
+	fn   string
+	name ast.Expr
+}
+
+// sizeUnion adds the size of the value of an interface type, for the member type of the value.
+// The tag byte is added by a sizeFixed step. Values of other types add nothing, and fail to encode.
+type sizeUnion struct {
+	name  ast.Expr
+	cases []sizeCase
+}
+
+// sizeCase is the case of a member type of a sizeUnion, whose steps name the value x.
+// The steps of a pointer type are only added if the pointer is not nil.
+type sizeCase struct {
+	typ     ast.Expr
+	pointer bool
+	body    []sizeStep
+}
+
+// sizeFunc is the function literal computing the size of a recursive type, which calls itself with sizeCall steps
+type sizeFunc struct {
+	name  string
+	param *ast.Field
+	steps []sizeStep
+}
+
+func (s sizeFixed) stmts(counter *ast.Ident) []ast.Stmt {
+	if s.size == 1 {
+		return []ast.Stmt{&ast.IncDecStmt{
+			X:   counter,
+			Tok: token.INC,
+		}}
+	}
+
+	return []ast.Stmt{addAssignStmt(counter, uintLit(s.size))}
+}
+
+func (s sizeElems) stmts(counter *ast.Ident) []ast.Stmt {
+	var x ast.Expr = uint64Conv(lenCall(s.name))
+	if s.size != 1 {
+		x = &ast.BinaryExpr{
+			X:  x,
+			Op: token.MUL,
+			Y:  uintLit(s.size),
+		}
+	}
+
+	return []ast.Stmt{addAssignStmt(counter, x)}
+}
+
+func (s sizeRange) stmts(counter *ast.Ident) []ast.Stmt {
+	r := &ast.RangeStmt{
+		X:    s.name,
+		Body: &ast.BlockStmt{List: renderSizeSteps(counter, s.body)},
+	}
+
+	switch {
+	case s.key != nil:
+		r.Key = s.key
+	case s.value != nil:
+		r.Key = ast.NewIdent("_")
+	}
+
+	if s.value != nil {
+		r.Value = s.value
+	}
+
+	if r.Key != nil {
+		r.Tok = token.DEFINE
+	}
+
+	return []ast.Stmt{r}
+}
+
+func (s sizeIf) stmts(counter *ast.Ident) []ast.Stmt {
+	return []ast.Stmt{&ast.IfStmt{
+		Cond: s.cond,
+		Body: &ast.BlockStmt{List: renderSizeSteps(counter, s.body)},
+	}}
+}
+
+func (s sizeCall) stmts(counter *ast.Ident) []ast.Stmt {
+	return []ast.Stmt{addAssignStmt(counter, &ast.CallExpr{
+		Fun: ast.NewIdent(s.fn),
+		Args: []ast.Expr{&ast.UnaryExpr{
+			Op: token.AND,
+			X:  s.name,
+		}},
+	})}
+}
+
+func (s sizeUnion) stmts(counter *ast.Ident) []ast.Stmt {
+	var clauses []ast.Stmt
+	bind := false
+	for _, c := range s.cases {
+		if _, fixed := fixedSizeSteps(c.body); c.pointer || !fixed {
+			bind = true
+		}
+
+		body := renderSizeSteps(counter, c.body)
+		if c.pointer {
+			body = []ast.Stmt{&ast.IfStmt{
+				Cond: &ast.BinaryExpr{
+					X:  ast.NewIdent("x"),
+					Op: token.NEQ,
+					Y:  ast.NewIdent("nil"),
+				},
+				Body: &ast.BlockStmt{List: body},
+			}}
+		}
+
+		clauses = append(clauses, &ast.CaseClause{
+			List: []ast.Expr{c.typ},
+			Body: body,
+		})
+	}
+
+	// x is only bound if a case uses it, because an unused x does not compile
+	var assign ast.Stmt = &ast.ExprStmt{X: &ast.TypeAssertExpr{X: s.name}}
+	if bind {
+		assign = &ast.AssignStmt{
+			Lhs: []ast.Expr{ast.NewIdent("x")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{&ast.TypeAssertExpr{X: s.name}},
+		}
+	}
+
+	return []ast.Stmt{&ast.TypeSwitchStmt{
+		Assign: assign,
+		Body:   &ast.BlockStmt{List: clauses},
+	}}
+}
+
+// renderSizeSteps renders steps as statements adding to the counter
+func renderSizeSteps(counter *ast.Ident, steps []sizeStep) []ast.Stmt {
+	var stmts []ast.Stmt
+	for _, s := range steps {
+		stmts = append(stmts, s.stmts(counter)...)
+	}
+	return stmts
+}
+
+// optimizeSize optimizes steps without changing the size they compute:
+// the fixed size of the elements of a sizeRange is added once for all elements, outside of the loop,
+// adjacent sizeIf steps with the same condition are merged,
+// and the fixed sizes of a list of steps are added at once, before the other steps.
+func optimizeSize(steps []sizeStep) []sizeStep {
+	var fixed uint64
+	var optimized []sizeStep
+
+	add := func(s sizeStep) {
+		if f, ok := s.(sizeFixed); ok {
+			fixed += f.size
+		} else {
+			optimized = append(optimized, s)
+		}
+	}
+
+	for _, s := range steps {
+		switch x := s.(type) {
+		case sizeRange:
+			x.body = optimizeSize(x.body)
+
+			// After optimizing, the fixed size of the elements is the first step of the body
+			if len(x.body) != 0 {
+				if f, ok := x.body[0].(sizeFixed); ok {
+					x.body = x.body[1:]
+					if x.length >= 0 {
+						add(sizeFixed{size: uint64(x.length) * f.size})
+					} else {
+						add(sizeElems{
+							name: x.name,
+							size: f.size,
+						})
+					}
+				}
+			}
+
+			if len(x.body) != 0 {
+				add(x)
+			}
+
+		case sizeIf:
+			// Adjacent steps with the same condition, such as fields introduced in the same version, are merged
+			if n := len(optimized); n != 0 {
+				if prev, ok := optimized[n-1].(sizeIf); ok && exprString(prev.cond) == exprString(x.cond) {
+					optimized = optimized[:n-1]
+					x.body = append(append([]sizeStep{}, prev.body...), x.body...)
+				}
+			}
+
+			x.body = optimizeSize(x.body)
+			if len(x.body) != 0 {
+				add(x)
+			}
+
+		case sizeUnion:
+			// Members with a fixed size are not optimized, because the size is only added for values of the member type
+			var cases []sizeCase
+			for _, c := range x.cases {
+				c.body = optimizeSize(c.body)
+				if len(c.body) != 0 {
+					cases = append(cases, c)
+				}
+			}
+
+			x.cases = cases
+			if len(x.cases) != 0 {
+				add(x)
+			}
+
+		default:
+			add(s)
+		}
+	}
+
+	if fixed != 0 {
+		optimized = append([]sizeStep{sizeFixed{size: fixed}}, optimized...)
+	}
+
+	return optimized
+}
+
+// fixedSizeSteps returns the size computed by optimized steps, if it is fixed
+func fixedSizeSteps(steps []sizeStep) (uint64, bool) {
+	switch len(steps) {
+	case 0:
+		return 0, true
+	case 1:
+		if f, ok := steps[0].(sizeFixed); ok {
+			return f.size, true
+		}
+	}
+	return 0, false
+}
+
+// buildSizeSteps returns the steps computing the encoded size of a value of type t, named x
+func buildSizeSteps(t types.Type, p *types.Package, x ast.Expr, depth int, options *Options) ([]sizeStep, error) {
+	debugPrintf("buildSizeSteps type=%T depth=%d options=%+v\n", t, depth, options)
+
+	if options != nil {
+		if options.OmitEmpty && !omitEmptyIsValid(t) {
+			return nil, errors.New("omitempty is only valid for array, slice, map and string")
+		}
+	}
+
+	switch tt := t.(type) {
+	case *types.Named:
+		if isRecursive(tt) {
+			return []sizeStep{sizeCall{
+				fn:   recursiveFuncName("encodeSize", tt),
+				name: x,
+			}}, nil
+		}
+		if isUnion(tt) {
+			members, err := findUnion(tt, options)
+			if err != nil {
+				return nil, err
+			}
+
+			u := sizeUnion{name: x}
+			for _, m := range members {
+				var value ast.Expr = ast.NewIdent("x")
+				typ := namedTypeExpr(m.t, p)
+				if m.pointer {
+					value = &ast.ParenExpr{X: &ast.StarExpr{X: value}}
+					typ = &ast.StarExpr{X: typ}
+				}
+
+				steps, err := buildSizeSteps(m.t, p, value, depth, inheritOptions(options, nil))
+				if err != nil {
+					return nil, err
+				}
+
+				u.cases = append(u.cases, sizeCase{
+					typ:     typ,
+					pointer: m.pointer,
+					body:    steps,
+				})
+			}
+
+			return []sizeStep{sizeFixed{size: 1}, u}, nil
+		}
+		return buildSizeSteps(tt.Underlying(), p, x, depth, options)
+
+	case *types.Basic:
+		if tt.Kind() != types.String {
+			size, _, err := fixedEncodedSize(tt, options)
+			if err != nil {
+				return nil, fmt.Errorf("%v (var=%q)", err, exprString(x))
+			}
+			return []sizeStep{sizeFixed{size: size}}, nil
+		}
+
+		if options != nil && options.Length > 0 {
+			return []sizeStep{sizeFixed{size: options.Length}}, nil
+		}
+
+		return sizeOmitEmpty(x, []sizeStep{
+			sizeFixed{size: 4},
+			sizeElems{name: x, size: 1},
+		}, options), nil
+
+	case *types.Array:
+		if isByte(tt.Elem()) {
+			return []sizeStep{sizeFixed{size: uint64(tt.Len())}}, nil
+		}
+
+		return buildSizeElemSteps(tt.Elem(), p, x, tt.Len(), depth, options)
+
+	case *types.Slice:
+		if empty, err := isEmptyStruct(tt.Elem()); err != nil {
+			return nil, err
+		} else if empty {
+			return nil, fmt.Errorf("A slice of an empty encoded struct is not allowed (var=%q)", exprString(x))
+		}
+
+		if options != nil && options.Length > 0 {
+			if isByte(tt.Elem()) {
+				return []sizeStep{sizeFixed{size: options.Length}}, nil
+			}
+
+			return buildSizeElemSteps(tt.Elem(), p, x, int64(options.Length), depth, options)
+		}
+
+		if isByte(tt.Elem()) {
+			return sizeOmitEmpty(x, []sizeStep{
+				sizeFixed{size: 4},
+				sizeElems{name: x, size: 1},
+			}, options), nil
+		}
+
+		elemSteps, err := buildSizeElemSteps(tt.Elem(), p, x, -1, depth, options)
+		if err != nil {
+			return nil, err
+		}
+
+		return sizeOmitEmpty(x, append([]sizeStep{sizeFixed{size: 4}}, elemSteps...), options), nil
+
+	case *types.Map:
+		key := ast.NewIdent(fmt.Sprintf("k%d", depth+1))
+		value := ast.NewIdent(fmt.Sprintf("v%d", depth+1))
+
+		keySteps, err := buildSizeSteps(tt.Key(), p, key, depth+1, inheritOptions(options, nil))
+		if err != nil {
+			return nil, err
+		}
+		keySteps = optimizeSize(keySteps)
+
+		elemSteps, err := buildSizeSteps(tt.Elem(), p, value, depth+1, inheritOptions(options, nil))
+		if err != nil {
+			return nil, err
+		}
+		elemSteps = optimizeSize(elemSteps)
+
+		r := sizeRange{
+			name:   x,
+			length: -1,
+			body:   append(keySteps, elemSteps...),
+		}
+		if _, fixed := fixedSizeSteps(keySteps); !fixed {
+			r.key = key
+		}
+		if _, fixed := fixedSizeSteps(elemSteps); !fixed {
+			r.value = value
+		}
+
+		return sizeOmitEmpty(x, []sizeStep{sizeFixed{size: 4}, r}, options), nil
+
+	case *types.Struct:
+		order, err := encodedFields(tt)
+		if err != nil {
+			return nil, err
+		}
+
+		var steps []sizeStep
+		for j, i := range order {
+			f := tt.Field(i)
+
+			if !f.Exported() {
+				continue
+			}
+
+			ignore, fieldOptions, err := parseTag(tt.Tag(i))
+			if err != nil {
+				return nil, err
+			}
+
+			if ignore {
+				continue
+			}
+
+			// NOTES ON OMITEMPTY
+			// - Must be last field in struct
+			// - Only applies to arrays, slices, maps and string
+			if fieldOptions != nil && fieldOptions.OmitEmpty && j != len(order)-1 {
+				return nil, errors.New("omitempty option can only be used on the last field in a struct")
+			}
+
+			fieldSteps, err := buildSizeSteps(f.Type(), p, &ast.SelectorExpr{
+				X:   x,
+				Sel: ast.NewIdent(f.Name()),
+			}, depth, inheritOptions(options, fieldOptions))
+			if err != nil {
+				return nil, err
+			}
+
+			steps = append(steps, fieldSteps...)
+		}
+
+		return steps, nil
+
+	default:
+		return nil, fmt.Errorf("Unhandled type %T for var %s", tt, exprString(x))
+	}
+}
+
+// buildSizeElemSteps returns the steps computing the encoded size of the elements of an array, slice or map named x,
+// excluding the length prefix. length is the length of an array, or -1 for a slice or map.
+func buildSizeElemSteps(elem types.Type, p *types.Package, x ast.Expr, length int64, depth int, options *Options) ([]sizeStep, error) {
+	value := ast.NewIdent(fmt.Sprintf("x%d", depth+1))
+
+	steps, err := buildSizeSteps(elem, p, value, depth+1, inheritOptions(options, nil))
+	if err != nil {
+		return nil, err
+	}
+
+	return []sizeStep{sizeRange{
+		name:   x,
+		length: length,
+		value:  value,
+		body:   steps,
+	}}, nil
+}
+
+// sizeOmitEmpty only adds the steps of an omitempty field if it is not empty
+func sizeOmitEmpty(x ast.Expr, steps []sizeStep, options *Options) []sizeStep {
+	if options == nil || !options.OmitEmpty {
+		return steps
+	}
+
+	return []sizeStep{sizeIf{
+		cond: &ast.BinaryExpr{
+			X:  lenCall(x),
+			Op: token.NEQ,
+			Y:  &ast.BasicLit{Kind: token.INT, Value: "0"},
+		},
+		body: steps,
+	}}
+}
+
+// sizeSince only adds the steps of a versioned field at its version or later
+func sizeSince(steps []sizeStep, since uint64) []sizeStep {
+	return []sizeStep{sizeIf{
+		cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("version"),
+			Op: token.GEQ,
+			Y:  uintLit(since),
+		},
+		body: steps,
+	}}
+}
+
+// buildSizeFuncs returns the function literals computing the sizes of the recursive types contained in the
+// encoded fields of types. p is the package of the generated code, or nil if it is not the package of the types,
+// and options are the options of the struct.
+func buildSizeFuncs(ts []types.Type, p *types.Package, options *Options) ([]sizeFunc, error) {
+	rts, err := recursiveTypes(ts...)
+	if err != nil {
+		return nil, err
+	}
+
+	funcs := make([]sizeFunc, len(rts))
+	for i, rt := range rts {
+		steps, err := buildSizeSteps(rt.Underlying(), p, ast.NewIdent("obj"), 0, recursiveOptions(options))
+		if err != nil {
+			return nil, err
+		}
+
+		pkgName := ""
+		if p == nil || rt.Obj().Pkg().Path() != p.Path() {
+			pkgName = rt.Obj().Pkg().Name()
+		}
+
+		funcs[i] = sizeFunc{
+			name:  recursiveFuncName("encodeSize", rt),
+			param: objParam(rt.Obj().Name(), pkgName),
+			steps: steps,
+		}
+	}
+
+	return funcs, nil
+}
+
+// renderSizeFunc renders a function computing the encoded size of an object with the steps,
+// declaring the function literals funcs of the recursive types which the steps call.
+// The function's doc comment is added by the caller, because go/ast comments are attached to source positions.
+func renderSizeFunc(name string, params []*ast.Field, funcs []sizeFunc, steps []sizeStep) ([]byte, error) {
+	var body []ast.Stmt
+
+	// The literals are declared before being defined, so that they can call each other
+	for _, f := range funcs {
+		body = append(body, &ast.DeclStmt{Decl: &ast.GenDecl{
+			Tok: token.VAR,
+			Specs: []ast.Spec{&ast.ValueSpec{
+				Names: []*ast.Ident{ast.NewIdent(f.name)},
+				Type:  sizeFuncType([]*ast.Field{f.param}),
+			}},
+		}})
+	}
+	for _, f := range funcs {
+		body = append(body, &ast.AssignStmt{
+			Lhs: []ast.Expr{ast.NewIdent(f.name)},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{&ast.FuncLit{
+				Type: sizeFuncType([]*ast.Field{f.param}),
+				Body: sizeFuncBody(f.steps),
+			}},
+		})
+	}
+
+	body = append(body, sizeFuncBody(steps).List...)
+
+	decl := &ast.FuncDecl{
+		Name: ast.NewIdent(name),
+		Type: sizeFuncType(params),
+		Body: &ast.BlockStmt{List: body},
+	}
+
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, token.NewFileSet(), decl); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// sizeFuncType returns the type of a function computing an encoded size
+func sizeFuncType(params []*ast.Field) *ast.FuncType {
+	return &ast.FuncType{
+		Params: &ast.FieldList{List: params},
+		Results: &ast.FieldList{List: []*ast.Field{{
+			Type: ast.NewIdent("uint64"),
+		}}},
+	}
+}
+
+// sizeFuncBody returns the body of a function computing an encoded size with the steps
+func sizeFuncBody(steps []sizeStep) *ast.BlockStmt {
+	counter := ast.NewIdent("i")
+
+	body := []ast.Stmt{&ast.AssignStmt{
+		Lhs: []ast.Expr{counter},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{uint64Conv(&ast.BasicLit{Kind: token.INT, Value: "0"})},
+	}}
+	body = append(body, renderSizeSteps(counter, optimizeSize(steps))...)
+	body = append(body, &ast.ReturnStmt{
+		Results: []ast.Expr{counter},
+	})
+
+	return &ast.BlockStmt{List: body}
+}
+
+// codeStep is a step of the code encoding or decoding a value
+type codeStep interface {
+	// render returns the source of the step
+	render() (string, error)
+}
+
+// leafStep is a step encoding or decoding numbers and byte arrays, which is merged with adjacent leaf steps
+// into a single step with a single buffer length check
+type leafStep interface {
+	codeStep
+	// fixedLeaves returns the numbers and byte arrays of the step, in encoding order
+	fixedLeaves() []fixedLeaf
+	// run returns the step encoding or decoding leaves at once
+	run(leaves []fixedLeaf) codeStep
+}
+
+// codeSection is a code section built by the templates of format.go
+type codeSection struct {
+	src string
+}
+
+// encodeLeaves encodes the numbers and byte arrays of a field with its code section, unless merged into an encodeRun
+type encodeLeaves struct {
+	leaves  []fixedLeaf
+	section string
+}
+
+// encodeRun writes a run of numbers and byte arrays to the encoder's buffer after a single bounds check
+type encodeRun struct {
+	leaves []fixedLeaf
+}
+
+// decodeLeaves decodes the numbers and byte arrays of a field with its code section, unless merged into a decodeRun
+type decodeLeaves struct {
+	leaves  []fixedLeaf
+	section string
+}
+
+// decodeRun reads a run of numbers and byte arrays from the decoder's buffer after a single length check
+type decodeRun struct {
+	leaves []fixedLeaf
+}
+
+func (s codeSection) render() (string, error) {
+	return s.src, nil
+}
+
+func (s encodeLeaves) render() (string, error) {
+	return s.section, nil
+}
+
+func (s encodeLeaves) fixedLeaves() []fixedLeaf {
+	return s.leaves
+}
+
+func (s encodeLeaves) run(leaves []fixedLeaf) codeStep {
+	return encodeRun{leaves: leaves}
+}
+
+func (s decodeLeaves) render() (string, error) {
+	return s.section, nil
+}
+
+func (s decodeLeaves) fixedLeaves() []fixedLeaf {
+	return s.leaves
+}
+
+func (s decodeLeaves) run(leaves []fixedLeaf) codeStep {
+	return decodeRun{leaves: leaves}
+}
+
+func (s encodeRun) render() (string, error) {
+	b := ast.NewIdent("b")
+
+	// b := e.Buffer[:n]
+	stmts := []ast.Stmt{&ast.AssignStmt{
+		Lhs: []ast.Expr{b},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{&ast.SliceExpr{
+			X:    encoderBuffer("e"),
+			High: intLit(runSize(s.leaves)),
+		}},
+	}}
+
+	offset := int64(0)
+	for _, f := range s.leaves {
+		x, err := parser.ParseExpr(f.varName)
+		if err != nil {
+			return "", err
+		}
+
+		dst := &ast.SliceExpr{
+			X:    b,
+			Low:  intLit(offset),
+			High: intLit(offset + f.size),
+		}
+
+		switch f.kind {
+		case types.Invalid:
+			stmts = append(stmts, &ast.ExprStmt{X: callExpr(ast.NewIdent("copy"), dst, &ast.SliceExpr{X: x})})
+
+		case types.Int8, types.Uint8:
+			if f.castType || f.kind == types.Int8 {
+				x = callExpr(ast.NewIdent("uint8"), x)
+			}
+			stmts = append(stmts, &ast.AssignStmt{
+				Lhs: []ast.Expr{&ast.IndexExpr{X: b, Index: intLit(offset)}},
+				Tok: token.ASSIGN,
+				Rhs: []ast.Expr{x},
+			})
+
+		default:
+			uintType := ast.NewIdent(fmt.Sprintf("uint%d", 8*f.size))
+			switch f.kind {
+			case types.Float32, types.Float64:
+				if f.castType {
+					x = callExpr(ast.NewIdent(fmt.Sprintf("float%d", 8*f.size)), x)
+				}
+				x = callExpr(selectorExpr("math", fmt.Sprintf("Float%dbits", 8*f.size)), x)
+			case types.Int16, types.Int32, types.Int64:
+				x = callExpr(uintType, x)
+			default:
+				if f.castType {
+					x = callExpr(uintType, x)
+				}
+			}
+
+			put := &ast.SelectorExpr{
+				X:   selectorExpr("binary", byteOrder(f.bigEndian)),
+				Sel: ast.NewIdent(fmt.Sprintf("PutUint%d", 8*f.size)),
+			}
+			stmts = append(stmts, &ast.ExprStmt{X: callExpr(put, dst, x)})
+		}
+
+		offset += f.size
+	}
+
+	// e.Buffer = e.Buffer[n:]
+	stmts = append(stmts, advanceBuffer("e", offset))
+
+	return renderRun(s.leaves, stmts)
+}
+
+func (s decodeRun) render() (string, error) {
+	n := runSize(s.leaves)
+
+	// if len(d.Buffer) < n { return 0, encoder.ErrBufferUnderflow }
+	stmts := []ast.Stmt{&ast.IfStmt{
+		Cond: &ast.BinaryExpr{
+			X:  lenCall(encoderBuffer("d")),
+			Op: token.LSS,
+			Y:  intLit(n),
+		},
+		Body: &ast.BlockStmt{List: []ast.Stmt{&ast.ReturnStmt{
+			Results: []ast.Expr{
+				&ast.BasicLit{Kind: token.INT, Value: "0"},
+				selectorExpr("encoder", "ErrBufferUnderflow"),
+			},
+		}}},
+	}}
+
+	offset := int64(0)
+	for _, f := range s.leaves {
+		x, err := parser.ParseExpr(f.varName)
+		if err != nil {
+			return "", err
+		}
+
+		src := &ast.SliceExpr{
+			X:    encoderBuffer("d"),
+			Low:  intLit(offset),
+			High: intLit(offset + f.size),
+		}
+
+		if f.kind == types.Invalid {
+			stmts = append(stmts, &ast.ExprStmt{X: callExpr(ast.NewIdent("copy"), &ast.SliceExpr{X: x}, src)})
+			offset += f.size
+			continue
+		}
+
+		var value ast.Expr
+		switch f.kind {
+		case types.Int8, types.Uint8:
+			value = &ast.IndexExpr{X: encoderBuffer("d"), Index: intLit(offset)}
+		default:
+			get := &ast.SelectorExpr{
+				X:   selectorExpr("binary", byteOrder(f.bigEndian)),
+				Sel: ast.NewIdent(fmt.Sprintf("Uint%d", 8*f.size)),
+			}
+			value = callExpr(get, src)
+		}
+
+		typeName, err := parser.ParseExpr(f.typeName)
+		if err != nil {
+			return "", err
+		}
+
+		switch f.kind {
+		case types.Float32, types.Float64:
+			value = callExpr(selectorExpr("math", fmt.Sprintf("Float%dfrombits", 8*f.size)), value)
+		case types.Int8, types.Int16, types.Int32, types.Int64:
+			if !f.castType {
+				value = callExpr(typeName, value)
+			}
+		}
+
+		if f.castType {
+			value = callExpr(typeName, value)
+		}
+
+		stmts = append(stmts, &ast.AssignStmt{
+			Lhs: []ast.Expr{x},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{value},
+		})
+		offset += f.size
+	}
+
+	// d.Buffer = d.Buffer[n:]
+	stmts = append(stmts, advanceBuffer("d", n))
+
+	return renderRun(s.leaves, stmts)
+}
+
+// optimizeRuns merges adjacent leaf steps with more than one number or byte array in total into a single run step
+func optimizeRuns(steps []codeStep) []codeStep {
+	var optimized []codeStep
+	var pending []leafStep
+	var leaves []fixedLeaf
+
+	flush := func() {
+		if len(leaves) > 1 {
+			optimized = append(optimized, pending[0].run(leaves))
+		} else {
+			for _, s := range pending {
+				optimized = append(optimized, s)
+			}
+		}
+		pending = nil
+		leaves = nil
+	}
+
+	for _, s := range steps {
+		l, ok := s.(leafStep)
+		if !ok {
+			flush()
+			optimized = append(optimized, s)
+			continue
+		}
+
+		pending = append(pending, l)
+		leaves = append(leaves, l.fixedLeaves()...)
+	}
+
+	flush()
+
+	return optimized
+}
+
+// renderCodeSteps renders steps as the source of a code section
+func renderCodeSteps(steps []codeStep) (string, error) {
+	var sections []string
+	for _, s := range steps {
+		src, err := s.render()
+		if err != nil {
+			return "", err
+		}
+		if src != "" {
+			sections = append(sections, src)
+		}
+	}
+
+	return strings.Join(sections, "\n\n"), nil
+}
+
+// renderRun renders the statements of a run step in a block, commented with the names of its values
+func renderRun(leaves []fixedLeaf, stmts []ast.Stmt) (string, error) {
+	names := make([]string, len(leaves))
+	for i, f := range leaves {
+		names[i] = f.varName
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "\n// %s\n", strings.Join(names, ", "))
+	if err := printer.Fprint(&buf, token.NewFileSet(), &ast.BlockStmt{List: stmts}); err != nil {
+		return "", err
+	}
+	buf.WriteString("\n")
+
+	return buf.String(), nil
+}
+
+// runSize returns the encoded size of the numbers and byte arrays of a run
+func runSize(leaves []fixedLeaf) int64 {
+	n := int64(0)
+	for _, f := range leaves {
+		n += f.size
+	}
+	return n
+}
+
+// encoderBuffer returns the Buffer field of the encoder or decoder named name
+func encoderBuffer(name string) ast.Expr {
+	return selectorExpr(name, "Buffer")
+}
+
+// advanceBuffer returns the statement removing the first n bytes of the buffer of the encoder or decoder named name
+func advanceBuffer(name string, n int64) ast.Stmt {
+	return &ast.AssignStmt{
+		Lhs: []ast.Expr{encoderBuffer(name)},
+		Tok: token.ASSIGN,
+		Rhs: []ast.Expr{&ast.SliceExpr{
+			X:   encoderBuffer(name),
+			Low: intLit(n),
+		}},
+	}
+}
+
+// objParam returns the obj *T parameter of a generated function, for a type in the package named typePackageName,
+// or in the same package if typePackageName is empty
+func objParam(typeName, typePackageName string) *ast.Field {
+	var t ast.Expr = ast.NewIdent(typeName)
+	if typePackageName != "" {
+		t = &ast.SelectorExpr{
+			X:   ast.NewIdent(typePackageName),
+			Sel: ast.NewIdent(typeName),
+		}
+	}
+
+	return &ast.Field{
+		Names: []*ast.Ident{ast.NewIdent("obj")},
+		Type:  &ast.StarExpr{X: t},
+	}
+}
+
+// namedTypeExpr returns the name of a named type in the generated code, qualified by its package name
+// unless it is in the package p
+func namedTypeExpr(t *types.Named, p *types.Package) ast.Expr {
+	if p != nil && t.Obj().Pkg().Path() == p.Path() {
+		return ast.NewIdent(t.Obj().Name())
+	}
+
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(t.Obj().Pkg().Name()),
+		Sel: ast.NewIdent(t.Obj().Name()),
+	}
+}
+
+func addAssignStmt(counter *ast.Ident, x ast.Expr) ast.Stmt {
+	return &ast.AssignStmt{
+		Lhs: []ast.Expr{counter},
+		Tok: token.ADD_ASSIGN,
+		Rhs: []ast.Expr{x},
+	}
+}
+
+func uintLit(n uint64) *ast.BasicLit {
+	return &ast.BasicLit{
+		Kind:  token.INT,
+		Value: strconv.FormatUint(n, 10),
+	}
+}
+
+func intLit(n int64) *ast.BasicLit {
+	return &ast.BasicLit{
+		Kind:  token.INT,
+		Value: strconv.FormatInt(n, 10),
+	}
+}
+
+func selectorExpr(x, sel string) *ast.SelectorExpr {
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(x),
+		Sel: ast.NewIdent(sel),
+	}
+}
+
+func callExpr(fun ast.Expr, args ...ast.Expr) ast.Expr {
+	return &ast.CallExpr{
+		Fun:  fun,
+		Args: args,
+	}
+}
+
+func lenCall(x ast.Expr) ast.Expr {
+	return &ast.CallExpr{
+		Fun:  ast.NewIdent("len"),
+		Args: []ast.Expr{x},
+	}
+}
+
+func uint64Conv(x ast.Expr) ast.Expr {
+	return &ast.CallExpr{
+		Fun:  ast.NewIdent("uint64"),
+		Args: []ast.Expr{x},
+	}
+}
+
+// exprString returns the source of an expression, for error messages
+func exprString(x ast.Expr) string {
+	var buf bytes.Buffer
+	if err := printer.Fprint(&buf, token.NewFileSet(), x); err != nil {
+		return fmt.Sprintf("%T", x)
+	}
+	return buf.String()
+}
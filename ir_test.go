@@ -0,0 +1,377 @@
+package skyencoder
+
+import (
+	"bytes"
+	"go/ast"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+func renderSizeStepsString(t *testing.T, steps []sizeStep) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	for _, stmt := range renderSizeSteps(ast.NewIdent("i"), steps) {
+		if err := printer.Fprint(&buf, token.NewFileSet(), stmt); err != nil {
+			t.Fatal(err)
+		}
+		buf.WriteString("\n")
+	}
+
+	return buf.String()
+}
+
+func TestRenderSizeSteps(t *testing.T) {
+	obj := func(name string) ast.Expr {
+		return &ast.SelectorExpr{
+			X:   ast.NewIdent("obj"),
+			Sel: ast.NewIdent(name),
+		}
+	}
+
+	cases := []struct {
+		name     string
+		steps    []sizeStep
+		expected string
+	}{
+		{
+			name:     "fixed",
+			steps:    []sizeStep{sizeFixed{size: 1}, sizeFixed{size: 8}},
+			expected: "i++\ni += 8\n",
+		},
+		{
+			name:     "elems",
+			steps:    []sizeStep{sizeElems{name: obj("A"), size: 1}, sizeElems{name: obj("B"), size: 4}},
+			expected: "i += uint64(len(obj.A))\ni += uint64(len(obj.B)) * 4\n",
+		},
+		{
+			name: "range",
+			steps: []sizeStep{
+				sizeRange{
+					name:   obj("A"),
+					length: -1,
+					value:  ast.NewIdent("x1"),
+					body:   []sizeStep{sizeElems{name: ast.NewIdent("x1"), size: 1}},
+				},
+				sizeRange{
+					name:   obj("B"),
+					length: -1,
+					key:    ast.NewIdent("k1"),
+					body:   []sizeStep{sizeElems{name: ast.NewIdent("k1"), size: 1}},
+				},
+			},
+			expected: "for _, x1 := range obj.A {\n\ti += uint64(len(x1))\n}\nfor k1 := range obj.B {\n\ti += uint64(len(k1))\n}\n",
+		},
+		{
+			name: "omitempty",
+			steps: sizeOmitEmpty(obj("A"), []sizeStep{sizeFixed{size: 4}}, &Options{
+				OmitEmpty: true,
+			}),
+			expected: "if len(obj.A) != 0 {\n\ti += 4\n}\n",
+		},
+		{
+			name:     "since",
+			steps:    sizeSince([]sizeStep{sizeFixed{size: 2}}, 3),
+			expected: "if version >= 3 {\n\ti += 2\n}\n",
+		},
+		{
+			name:     "call",
+			steps:    []sizeStep{sizeCall{fn: "encodeSizeNode", name: obj("A")}},
+			expected: "i += encodeSizeNode(&obj.A)\n",
+		},
+		{
+			name: "union",
+			steps: []sizeStep{sizeUnion{
+				name: obj("A"),
+				cases: []sizeCase{
+					{
+						typ:  ast.NewIdent("Tx"),
+						body: []sizeStep{sizeFixed{size: 8}},
+					},
+					{
+						typ:     &ast.StarExpr{X: ast.NewIdent("Block")},
+						pointer: true,
+						body:    []sizeStep{sizeElems{name: ast.NewIdent("x"), size: 1}},
+					},
+				},
+			}},
+			expected: "switch x := obj.A.(type) {\ncase Tx:\n\ti += 8\ncase *Block:\n\tif x != nil {\n\t\ti += uint64(len(x))\n\t}\n}\n",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if s := renderSizeStepsString(t, tc.steps); s != tc.expected {
+				t.Fatalf("renderSizeSteps =\n%s\nexpected\n%s", s, tc.expected)
+			}
+		})
+	}
+}
+
+func TestOptimizeSize(t *testing.T) {
+	x1 := ast.NewIdent("x1")
+	a := &ast.SelectorExpr{
+		X:   ast.NewIdent("obj"),
+		Sel: ast.NewIdent("A"),
+	}
+
+	steps := []sizeStep{
+		sizeFixed{size: 1},
+		sizeElems{name: a, size: 1},
+		sizeFixed{size: 4},
+		sizeFixed{size: 0},
+		// The fixed size of the elements is added outside of the loop
+		sizeRange{
+			name:   a,
+			length: -1,
+			value:  x1,
+			body:   []sizeStep{sizeFixed{size: 2}, sizeElems{name: x1, size: 1}, sizeFixed{size: 2}},
+		},
+		// An array with fixed size elements has a fixed size
+		sizeRange{
+			name:   a,
+			length: 3,
+			value:  x1,
+			body:   []sizeStep{sizeFixed{size: 2}},
+		},
+		// Steps with the same condition are merged, and empty steps are removed
+		sizeSince([]sizeStep{sizeFixed{size: 1}}, 2)[0],
+		sizeSince([]sizeStep{sizeFixed{size: 2}}, 2)[0],
+		sizeSince([]sizeStep{sizeFixed{size: 0}}, 3)[0],
+	}
+
+	expected := `i += 11
+i += uint64(len(obj.A))
+i += uint64(len(obj.A)) * 4
+for _, x1 := range obj.A {
+	i += uint64(len(x1))
+}
+if version >= 2 {
+	i += 3
+}
+`
+
+	if s := renderSizeStepsString(t, optimizeSize(steps)); s != expected {
+		t.Fatalf("optimizeSize =\n%s\nexpected\n%s", s, expected)
+	}
+}
+
+func TestOptimizeRuns(t *testing.T) {
+	a := fixedLeaf{varName: "obj.A", kind: types.Uint8, size: 1, typeName: "uint8"}
+	b := fixedLeaf{varName: "obj.B", kind: types.Int32, size: 4, castType: true, typeName: "Coins"}
+	c := fixedLeaf{varName: "obj.C", kind: types.Float64, size: 8, typeName: "float64", bigEndian: true}
+	d := fixedLeaf{varName: "obj.D", kind: types.Invalid, size: 20}
+
+	encode := func(leaves ...fixedLeaf) codeStep {
+		return encodeLeaves{leaves: leaves, section: "// " + leaves[0].varName}
+	}
+	decode := func(leaves ...fixedLeaf) codeStep {
+		return decodeLeaves{leaves: leaves, section: "// " + leaves[0].varName}
+	}
+
+	cases := []struct {
+		name     string
+		steps    []codeStep
+		expected string
+	}{
+		{
+			name: "encode",
+			// The leaves of adjacent steps are merged, a single leaf is not, and other steps end a run
+			steps: []codeStep{encode(a), encode(b, c), codeSection{src: "// x"}, encode(d)},
+			expected: `
+// obj.A, obj.B, obj.C
+{
+	b := e.Buffer[:13]
+	b[0] = obj.A
+	binary.LittleEndian.PutUint32(b[1:5], uint32(obj.B))
+	binary.BigEndian.PutUint64(b[5:13], math.Float64bits(obj.C))
+	e.Buffer = e.Buffer[13:]
+}
+
+
+// x
+
+// obj.D`,
+		},
+		{
+			name:  "decode",
+			steps: []codeStep{decode(a), codeSection{src: "// x"}, decode(b), decode(c, d)},
+			expected: `// obj.A
+
+// x
+
+
+// obj.B, obj.C, obj.D
+{
+	if len(d.Buffer) < 32 {
+		return 0, encoder.ErrBufferUnderflow
+	}
+	obj.B = Coins(binary.LittleEndian.Uint32(d.Buffer[0:4]))
+	obj.C = math.Float64frombits(binary.BigEndian.Uint64(d.Buffer[4:12]))
+	copy(obj.D[:], d.Buffer[12:32])
+	d.Buffer = d.Buffer[32:]
+}
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := renderCodeSteps(optimizeRuns(tc.steps))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s != tc.expected {
+				t.Fatalf("renderCodeSteps =\n%s\nexpected\n%s", s, tc.expected)
+			}
+		})
+	}
+}
+
+func TestBuildSizeSteps(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	fn := filepath.Join(dir, "foo.go")
+	if err := ioutil.WriteFile(fn, []byte(`package foo
+
+type Inner struct {
+	X []byte
+	Y uint16
+}
+
+type Foo struct {
+	A int32
+	B [3]uint16
+	C []uint64
+	D string
+	E []Inner
+	F map[int32]string
+	H [2]string
+	I string `+"`enc:\",len=5\"`"+`
+	G []byte `+"`enc:\",omitempty\"`"+`
+}
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	program, err := LoadProgram([]string{fn}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sInfo, err := FindStructInfoInProgram(program, "Foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	steps, err := buildSizeSteps(sInfo.Type, sInfo.Package, ast.NewIdent("obj"), 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := renderSizeFunc("EncodeSizeFoo", []*ast.Field{objParam("Foo", "")}, nil, steps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `func EncodeSizeFoo(obj *Foo) uint64 {
+	i := uint64(0)
+	i += 39
+	i += uint64(len(obj.C)) * 8
+	i += uint64(len(obj.D))
+	i += uint64(len(obj.E)) * 6
+	for _, x1 := range obj.E {
+		i += uint64(len(x1.X))
+	}
+	i += uint64(len(obj.F)) * 8
+	for _, v1 := range obj.F {
+		i += uint64(len(v1))
+	}
+	for _, x1 := range obj.H {
+		i += uint64(len(x1))
+	}
+	if len(obj.G) != 0 {
+		i += 4
+		i += uint64(len(obj.G))
+	}
+	return i
+}`
+
+	if s := string(src); s != expected {
+		t.Fatalf("renderSizeFunc =\n%s\nexpected\n%s", s, expected)
+	}
+}
+
+func TestBuildSizeFuncs(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	fn := filepath.Join(dir, "foo.go")
+	if err := ioutil.WriteFile(fn, []byte(`package foo
+
+type Node struct {
+	A        int32
+	Children []Node
+}
+
+type Foo struct {
+	Root  Node
+	Nodes map[string]Node
+}
+`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	program, err := LoadProgram([]string{fn}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sInfo, err := FindStructInfoInProgram(program, "Foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	steps, err := buildSizeSteps(sInfo.Type, sInfo.Package, ast.NewIdent("obj"), 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	funcs, err := buildSizeFuncs([]types.Type{sInfo.Type}, sInfo.Package, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := renderSizeFunc("EncodeSizeFoo", []*ast.Field{objParam("Foo", "")}, funcs, steps)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `func EncodeSizeFoo(obj *Foo) uint64 {
+	var encodeSizeNode func(obj *Node) uint64
+	encodeSizeNode = func(obj *Node) uint64 {
+		i := uint64(0)
+		i += 8
+		for _, x1 := range obj.Children {
+			i += encodeSizeNode(&x1)
+		}
+		return i
+	}
+	i := uint64(0)
+	i += 4
+	i += encodeSizeNode(&obj.Root)
+	i += uint64(len(obj.Nodes)) * 4
+	for k1, v1 := range obj.Nodes {
+		i += uint64(len(k1))
+		i += encodeSizeNode(&v1)
+	}
+	return i
+}`
+
+	if s := string(src); s != expected {
+		t.Fatalf("renderSizeFunc =\n%s\nexpected\n%s", s, expected)
+	}
+}
@@ -0,0 +1,115 @@
+package runtime
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+// The Put functions write a slice of numbers to a buffer in bulk, for the generated code which encodes
+// numeric slices and arrays. The buffer must have a length of at least the element size times len(s).
+// The little-endian functions are unrolled loops, or a single memory copy on little-endian platforms
+// when built with the skyencoder_unsafe build tag.
+
+// PutUint16s writes a slice of uint16s in little-endian byte order
+func PutUint16s(b []byte, s []uint16) {
+	putUint16s(b, s)
+}
+
+// PutInt16s writes a slice of int16s in little-endian byte order
+func PutInt16s(b []byte, s []int16) {
+	putInt16s(b, s)
+}
+
+// PutUint32s writes a slice of uint32s in little-endian byte order
+func PutUint32s(b []byte, s []uint32) {
+	putUint32s(b, s)
+}
+
+// PutInt32s writes a slice of int32s in little-endian byte order
+func PutInt32s(b []byte, s []int32) {
+	putInt32s(b, s)
+}
+
+// PutUint64s writes a slice of uint64s in little-endian byte order
+func PutUint64s(b []byte, s []uint64) {
+	putUint64s(b, s)
+}
+
+// PutInt64s writes a slice of int64s in little-endian byte order
+func PutInt64s(b []byte, s []int64) {
+	putInt64s(b, s)
+}
+
+// PutFloat32s writes a slice of float32s in little-endian byte order
+func PutFloat32s(b []byte, s []float32) {
+	putFloat32s(b, s)
+}
+
+// PutFloat64s writes a slice of float64s in little-endian byte order
+func PutFloat64s(b []byte, s []float64) {
+	putFloat64s(b, s)
+}
+
+// PutUint16sBigEndian writes a slice of uint16s in big-endian byte order
+func PutUint16sBigEndian(b []byte, s []uint16) {
+	b = b[:2*len(s)]
+	for i, x := range s {
+		binary.BigEndian.PutUint16(b[2*i:], x)
+	}
+}
+
+// PutInt16sBigEndian writes a slice of int16s in big-endian byte order
+func PutInt16sBigEndian(b []byte, s []int16) {
+	b = b[:2*len(s)]
+	for i, x := range s {
+		binary.BigEndian.PutUint16(b[2*i:], uint16(x))
+	}
+}
+
+// PutUint32sBigEndian writes a slice of uint32s in big-endian byte order
+func PutUint32sBigEndian(b []byte, s []uint32) {
+	b = b[:4*len(s)]
+	for i, x := range s {
+		binary.BigEndian.PutUint32(b[4*i:], x)
+	}
+}
+
+// PutInt32sBigEndian writes a slice of int32s in big-endian byte order
+func PutInt32sBigEndian(b []byte, s []int32) {
+	b = b[:4*len(s)]
+	for i, x := range s {
+		binary.BigEndian.PutUint32(b[4*i:], uint32(x))
+	}
+}
+
+// PutUint64sBigEndian writes a slice of uint64s in big-endian byte order
+func PutUint64sBigEndian(b []byte, s []uint64) {
+	b = b[:8*len(s)]
+	for i, x := range s {
+		binary.BigEndian.PutUint64(b[8*i:], x)
+	}
+}
+
+// PutInt64sBigEndian writes a slice of int64s in big-endian byte order
+func PutInt64sBigEndian(b []byte, s []int64) {
+	b = b[:8*len(s)]
+	for i, x := range s {
+		binary.BigEndian.PutUint64(b[8*i:], uint64(x))
+	}
+}
+
+// PutFloat32sBigEndian writes a slice of float32s in big-endian byte order
+func PutFloat32sBigEndian(b []byte, s []float32) {
+	b = b[:4*len(s)]
+	for i, x := range s {
+		binary.BigEndian.PutUint32(b[4*i:], math.Float32bits(x))
+	}
+}
+
+// PutFloat64sBigEndian writes a slice of float64s in big-endian byte order
+func PutFloat64sBigEndian(b []byte, s []float64) {
+	b = b[:8*len(s)]
+	for i, x := range s {
+		binary.BigEndian.PutUint64(b[8*i:], math.Float64bits(x))
+	}
+}
@@ -0,0 +1,129 @@
+//go:build !skyencoder_unsafe || !go1.17 || !(386 || amd64 || arm || arm64 || loong64 || mips64le || mipsle || ppc64le || riscv64 || wasm)
+// +build !skyencoder_unsafe !go1.17 !386,!amd64,!arm,!arm64,!loong64,!mips64le,!mipsle,!ppc64le,!riscv64,!wasm
+
+package runtime
+
+import (
+	"encoding/binary"
+	"math"
+)
+
+func putUint16s(b []byte, s []uint16) {
+	b = b[:2*len(s)]
+	for len(s) >= 4 {
+		binary.LittleEndian.PutUint16(b[0:2], s[0])
+		binary.LittleEndian.PutUint16(b[2:4], s[1])
+		binary.LittleEndian.PutUint16(b[4:6], s[2])
+		binary.LittleEndian.PutUint16(b[6:8], s[3])
+		b = b[8:]
+		s = s[4:]
+	}
+	for i, x := range s {
+		binary.LittleEndian.PutUint16(b[2*i:], x)
+	}
+}
+
+func putInt16s(b []byte, s []int16) {
+	b = b[:2*len(s)]
+	for len(s) >= 4 {
+		binary.LittleEndian.PutUint16(b[0:2], uint16(s[0]))
+		binary.LittleEndian.PutUint16(b[2:4], uint16(s[1]))
+		binary.LittleEndian.PutUint16(b[4:6], uint16(s[2]))
+		binary.LittleEndian.PutUint16(b[6:8], uint16(s[3]))
+		b = b[8:]
+		s = s[4:]
+	}
+	for i, x := range s {
+		binary.LittleEndian.PutUint16(b[2*i:], uint16(x))
+	}
+}
+
+func putUint32s(b []byte, s []uint32) {
+	b = b[:4*len(s)]
+	for len(s) >= 4 {
+		binary.LittleEndian.PutUint32(b[0:4], s[0])
+		binary.LittleEndian.PutUint32(b[4:8], s[1])
+		binary.LittleEndian.PutUint32(b[8:12], s[2])
+		binary.LittleEndian.PutUint32(b[12:16], s[3])
+		b = b[16:]
+		s = s[4:]
+	}
+	for i, x := range s {
+		binary.LittleEndian.PutUint32(b[4*i:], x)
+	}
+}
+
+func putInt32s(b []byte, s []int32) {
+	b = b[:4*len(s)]
+	for len(s) >= 4 {
+		binary.LittleEndian.PutUint32(b[0:4], uint32(s[0]))
+		binary.LittleEndian.PutUint32(b[4:8], uint32(s[1]))
+		binary.LittleEndian.PutUint32(b[8:12], uint32(s[2]))
+		binary.LittleEndian.PutUint32(b[12:16], uint32(s[3]))
+		b = b[16:]
+		s = s[4:]
+	}
+	for i, x := range s {
+		binary.LittleEndian.PutUint32(b[4*i:], uint32(x))
+	}
+}
+
+func putUint64s(b []byte, s []uint64) {
+	b = b[:8*len(s)]
+	for len(s) >= 4 {
+		binary.LittleEndian.PutUint64(b[0:8], s[0])
+		binary.LittleEndian.PutUint64(b[8:16], s[1])
+		binary.LittleEndian.PutUint64(b[16:24], s[2])
+		binary.LittleEndian.PutUint64(b[24:32], s[3])
+		b = b[32:]
+		s = s[4:]
+	}
+	for i, x := range s {
+		binary.LittleEndian.PutUint64(b[8*i:], x)
+	}
+}
+
+func putInt64s(b []byte, s []int64) {
+	b = b[:8*len(s)]
+	for len(s) >= 4 {
+		binary.LittleEndian.PutUint64(b[0:8], uint64(s[0]))
+		binary.LittleEndian.PutUint64(b[8:16], uint64(s[1]))
+		binary.LittleEndian.PutUint64(b[16:24], uint64(s[2]))
+		binary.LittleEndian.PutUint64(b[24:32], uint64(s[3]))
+		b = b[32:]
+		s = s[4:]
+	}
+	for i, x := range s {
+		binary.LittleEndian.PutUint64(b[8*i:], uint64(x))
+	}
+}
+
+func putFloat32s(b []byte, s []float32) {
+	b = b[:4*len(s)]
+	for len(s) >= 4 {
+		binary.LittleEndian.PutUint32(b[0:4], math.Float32bits(s[0]))
+		binary.LittleEndian.PutUint32(b[4:8], math.Float32bits(s[1]))
+		binary.LittleEndian.PutUint32(b[8:12], math.Float32bits(s[2]))
+		binary.LittleEndian.PutUint32(b[12:16], math.Float32bits(s[3]))
+		b = b[16:]
+		s = s[4:]
+	}
+	for i, x := range s {
+		binary.LittleEndian.PutUint32(b[4*i:], math.Float32bits(x))
+	}
+}
+
+func putFloat64s(b []byte, s []float64) {
+	b = b[:8*len(s)]
+	for len(s) >= 4 {
+		binary.LittleEndian.PutUint64(b[0:8], math.Float64bits(s[0]))
+		binary.LittleEndian.PutUint64(b[8:16], math.Float64bits(s[1]))
+		binary.LittleEndian.PutUint64(b[16:24], math.Float64bits(s[2]))
+		binary.LittleEndian.PutUint64(b[24:32], math.Float64bits(s[3]))
+		b = b[32:]
+		s = s[4:]
+	}
+	for i, x := range s {
+		binary.LittleEndian.PutUint64(b[8*i:], math.Float64bits(x))
+	}
+}
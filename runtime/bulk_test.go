@@ -0,0 +1,119 @@
+package runtime
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/skycoin/skycoin/src/cipher/encoder"
+)
+
+func TestPutLittleEndian(t *testing.T) {
+	for n := 0; n < 10; n++ {
+		u16 := make([]int16, n)
+		u32 := make([]uint32, n)
+		u64 := make([]uint64, n)
+		f32 := make([]float32, n)
+		f64 := make([]float64, n)
+		for i := 0; i < n; i++ {
+			u16[i] = -int16(i) - 1
+			u32[i] = 0xF1F2F3F4 + uint32(i)
+			u64[i] = 0xF1F2F3F4F5F6F7F8 + uint64(i)
+			f32[i] = float32(i) * -1.5
+			f64[i] = float64(i) * 2.25
+		}
+
+		expected := make([]byte, n*(2+4+8+4+8))
+		e := &encoder.Encoder{
+			Buffer: expected,
+		}
+		for _, x := range u16 {
+			e.Int16(x)
+		}
+		for _, x := range u32 {
+			e.Uint32(x)
+		}
+		for _, x := range u64 {
+			e.Uint64(x)
+		}
+		for _, x := range f32 {
+			e.Uint32(math.Float32bits(x))
+		}
+		for _, x := range f64 {
+			e.Uint64(math.Float64bits(x))
+		}
+
+		b := make([]byte, len(expected))
+		rest := b
+		PutInt16s(rest, u16)
+		rest = rest[2*n:]
+		PutUint32s(rest, u32)
+		rest = rest[4*n:]
+		PutUint64s(rest, u64)
+		rest = rest[8*n:]
+		PutFloat32s(rest, f32)
+		rest = rest[4*n:]
+		PutFloat64s(rest, f64)
+
+		if !bytes.Equal(b, expected) {
+			t.Fatalf("n=%d: Put functions wrote %x, expected %x", n, b, expected)
+		}
+	}
+}
+
+func TestPutBigEndian(t *testing.T) {
+	for n := 0; n < 10; n++ {
+		u16 := make([]uint16, n)
+		u32 := make([]int32, n)
+		u64 := make([]int64, n)
+		f32 := make([]float32, n)
+		f64 := make([]float64, n)
+		for i := 0; i < n; i++ {
+			u16[i] = 0xF1F2 + uint16(i)
+			u32[i] = -int32(i) - 1
+			u64[i] = -int64(i) - 1
+			f32[i] = float32(i) * -1.5
+			f64[i] = float64(i) * 2.25
+		}
+
+		expected := make([]byte, n*(2+4+8+4+8))
+		rest := expected
+		for _, x := range u16 {
+			binary.BigEndian.PutUint16(rest, x)
+			rest = rest[2:]
+		}
+		for _, x := range u32 {
+			binary.BigEndian.PutUint32(rest, uint32(x))
+			rest = rest[4:]
+		}
+		for _, x := range u64 {
+			binary.BigEndian.PutUint64(rest, uint64(x))
+			rest = rest[8:]
+		}
+		for _, x := range f32 {
+			binary.BigEndian.PutUint32(rest, math.Float32bits(x))
+			rest = rest[4:]
+		}
+		for _, x := range f64 {
+			binary.BigEndian.PutUint64(rest, math.Float64bits(x))
+			rest = rest[8:]
+		}
+
+		b := make([]byte, len(expected))
+		rest = b
+		PutUint16sBigEndian(rest, u16)
+		rest = rest[2*n:]
+		PutInt32sBigEndian(rest, u32)
+		rest = rest[4*n:]
+		PutInt64sBigEndian(rest, u64)
+		rest = rest[8*n:]
+		PutFloat32sBigEndian(rest, f32)
+		rest = rest[4*n:]
+		PutFloat64sBigEndian(rest, f64)
+
+		if !bytes.Equal(b, expected) {
+			t.Fatalf("n=%d: Put functions wrote %x, expected %x", n, b, expected)
+		}
+	}
+}
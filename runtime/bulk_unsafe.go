@@ -0,0 +1,63 @@
+//go:build skyencoder_unsafe && go1.17 && (386 || amd64 || arm || arm64 || loong64 || mips64le || mipsle || ppc64le || riscv64 || wasm)
+// +build skyencoder_unsafe
+// +build go1.17
+// +build 386 amd64 arm arm64 loong64 mips64le mipsle ppc64le riscv64 wasm
+
+package runtime
+
+import "unsafe"
+
+// On little-endian platforms, the in-memory representation of a numeric slice is its little-endian encoding
+
+func putUint16s(b []byte, s []uint16) {
+	if len(s) != 0 {
+		copyMemory(b, unsafe.Pointer(&s[0]), 2*len(s))
+	}
+}
+
+func putInt16s(b []byte, s []int16) {
+	if len(s) != 0 {
+		copyMemory(b, unsafe.Pointer(&s[0]), 2*len(s))
+	}
+}
+
+func putUint32s(b []byte, s []uint32) {
+	if len(s) != 0 {
+		copyMemory(b, unsafe.Pointer(&s[0]), 4*len(s))
+	}
+}
+
+func putInt32s(b []byte, s []int32) {
+	if len(s) != 0 {
+		copyMemory(b, unsafe.Pointer(&s[0]), 4*len(s))
+	}
+}
+
+func putUint64s(b []byte, s []uint64) {
+	if len(s) != 0 {
+		copyMemory(b, unsafe.Pointer(&s[0]), 8*len(s))
+	}
+}
+
+func putInt64s(b []byte, s []int64) {
+	if len(s) != 0 {
+		copyMemory(b, unsafe.Pointer(&s[0]), 8*len(s))
+	}
+}
+
+func putFloat32s(b []byte, s []float32) {
+	if len(s) != 0 {
+		copyMemory(b, unsafe.Pointer(&s[0]), 4*len(s))
+	}
+}
+
+func putFloat64s(b []byte, s []float64) {
+	if len(s) != 0 {
+		copyMemory(b, unsafe.Pointer(&s[0]), 8*len(s))
+	}
+}
+
+// copyMemory copies n bytes of memory starting at p to b
+func copyMemory(b []byte, p unsafe.Pointer, n int) {
+	copy(b[:n], unsafe.Slice((*byte)(p), n))
+}
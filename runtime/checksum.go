@@ -0,0 +1,40 @@
+package runtime
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+)
+
+// ChecksumSize is the size of the checksum appended to the encoding of an object by the checksum directive
+const ChecksumSize = 4
+
+// ErrChecksumMismatch is returned if the checksum appended to the encoding of an object is not the checksum of the encoding.
+// The skycoin encoder has no equivalent.
+var ErrChecksumMismatch = errors.New("Checksum does not match the encoded object")
+
+var castagnoliTable = crc32.MakeTable(crc32.Castagnoli)
+
+// ChecksumCRC32 returns the CRC-32 checksum of data with the IEEE polynomial, in little-endian byte order
+func ChecksumCRC32(data []byte) [ChecksumSize]byte {
+	var sum [ChecksumSize]byte
+	binary.LittleEndian.PutUint32(sum[:], crc32.ChecksumIEEE(data))
+	return sum
+}
+
+// ChecksumCRC32C returns the CRC-32 checksum of data with the Castagnoli polynomial, in little-endian byte order
+func ChecksumCRC32C(data []byte) [ChecksumSize]byte {
+	var sum [ChecksumSize]byte
+	binary.LittleEndian.PutUint32(sum[:], crc32.Checksum(data, castagnoliTable))
+	return sum
+}
+
+// ChecksumSHA256Truncated returns the first ChecksumSize bytes of the SHA256 hash of data
+func ChecksumSHA256Truncated(data []byte) [ChecksumSize]byte {
+	h := sha256.Sum256(data)
+
+	var sum [ChecksumSize]byte
+	copy(sum[:], h[:])
+	return sum
+}
@@ -0,0 +1,40 @@
+package runtime
+
+import (
+	"testing"
+)
+
+func TestChecksums(t *testing.T) {
+	// The check values of the CRC algorithms are their checksums of "123456789"
+	data := []byte("123456789")
+
+	cases := []struct {
+		name     string
+		checksum func([]byte) [ChecksumSize]byte
+		expected [ChecksumSize]byte
+	}{
+		{
+			name:     "CRC32",
+			checksum: ChecksumCRC32,
+			expected: [ChecksumSize]byte{0x26, 0x39, 0xf4, 0xcb},
+		},
+		{
+			name:     "CRC32C",
+			checksum: ChecksumCRC32C,
+			expected: [ChecksumSize]byte{0x83, 0x92, 0x06, 0xe3},
+		},
+		{
+			name:     "SHA256Truncated",
+			checksum: ChecksumSHA256Truncated,
+			expected: [ChecksumSize]byte{0x15, 0xe2, 0xb0, 0xd3},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if sum := tc.checksum(data); sum != tc.expected {
+				t.Fatalf("checksum = %x, expected %x", sum, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package runtime
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// The Encoder, Decoder and errors of this file are used by code generated in standalone mode,
+// in place of those of github.com/skycoin/skycoin/src/cipher/encoder, which they are compatible with.
+
+var (
+	// ErrBufferUnderflow bytes in input buffer not enough to deserialize expected type
+	ErrBufferUnderflow = errors.New("Not enough buffer data to deserialize")
+	// ErrRemainingBytes bytes remain in buffer after deserializing object
+	ErrRemainingBytes = errors.New("Bytes remain in buffer after deserializing object")
+	// ErrMaxLenExceeded a specified maximum length was exceeded when serializing or deserializing a variable length field
+	ErrMaxLenExceeded = errors.New("Maximum length exceeded for variable length field")
+	// ErrMapDuplicateKeys encountered duplicate map keys while decoding a map
+	ErrMapDuplicateKeys = errors.New("Duplicate keys encountered while decoding a map")
+	// ErrInvalidBool is returned if the decoder encounters a value other than 0 or 1 for a bool type field
+	ErrInvalidBool = errors.New("Invalid value for bool type")
+)
+
+// ErrMaxDepthExceeded is returned if a value of a recursive type is nested deeper than the maxdepth option of its field.
+// The skycoin encoder has no equivalent, so generated code always uses this error.
+var ErrMaxDepthExceeded = errors.New("Maximum depth exceeded for recursive type")
+
+// The union errors have no equivalent in the skycoin encoder either
+var (
+	// ErrUnknownUnionTag is returned if the tag byte of a union is not the tag of any of its types
+	ErrUnknownUnionTag = errors.New("Unknown tag for union")
+	// ErrUnknownUnionType is returned if the value of a union has a type which is not in the union, or is a nil pointer
+	ErrUnknownUnionType = errors.New("Value of union has a type which is not in the union, or is a nil pointer")
+)
+
+// ErrConstMismatch is returned if a field tagged with a const option is encoded with a value other than the constant
+// or the zero value, or decoded from bytes other than the encoding of the constant.
+// The skycoin encoder has no equivalent either.
+var ErrConstMismatch = errors.New("Value of const field does not match its constant")
+
+// Encoder writes encoded values to a buffer, which must be large enough for the values
+type Encoder struct {
+	Buffer []byte
+}
+
+// Bool writes a bool
+func (e *Encoder) Bool(x bool) {
+	if x {
+		e.Buffer[0] = 1
+	} else {
+		e.Buffer[0] = 0
+	}
+	e.Buffer = e.Buffer[1:]
+}
+
+// Uint8 writes a uint8
+func (e *Encoder) Uint8(x uint8) {
+	e.Buffer[0] = x
+	e.Buffer = e.Buffer[1:]
+}
+
+// Uint16 writes a uint16
+func (e *Encoder) Uint16(x uint16) {
+	binary.LittleEndian.PutUint16(e.Buffer[:2], x)
+	e.Buffer = e.Buffer[2:]
+}
+
+// Uint32 writes a uint32
+func (e *Encoder) Uint32(x uint32) {
+	binary.LittleEndian.PutUint32(e.Buffer[:4], x)
+	e.Buffer = e.Buffer[4:]
+}
+
+// Uint64 writes a uint64
+func (e *Encoder) Uint64(x uint64) {
+	binary.LittleEndian.PutUint64(e.Buffer[:8], x)
+	e.Buffer = e.Buffer[8:]
+}
+
+// Int8 writes an int8
+func (e *Encoder) Int8(x int8) {
+	e.Uint8(uint8(x))
+}
+
+// Int16 writes an int16
+func (e *Encoder) Int16(x int16) {
+	e.Uint16(uint16(x))
+}
+
+// Int32 writes an int32
+func (e *Encoder) Int32(x int32) {
+	e.Uint32(uint32(x))
+}
+
+// Int64 writes an int64
+func (e *Encoder) Int64(x int64) {
+	e.Uint64(uint64(x))
+}
+
+// ByteSlice writes a length-prefixed []byte
+func (e *Encoder) ByteSlice(x []byte) {
+	e.Uint32(uint32(len(x)))
+	e.CopyBytes(x)
+}
+
+// CopyBytes writes a []byte without a length prefix
+func (e *Encoder) CopyBytes(x []byte) {
+	if len(x) == 0 {
+		return
+	}
+	copy(e.Buffer, x)
+	e.Buffer = e.Buffer[len(x):]
+}
+
+// Decoder reads encoded values from a buffer
+type Decoder struct {
+	Buffer []byte
+}
+
+// Bool reads a bool. Returns ErrInvalidBool if the byte read is not 0 or 1.
+func (d *Decoder) Bool() (bool, error) {
+	x, err := d.Uint8()
+	if err != nil {
+		return false, err
+	}
+
+	switch x {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	default:
+		return false, ErrInvalidBool
+	}
+}
+
+// Uint8 reads a uint8
+func (d *Decoder) Uint8() (uint8, error) {
+	if len(d.Buffer) < 1 {
+		return 0, ErrBufferUnderflow
+	}
+
+	x := d.Buffer[0]
+	d.Buffer = d.Buffer[1:]
+	return x, nil
+}
+
+// Uint16 reads a uint16
+func (d *Decoder) Uint16() (uint16, error) {
+	if len(d.Buffer) < 2 {
+		return 0, ErrBufferUnderflow
+	}
+
+	x := binary.LittleEndian.Uint16(d.Buffer[:2])
+	d.Buffer = d.Buffer[2:]
+	return x, nil
+}
+
+// Uint32 reads a uint32
+func (d *Decoder) Uint32() (uint32, error) {
+	if len(d.Buffer) < 4 {
+		return 0, ErrBufferUnderflow
+	}
+
+	x := binary.LittleEndian.Uint32(d.Buffer[:4])
+	d.Buffer = d.Buffer[4:]
+	return x, nil
+}
+
+// Uint64 reads a uint64
+func (d *Decoder) Uint64() (uint64, error) {
+	if len(d.Buffer) < 8 {
+		return 0, ErrBufferUnderflow
+	}
+
+	x := binary.LittleEndian.Uint64(d.Buffer[:8])
+	d.Buffer = d.Buffer[8:]
+	return x, nil
+}
+
+// Int8 reads an int8
+func (d *Decoder) Int8() (int8, error) {
+	x, err := d.Uint8()
+	return int8(x), err
+}
+
+// Int16 reads an int16
+func (d *Decoder) Int16() (int16, error) {
+	x, err := d.Uint16()
+	return int16(x), err
+}
+
+// Int32 reads an int32
+func (d *Decoder) Int32() (int32, error) {
+	x, err := d.Uint32()
+	return int32(x), err
+}
+
+// Int64 reads an int64
+func (d *Decoder) Int64() (int64, error) {
+	x, err := d.Uint64()
+	return int64(x), err
+}
@@ -0,0 +1,120 @@
+package runtime
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/skycoin/skycoin/src/cipher/encoder"
+)
+
+func TestEncoder(t *testing.T) {
+	n := 1 + 1 + 2 + 4 + 8 + 1 + 2 + 4 + 8 + 4 + 3 + 2
+	expected := make([]byte, n)
+	buf := make([]byte, n)
+
+	for _, e := range []interface {
+		Bool(bool)
+		Uint8(uint8)
+		Uint16(uint16)
+		Uint32(uint32)
+		Uint64(uint64)
+		Int8(int8)
+		Int16(int16)
+		Int32(int32)
+		Int64(int64)
+		ByteSlice([]byte)
+		CopyBytes([]byte)
+	}{&encoder.Encoder{Buffer: expected}, &Encoder{Buffer: buf}} {
+		e.Bool(true)
+		e.Uint8(0xF1)
+		e.Uint16(0xF1F2)
+		e.Uint32(0xF1F2F3F4)
+		e.Uint64(0xF1F2F3F4F5F6F7F8)
+		e.Int8(-2)
+		e.Int16(-3)
+		e.Int32(-4)
+		e.Int64(-5)
+		e.ByteSlice([]byte("foo"))
+		e.CopyBytes([]byte("ba"))
+	}
+
+	if !bytes.Equal(buf, expected) {
+		t.Fatalf("Encoder wrote %x, encoder.Encoder wrote %x", buf, expected)
+	}
+}
+
+func TestDecoder(t *testing.T) {
+	buf := []byte{
+		0x01,
+		0xF1,
+		0xF2, 0xF1,
+		0xF4, 0xF3, 0xF2, 0xF1,
+		0xF8, 0xF7, 0xF6, 0xF5, 0xF4, 0xF3, 0xF2, 0xF1,
+		0xFE,
+		0xFD, 0xFF,
+		0xFC, 0xFF, 0xFF, 0xFF,
+		0xFB, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF,
+	}
+
+	d := &Decoder{
+		Buffer: buf,
+	}
+
+	check := func(name string, x, expected interface{}, err error) {
+		t.Helper()
+		if err != nil {
+			t.Fatalf("Decoder.%s failed: %v", name, err)
+		}
+		if x != expected {
+			t.Fatalf("Decoder.%s = %v, expected %v", name, x, expected)
+		}
+	}
+
+	b, err := d.Bool()
+	check("Bool", b, true, err)
+	u8, err := d.Uint8()
+	check("Uint8", u8, uint8(0xF1), err)
+	u16, err := d.Uint16()
+	check("Uint16", u16, uint16(0xF1F2), err)
+	u32, err := d.Uint32()
+	check("Uint32", u32, uint32(0xF1F2F3F4), err)
+	u64, err := d.Uint64()
+	check("Uint64", u64, uint64(0xF1F2F3F4F5F6F7F8), err)
+	i8, err := d.Int8()
+	check("Int8", i8, int8(-2), err)
+	i16, err := d.Int16()
+	check("Int16", i16, int16(-3), err)
+	i32, err := d.Int32()
+	check("Int32", i32, int32(-4), err)
+	i64, err := d.Int64()
+	check("Int64", i64, int64(-5), err)
+
+	if len(d.Buffer) != 0 {
+		t.Fatalf("Decoder has %d remaining bytes", len(d.Buffer))
+	}
+
+	if _, err := d.Uint8(); err != ErrBufferUnderflow {
+		t.Fatalf("Decoder.Uint8 on an empty buffer expected ErrBufferUnderflow, got %v", err)
+	}
+
+	d.Buffer = []byte{0x02}
+	if _, err := d.Bool(); err != ErrInvalidBool {
+		t.Fatalf("Decoder.Bool of 0x02 expected ErrInvalidBool, got %v", err)
+	}
+}
+
+func TestErrorsMatchEncoder(t *testing.T) {
+	for _, tc := range []struct {
+		err, expected error
+	}{
+		{ErrBufferUnderflow, encoder.ErrBufferUnderflow},
+		{ErrRemainingBytes, encoder.ErrRemainingBytes},
+		{ErrMaxLenExceeded, encoder.ErrMaxLenExceeded},
+		{ErrMapDuplicateKeys, encoder.ErrMapDuplicateKeys},
+		{ErrInvalidBool, encoder.ErrInvalidBool},
+	} {
+		if tc.err.Error() != tc.expected.Error() {
+			t.Errorf("Error message %q != %q", tc.err, tc.expected)
+		}
+	}
+}
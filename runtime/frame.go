@@ -0,0 +1,52 @@
+package runtime
+
+import (
+	"encoding/binary"
+	"errors"
+	"io"
+	"math"
+)
+
+// FrameHeaderSize is the size of the little-endian uint32 length prefix of a frame
+const FrameHeaderSize = 4
+
+// ErrFrameTooLarge is returned if the length of a frame exceeds the maximum frame size of the reader,
+// or if an encoded object is too large for the uint32 length prefix of a frame
+var ErrFrameTooLarge = errors.New("Frame exceeds the maximum frame size")
+
+// NewFrame returns a frame for a payload of n bytes, with its length prefix written.
+// The payload is written to the bytes following the FrameHeaderSize bytes of the prefix.
+func NewFrame(n uint64) ([]byte, error) {
+	if n > math.MaxUint32 {
+		return nil, ErrFrameTooLarge
+	}
+
+	frame := make([]byte, FrameHeaderSize+n)
+	binary.LittleEndian.PutUint32(frame, uint32(n))
+	return frame, nil
+}
+
+// ReadFrame reads a length prefixed frame from r and returns its payload.
+// The length is checked against maxFrame before the payload is allocated, returning ErrFrameTooLarge if it exceeds it.
+// Returns io.EOF if r has no bytes before the frame, and io.ErrUnexpectedEOF if r ends within the frame.
+func ReadFrame(r io.Reader, maxFrame uint32) ([]byte, error) {
+	var header [FrameHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, err
+	}
+
+	n := binary.LittleEndian.Uint32(header[:])
+	if n > maxFrame {
+		return nil, ErrFrameTooLarge
+	}
+
+	payload := make([]byte, n)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	return payload, nil
+}
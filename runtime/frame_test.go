@@ -0,0 +1,86 @@
+package runtime
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestNewFrame(t *testing.T) {
+	frame, err := NewFrame(3)
+	if err != nil {
+		t.Fatalf("NewFrame failed: %v", err)
+	}
+	if !bytes.Equal(frame, []byte{3, 0, 0, 0, 0, 0, 0}) {
+		t.Fatalf("NewFrame(3) = %v", frame)
+	}
+
+	if _, err := NewFrame(1 << 32); err != ErrFrameTooLarge {
+		t.Fatalf("NewFrame(1<<32) expected error %v, got %v", ErrFrameTooLarge, err)
+	}
+}
+
+func TestReadFrame(t *testing.T) {
+	cases := []struct {
+		name     string
+		data     []byte
+		maxFrame uint32
+		payload  []byte
+		err      error
+	}{
+		{
+			name:     "frame",
+			data:     []byte{3, 0, 0, 0, 1, 2, 3, 4},
+			maxFrame: 3,
+			payload:  []byte{1, 2, 3},
+		},
+		{
+			name:     "empty frame",
+			data:     []byte{0, 0, 0, 0},
+			maxFrame: 0,
+			payload:  []byte{},
+		},
+		{
+			name:     "no frame",
+			maxFrame: 3,
+			err:      io.EOF,
+		},
+		{
+			name:     "short header",
+			data:     []byte{3, 0},
+			maxFrame: 3,
+			err:      io.ErrUnexpectedEOF,
+		},
+		{
+			name:     "short payload",
+			data:     []byte{3, 0, 0, 0, 1, 2},
+			maxFrame: 3,
+			err:      io.ErrUnexpectedEOF,
+		},
+		{
+			name:     "no payload",
+			data:     []byte{3, 0, 0, 0},
+			maxFrame: 3,
+			err:      io.ErrUnexpectedEOF,
+		},
+		{
+			// The length is checked before allocating the payload
+			name:     "too large",
+			data:     []byte{0xff, 0xff, 0xff, 0xff},
+			maxFrame: 1024,
+			err:      ErrFrameTooLarge,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			payload, err := ReadFrame(bytes.NewReader(tc.data), tc.maxFrame)
+			if err != tc.err {
+				t.Fatalf("ReadFrame expected error %v, got %v", tc.err, err)
+			}
+			if tc.err == nil && !bytes.Equal(payload, tc.payload) {
+				t.Fatalf("ReadFrame = %v, expected %v", payload, tc.payload)
+			}
+		})
+	}
+}
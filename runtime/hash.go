@@ -0,0 +1,114 @@
+// Package runtime has support code for the code generated by skyencoder
+package runtime
+
+import (
+	"encoding/binary"
+	"hash"
+)
+
+// hashEncoderBufferSize is the size of the buffer of a HashEncoder, a multiple of the SHA256 block size
+const hashEncoderBufferSize = 256
+
+// HashEncoder writes encoded values to a hash.Hash.
+// It has the writing methods of encoder.Encoder, so that the generated code which encodes an object
+// to a buffer can also stream the encoding of the object to a hash, without allocating the encoded bytes.
+// Writes are buffered; call Flush after the last write.
+type HashEncoder struct {
+	Hash hash.Hash
+	buf  [hashEncoderBufferSize]byte
+	n    int
+}
+
+// NewHashEncoder creates a HashEncoder which writes to a hash.Hash
+func NewHashEncoder(h hash.Hash) *HashEncoder {
+	return &HashEncoder{
+		Hash: h,
+	}
+}
+
+// Flush writes the buffered bytes to the hash
+func (e *HashEncoder) Flush() {
+	if e.n != 0 {
+		// hash.Hash.Write never returns an error
+		e.Hash.Write(e.buf[:e.n])
+		e.n = 0
+	}
+}
+
+// reserve returns a buffer for the next n <= 8 bytes
+func (e *HashEncoder) reserve(n int) []byte {
+	if e.n+n > len(e.buf) {
+		e.Flush()
+	}
+	b := e.buf[e.n : e.n+n]
+	e.n += n
+	return b
+}
+
+// Bool writes a bool
+func (e *HashEncoder) Bool(x bool) {
+	if x {
+		e.Uint8(1)
+	} else {
+		e.Uint8(0)
+	}
+}
+
+// Uint8 writes a uint8
+func (e *HashEncoder) Uint8(x uint8) {
+	e.reserve(1)[0] = x
+}
+
+// Uint16 writes a uint16
+func (e *HashEncoder) Uint16(x uint16) {
+	binary.LittleEndian.PutUint16(e.reserve(2), x)
+}
+
+// Uint32 writes a uint32
+func (e *HashEncoder) Uint32(x uint32) {
+	binary.LittleEndian.PutUint32(e.reserve(4), x)
+}
+
+// Uint64 writes a uint64
+func (e *HashEncoder) Uint64(x uint64) {
+	binary.LittleEndian.PutUint64(e.reserve(8), x)
+}
+
+// Int8 writes an int8
+func (e *HashEncoder) Int8(x int8) {
+	e.Uint8(uint8(x))
+}
+
+// Int16 writes an int16
+func (e *HashEncoder) Int16(x int16) {
+	e.Uint16(uint16(x))
+}
+
+// Int32 writes an int32
+func (e *HashEncoder) Int32(x int32) {
+	e.Uint32(uint32(x))
+}
+
+// Int64 writes an int64
+func (e *HashEncoder) Int64(x int64) {
+	e.Uint64(uint64(x))
+}
+
+// ByteSlice writes a length-prefixed []byte
+func (e *HashEncoder) ByteSlice(x []byte) {
+	e.Uint32(uint32(len(x)))
+	e.CopyBytes(x)
+}
+
+// CopyBytes writes a []byte without a length prefix.
+// The bytes are copied through the buffer, so that x does not escape to the heap.
+func (e *HashEncoder) CopyBytes(x []byte) {
+	for len(x) != 0 {
+		if e.n == len(e.buf) {
+			e.Flush()
+		}
+		k := copy(e.buf[e.n:], x)
+		e.n += k
+		x = x[k:]
+	}
+}
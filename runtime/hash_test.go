@@ -0,0 +1,90 @@
+package runtime
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/skycoin/skycoin/src/cipher/encoder"
+)
+
+// bufferHash is a hash.Hash which collects the bytes written to it
+type bufferHash struct {
+	bytes.Buffer
+}
+
+func (h *bufferHash) Sum(b []byte) []byte {
+	return append(b, h.Bytes()...)
+}
+
+func (h *bufferHash) Size() int {
+	return h.Len()
+}
+
+func (h *bufferHash) BlockSize() int {
+	return 1
+}
+
+func TestHashEncoder(t *testing.T) {
+	buf := make([]byte, 1+1+2+4+8+1+2+4+8+4+3+2)
+	e := &encoder.Encoder{
+		Buffer: buf,
+	}
+
+	h := &bufferHash{}
+	he := NewHashEncoder(h)
+
+	for _, e := range []interface {
+		Bool(bool)
+		Uint8(uint8)
+		Uint16(uint16)
+		Uint32(uint32)
+		Uint64(uint64)
+		Int8(int8)
+		Int16(int16)
+		Int32(int32)
+		Int64(int64)
+		ByteSlice([]byte)
+		CopyBytes([]byte)
+	}{e, he} {
+		e.Bool(true)
+		e.Uint8(0xF1)
+		e.Uint16(0xF1F2)
+		e.Uint32(0xF1F2F3F4)
+		e.Uint64(0xF1F2F3F4F5F6F7F8)
+		e.Int8(-2)
+		e.Int16(-3)
+		e.Int32(-4)
+		e.Int64(-5)
+		e.ByteSlice([]byte("foo"))
+		e.CopyBytes([]byte("ba"))
+	}
+
+	he.Flush()
+
+	if !bytes.Equal(buf, h.Bytes()) {
+		t.Fatalf("HashEncoder wrote %x, encoder.Encoder wrote %x", h.Bytes(), buf)
+	}
+}
+
+func TestHashEncoderFlush(t *testing.T) {
+	// Writes larger than the buffer are flushed in parts
+	data := make([]byte, 3*hashEncoderBufferSize+5)
+	for i := range data {
+		data[i] = byte(i)
+	}
+
+	h := &bufferHash{}
+	e := NewHashEncoder(h)
+
+	e.Uint8(0xFF)
+	e.CopyBytes(data)
+	e.Uint64(0x0102030405060708)
+	e.Flush()
+
+	expected := append([]byte{0xFF}, data...)
+	expected = append(expected, 0x08, 0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01)
+
+	if !bytes.Equal(expected, h.Bytes()) {
+		t.Fatalf("HashEncoder wrote %x, expected %x", h.Bytes(), expected)
+	}
+}
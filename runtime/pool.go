@@ -0,0 +1,72 @@
+package runtime
+
+import "sync"
+
+const (
+	// minPoolClass is the log2 of the capacity of the smallest pooled buffers
+	minPoolClass = 6
+	// maxPoolClass is the log2 of the capacity of the largest pooled buffers.
+	// Larger buffers are allocated and released to the garbage collector.
+	maxPoolClass = 24
+)
+
+// pools has a sync.Pool of *Buffer for each size class, the buffers of class c having a capacity of 1<<c bytes
+var pools [maxPoolClass + 1]sync.Pool
+
+// Buffer is a byte slice from a pool of buffers. Release returns it to the pool.
+type Buffer struct {
+	b     []byte
+	class int
+}
+
+// GetBuffer returns a Buffer of length n from the pool of the smallest size class which fits n bytes.
+// The contents of the buffer are undefined.
+func GetBuffer(n uint64) *Buffer {
+	class := poolClass(n)
+	if class > maxPoolClass {
+		return &Buffer{
+			b:     make([]byte, n),
+			class: class,
+		}
+	}
+
+	if b, ok := pools[class].Get().(*Buffer); ok {
+		b.b = b.b[:n]
+		return b
+	}
+
+	return &Buffer{
+		b:     make([]byte, n, 1<<uint(class)),
+		class: class,
+	}
+}
+
+// poolClass returns the smallest size class which fits n bytes
+func poolClass(n uint64) int {
+	class := minPoolClass
+	for class <= maxPoolClass && uint64(1)<<uint(class) < n {
+		class++
+	}
+	return class
+}
+
+// Bytes returns the bytes of the buffer, which are valid until the buffer is released
+func (b *Buffer) Bytes() []byte {
+	return b.b
+}
+
+// Len returns the length of the buffer
+func (b *Buffer) Len() int {
+	return len(b.b)
+}
+
+// Release returns the buffer to its pool. The buffer and its bytes must not be used after it is released.
+func (b *Buffer) Release() {
+	if b.class > maxPoolClass {
+		b.b = nil
+		return
+	}
+
+	b.b = b.b[:0]
+	pools[b.class].Put(b)
+}
@@ -0,0 +1,57 @@
+package runtime
+
+import "testing"
+
+func TestPoolClass(t *testing.T) {
+	cases := []struct {
+		n     uint64
+		class int
+	}{
+		{0, minPoolClass},
+		{1, minPoolClass},
+		{64, minPoolClass},
+		{65, minPoolClass + 1},
+		{128, minPoolClass + 1},
+		{1000, 10},
+		{1 << maxPoolClass, maxPoolClass},
+		{1<<maxPoolClass + 1, maxPoolClass + 1},
+	}
+
+	for _, tc := range cases {
+		if class := poolClass(tc.n); class != tc.class {
+			t.Errorf("poolClass(%d) = %d, expected %d", tc.n, class, tc.class)
+		}
+	}
+}
+
+func TestGetBuffer(t *testing.T) {
+	for _, n := range []uint64{0, 1, 63, 64, 65, 1000, 1<<maxPoolClass + 1} {
+		b := GetBuffer(n)
+		if uint64(b.Len()) != n || uint64(len(b.Bytes())) != n {
+			t.Fatalf("GetBuffer(%d) has length %d", n, b.Len())
+		}
+
+		class := poolClass(n)
+		if class <= maxPoolClass && cap(b.Bytes()) != 1<<uint(class) {
+			t.Fatalf("GetBuffer(%d) has capacity %d, expected %d", n, cap(b.Bytes()), 1<<uint(class))
+		}
+
+		// Every byte of the buffer can be written
+		for i := range b.Bytes() {
+			b.Bytes()[i] = 0xFF
+		}
+
+		b.Release()
+	}
+}
+
+func TestGetBufferAllocs(t *testing.T) {
+	allocs := testing.AllocsPerRun(100, func() {
+		b := GetBuffer(1000)
+		b.Bytes()[999] = 1
+		b.Release()
+	})
+	if allocs != 0 {
+		t.Fatalf("GetBuffer and Release allocated %v times", allocs)
+	}
+}
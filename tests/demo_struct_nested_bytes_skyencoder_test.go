@@ -11,20 +11,13 @@ import (
 
 // EncodeSizeDemoStructNestedBytes computes the size of an encoded object of type DemoStructNestedBytes
 func EncodeSizeDemoStructNestedBytes(obj *DemoStructNestedBytes) uint64 {
-	i0 := uint64(0)
-
-	// obj.Objects
-	i0 += 4
+	i := uint64(0)
+	i += 4
+	i += uint64(len(obj.Objects)) * 4
 	for _, x1 := range obj.Objects {
-		i1 := uint64(0)
-
-		// x1.Data
-		i1 += 4 + uint64(len(x1.Data))
-
-		i0 += i1
+		i += uint64(len(x1.Data))
 	}
-
-	return i0
+	return i
 }
 
 // EncodeDemoStructNestedBytes encodes an object of type DemoStructNestedBytes to a buffer allocated to the exact size
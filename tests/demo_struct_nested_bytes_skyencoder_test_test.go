@@ -63,62 +63,6 @@ func newRandomZeroLenNilDemoStructNestedBytesForEncodeTest(t *testing.T, rand *m
 }
 
 func testSkyencoderDemoStructNestedBytes(t *testing.T, obj *DemoStructNestedBytes) {
-	isEncodableField := func(f reflect.StructField) bool {
-		// Skip unexported fields
-		if f.PkgPath != "" {
-			return false
-		}
-
-		// Skip fields disabled with and enc:"- struct tag
-		tag := f.Tag.Get("enc")
-		return !strings.HasPrefix(tag, "-,") && tag != "-"
-	}
-
-	hasOmitEmptyField := func(obj interface{}) bool {
-		v := reflect.ValueOf(obj)
-		switch v.Kind() {
-		case reflect.Ptr:
-			v = v.Elem()
-		}
-
-		switch v.Kind() {
-		case reflect.Struct:
-			t := v.Type()
-			n := v.NumField()
-			f := t.Field(n - 1)
-			tag := f.Tag.Get("enc")
-			return isEncodableField(f) && strings.Contains(tag, ",omitempty")
-		default:
-			return false
-		}
-	}
-
-	// returns the number of bytes encoded by an omitempty field on a given object
-	omitEmptyLen := func(obj interface{}) uint64 {
-		if !hasOmitEmptyField(obj) {
-			return 0
-		}
-
-		v := reflect.ValueOf(obj)
-		switch v.Kind() {
-		case reflect.Ptr:
-			v = v.Elem()
-		}
-
-		switch v.Kind() {
-		case reflect.Struct:
-			n := v.NumField()
-			f := v.Field(n - 1)
-			if f.Len() == 0 {
-				return 0
-			}
-			return uint64(4 + f.Len())
-
-		default:
-			return 0
-		}
-	}
-
 	// EncodeSize
 
 	n1 := encoder.Size(obj)
@@ -186,7 +130,7 @@ func testSkyencoderDemoStructNestedBytes(t *testing.T, obj *DemoStructNestedByte
 		t.Fatalf("DecodeDemoStructNestedBytes failed: %v", err)
 	}
 
-	if hasOmitEmptyField(&obj4) && omitEmptyLen(&obj4) == 0 {
+	if hasOmitEmptyFieldDemoStructNestedBytesForEncodeTest(&obj4) && omitEmptyLenDemoStructNestedBytesForEncodeTest(&obj4) == 0 {
 		// 4 bytes read for the omitEmpty length, which should be zero (see the 5 bytes added above)
 		if n != n2+4 {
 			t.Fatalf("DecodeDemoStructNestedBytes bytes read length should be %d, is %d", n2+4, n)
@@ -210,7 +154,7 @@ func testSkyencoderDemoStructNestedBytes(t *testing.T, obj *DemoStructNestedByte
 	}
 
 	// Check that the bytes read value is correct when providing an extended buffer
-	if !hasOmitEmptyField(&obj3) || omitEmptyLen(&obj3) > 0 {
+	if !hasOmitEmptyFieldDemoStructNestedBytesForEncodeTest(&obj3) || omitEmptyLenDemoStructNestedBytesForEncodeTest(&obj3) > 0 {
 		padding := []byte{0xFF, 0xFE, 0xFD, 0xFC}
 		data4 := append(data2[:], padding...)
 		if n, err := DecodeDemoStructNestedBytes(data4, &obj3); err != nil {
@@ -279,87 +223,6 @@ func decodeDemoStructNestedBytesExactExpectError(t *testing.T, buf []byte, expec
 }
 
 func testSkyencoderDemoStructNestedBytesDecodeErrors(t *testing.T, k int, tag string, obj *DemoStructNestedBytes) {
-	isEncodableField := func(f reflect.StructField) bool {
-		// Skip unexported fields
-		if f.PkgPath != "" {
-			return false
-		}
-
-		// Skip fields disabled with and enc:"- struct tag
-		tag := f.Tag.Get("enc")
-		return !strings.HasPrefix(tag, "-,") && tag != "-"
-	}
-
-	numEncodableFields := func(obj interface{}) int {
-		v := reflect.ValueOf(obj)
-		switch v.Kind() {
-		case reflect.Ptr:
-			v = v.Elem()
-		}
-
-		switch v.Kind() {
-		case reflect.Struct:
-			t := v.Type()
-
-			n := 0
-			for i := 0; i < v.NumField(); i++ {
-				f := t.Field(i)
-				if !isEncodableField(f) {
-					continue
-				}
-				n++
-			}
-			return n
-		default:
-			return 0
-		}
-	}
-
-	hasOmitEmptyField := func(obj interface{}) bool {
-		v := reflect.ValueOf(obj)
-		switch v.Kind() {
-		case reflect.Ptr:
-			v = v.Elem()
-		}
-
-		switch v.Kind() {
-		case reflect.Struct:
-			t := v.Type()
-			n := v.NumField()
-			f := t.Field(n - 1)
-			tag := f.Tag.Get("enc")
-			return isEncodableField(f) && strings.Contains(tag, ",omitempty")
-		default:
-			return false
-		}
-	}
-
-	// returns the number of bytes encoded by an omitempty field on a given object
-	omitEmptyLen := func(obj interface{}) uint64 {
-		if !hasOmitEmptyField(obj) {
-			return 0
-		}
-
-		v := reflect.ValueOf(obj)
-		switch v.Kind() {
-		case reflect.Ptr:
-			v = v.Elem()
-		}
-
-		switch v.Kind() {
-		case reflect.Struct:
-			n := v.NumField()
-			f := v.Field(n - 1)
-			if f.Len() == 0 {
-				return 0
-			}
-			return uint64(4 + f.Len())
-
-		default:
-			return 0
-		}
-	}
-
 	n := EncodeSizeDemoStructNestedBytes(obj)
 	buf, err := EncodeDemoStructNestedBytes(obj)
 	if err != nil {
@@ -367,7 +230,7 @@ func testSkyencoderDemoStructNestedBytesDecodeErrors(t *testing.T, k int, tag st
 	}
 
 	// A nil buffer cannot decode, unless the object is a struct with a single omitempty field
-	if hasOmitEmptyField(obj) && numEncodableFields(obj) > 1 {
+	if hasOmitEmptyFieldDemoStructNestedBytesForEncodeTest(obj) && numEncodableFieldsDemoStructNestedBytesForEncodeTest(obj) > 1 {
 		t.Run(fmt.Sprintf("%d %s buffer underflow nil", k, tag), func(t *testing.T) {
 			decodeDemoStructNestedBytesExpectError(t, nil, encoder.ErrBufferUnderflow)
 		})
@@ -379,7 +242,7 @@ func testSkyencoderDemoStructNestedBytesDecodeErrors(t *testing.T, k int, tag st
 
 	// Test all possible truncations of the encoded byte array, but skip
 	// a truncation that would be valid where omitempty is removed
-	skipN := n - omitEmptyLen(obj)
+	skipN := n - omitEmptyLenDemoStructNestedBytesForEncodeTest(obj)
 	for i := uint64(0); i < n; i++ {
 		if i == skipN {
 			continue
@@ -397,7 +260,7 @@ func testSkyencoderDemoStructNestedBytesDecodeErrors(t *testing.T, k int, tag st
 	// Append 5 bytes for omit empty with a 0 length prefix, to cause an ErrRemainingBytes.
 	// If only 1 byte is appended, the decoder will try to read the 4-byte length prefix,
 	// and return an ErrBufferUnderflow instead
-	if hasOmitEmptyField(obj) {
+	if hasOmitEmptyFieldDemoStructNestedBytesForEncodeTest(obj) {
 		buf = append(buf, []byte{0, 0, 0, 0, 0}...)
 	} else {
 		buf = append(buf, 0)
@@ -419,3 +282,98 @@ func TestSkyencoderDemoStructNestedBytesDecodeErrors(t *testing.T) {
 		testSkyencoderDemoStructNestedBytesDecodeErrors(t, i, "full", fullObj)
 	}
 }
+
+// isEncodableFieldDemoStructNestedBytesForEncodeTest returns true if a struct field is encoded
+func isEncodableFieldDemoStructNestedBytesForEncodeTest(f reflect.StructField) bool {
+	// Skip unexported fields
+	if f.PkgPath != "" {
+		return false
+	}
+
+	// Skip fields disabled with and enc:"- struct tag
+	tag := f.Tag.Get("enc")
+	return !strings.HasPrefix(tag, "-,") && tag != "-"
+}
+
+// numEncodableFieldsDemoStructNestedBytesForEncodeTest returns the number of encoded fields of a struct
+func numEncodableFieldsDemoStructNestedBytesForEncodeTest(obj interface{}) int {
+	v := reflect.ValueOf(obj)
+	switch v.Kind() {
+	case reflect.Ptr:
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+
+		n := 0
+		for i := 0; i < v.NumField(); i++ {
+			f := t.Field(i)
+			if !isEncodableFieldDemoStructNestedBytesForEncodeTest(f) {
+				continue
+			}
+			n++
+		}
+		return n
+	default:
+		return 0
+	}
+}
+
+// hasOmitEmptyFieldDemoStructNestedBytesForEncodeTest returns true if a struct has an omitempty field
+func hasOmitEmptyFieldDemoStructNestedBytesForEncodeTest(obj interface{}) bool {
+	v := reflect.ValueOf(obj)
+	switch v.Kind() {
+	case reflect.Ptr:
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		// The omitempty field is the last encoded field, which is declared last unless the fields are ordered
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			f := t.Field(i)
+			tag := f.Tag.Get("enc")
+			if isEncodableFieldDemoStructNestedBytesForEncodeTest(f) && strings.Contains(tag, ",omitempty") {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// omitEmptyLenDemoStructNestedBytesForEncodeTest returns the number of bytes encoded by an omitempty field on a given object
+func omitEmptyLenDemoStructNestedBytesForEncodeTest(obj interface{}) uint64 {
+	if !hasOmitEmptyFieldDemoStructNestedBytesForEncodeTest(obj) {
+		return 0
+	}
+
+	v := reflect.ValueOf(obj)
+	switch v.Kind() {
+	case reflect.Ptr:
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			if !strings.Contains(t.Field(i).Tag.Get("enc"), ",omitempty") {
+				continue
+			}
+			f := v.Field(i)
+			if f.Len() == 0 {
+				return 0
+			}
+			return uint64(4 + f.Len())
+		}
+		return 0
+
+	default:
+		return 0
+	}
+}
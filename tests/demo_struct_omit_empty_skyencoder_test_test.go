@@ -63,62 +63,6 @@ func newRandomZeroLenNilDemoStructOmitEmptyForEncodeTest(t *testing.T, rand *mat
 }
 
 func testSkyencoderDemoStructOmitEmpty(t *testing.T, obj *DemoStructOmitEmpty) {
-	isEncodableField := func(f reflect.StructField) bool {
-		// Skip unexported fields
-		if f.PkgPath != "" {
-			return false
-		}
-
-		// Skip fields disabled with and enc:"- struct tag
-		tag := f.Tag.Get("enc")
-		return !strings.HasPrefix(tag, "-,") && tag != "-"
-	}
-
-	hasOmitEmptyField := func(obj interface{}) bool {
-		v := reflect.ValueOf(obj)
-		switch v.Kind() {
-		case reflect.Ptr:
-			v = v.Elem()
-		}
-
-		switch v.Kind() {
-		case reflect.Struct:
-			t := v.Type()
-			n := v.NumField()
-			f := t.Field(n - 1)
-			tag := f.Tag.Get("enc")
-			return isEncodableField(f) && strings.Contains(tag, ",omitempty")
-		default:
-			return false
-		}
-	}
-
-	// returns the number of bytes encoded by an omitempty field on a given object
-	omitEmptyLen := func(obj interface{}) uint64 {
-		if !hasOmitEmptyField(obj) {
-			return 0
-		}
-
-		v := reflect.ValueOf(obj)
-		switch v.Kind() {
-		case reflect.Ptr:
-			v = v.Elem()
-		}
-
-		switch v.Kind() {
-		case reflect.Struct:
-			n := v.NumField()
-			f := v.Field(n - 1)
-			if f.Len() == 0 {
-				return 0
-			}
-			return uint64(4 + f.Len())
-
-		default:
-			return 0
-		}
-	}
-
 	// EncodeSize
 
 	n1 := encoder.Size(obj)
@@ -186,7 +130,7 @@ func testSkyencoderDemoStructOmitEmpty(t *testing.T, obj *DemoStructOmitEmpty) {
 		t.Fatalf("DecodeDemoStructOmitEmpty failed: %v", err)
 	}
 
-	if hasOmitEmptyField(&obj4) && omitEmptyLen(&obj4) == 0 {
+	if hasOmitEmptyFieldDemoStructOmitEmptyForEncodeTest(&obj4) && omitEmptyLenDemoStructOmitEmptyForEncodeTest(&obj4) == 0 {
 		// 4 bytes read for the omitEmpty length, which should be zero (see the 5 bytes added above)
 		if n != n2+4 {
 			t.Fatalf("DecodeDemoStructOmitEmpty bytes read length should be %d, is %d", n2+4, n)
@@ -210,7 +154,7 @@ func testSkyencoderDemoStructOmitEmpty(t *testing.T, obj *DemoStructOmitEmpty) {
 	}
 
 	// Check that the bytes read value is correct when providing an extended buffer
-	if !hasOmitEmptyField(&obj3) || omitEmptyLen(&obj3) > 0 {
+	if !hasOmitEmptyFieldDemoStructOmitEmptyForEncodeTest(&obj3) || omitEmptyLenDemoStructOmitEmptyForEncodeTest(&obj3) > 0 {
 		padding := []byte{0xFF, 0xFE, 0xFD, 0xFC}
 		data4 := append(data2[:], padding...)
 		if n, err := DecodeDemoStructOmitEmpty(data4, &obj3); err != nil {
@@ -279,87 +223,6 @@ func decodeDemoStructOmitEmptyExactExpectError(t *testing.T, buf []byte, expecte
 }
 
 func testSkyencoderDemoStructOmitEmptyDecodeErrors(t *testing.T, k int, tag string, obj *DemoStructOmitEmpty) {
-	isEncodableField := func(f reflect.StructField) bool {
-		// Skip unexported fields
-		if f.PkgPath != "" {
-			return false
-		}
-
-		// Skip fields disabled with and enc:"- struct tag
-		tag := f.Tag.Get("enc")
-		return !strings.HasPrefix(tag, "-,") && tag != "-"
-	}
-
-	numEncodableFields := func(obj interface{}) int {
-		v := reflect.ValueOf(obj)
-		switch v.Kind() {
-		case reflect.Ptr:
-			v = v.Elem()
-		}
-
-		switch v.Kind() {
-		case reflect.Struct:
-			t := v.Type()
-
-			n := 0
-			for i := 0; i < v.NumField(); i++ {
-				f := t.Field(i)
-				if !isEncodableField(f) {
-					continue
-				}
-				n++
-			}
-			return n
-		default:
-			return 0
-		}
-	}
-
-	hasOmitEmptyField := func(obj interface{}) bool {
-		v := reflect.ValueOf(obj)
-		switch v.Kind() {
-		case reflect.Ptr:
-			v = v.Elem()
-		}
-
-		switch v.Kind() {
-		case reflect.Struct:
-			t := v.Type()
-			n := v.NumField()
-			f := t.Field(n - 1)
-			tag := f.Tag.Get("enc")
-			return isEncodableField(f) && strings.Contains(tag, ",omitempty")
-		default:
-			return false
-		}
-	}
-
-	// returns the number of bytes encoded by an omitempty field on a given object
-	omitEmptyLen := func(obj interface{}) uint64 {
-		if !hasOmitEmptyField(obj) {
-			return 0
-		}
-
-		v := reflect.ValueOf(obj)
-		switch v.Kind() {
-		case reflect.Ptr:
-			v = v.Elem()
-		}
-
-		switch v.Kind() {
-		case reflect.Struct:
-			n := v.NumField()
-			f := v.Field(n - 1)
-			if f.Len() == 0 {
-				return 0
-			}
-			return uint64(4 + f.Len())
-
-		default:
-			return 0
-		}
-	}
-
 	n := EncodeSizeDemoStructOmitEmpty(obj)
 	buf, err := EncodeDemoStructOmitEmpty(obj)
 	if err != nil {
@@ -367,7 +230,7 @@ func testSkyencoderDemoStructOmitEmptyDecodeErrors(t *testing.T, k int, tag stri
 	}
 
 	// A nil buffer cannot decode, unless the object is a struct with a single omitempty field
-	if hasOmitEmptyField(obj) && numEncodableFields(obj) > 1 {
+	if hasOmitEmptyFieldDemoStructOmitEmptyForEncodeTest(obj) && numEncodableFieldsDemoStructOmitEmptyForEncodeTest(obj) > 1 {
 		t.Run(fmt.Sprintf("%d %s buffer underflow nil", k, tag), func(t *testing.T) {
 			decodeDemoStructOmitEmptyExpectError(t, nil, encoder.ErrBufferUnderflow)
 		})
@@ -379,7 +242,7 @@ func testSkyencoderDemoStructOmitEmptyDecodeErrors(t *testing.T, k int, tag stri
 
 	// Test all possible truncations of the encoded byte array, but skip
 	// a truncation that would be valid where omitempty is removed
-	skipN := n - omitEmptyLen(obj)
+	skipN := n - omitEmptyLenDemoStructOmitEmptyForEncodeTest(obj)
 	for i := uint64(0); i < n; i++ {
 		if i == skipN {
 			continue
@@ -397,7 +260,7 @@ func testSkyencoderDemoStructOmitEmptyDecodeErrors(t *testing.T, k int, tag stri
 	// Append 5 bytes for omit empty with a 0 length prefix, to cause an ErrRemainingBytes.
 	// If only 1 byte is appended, the decoder will try to read the 4-byte length prefix,
 	// and return an ErrBufferUnderflow instead
-	if hasOmitEmptyField(obj) {
+	if hasOmitEmptyFieldDemoStructOmitEmptyForEncodeTest(obj) {
 		buf = append(buf, []byte{0, 0, 0, 0, 0}...)
 	} else {
 		buf = append(buf, 0)
@@ -419,3 +282,98 @@ func TestSkyencoderDemoStructOmitEmptyDecodeErrors(t *testing.T) {
 		testSkyencoderDemoStructOmitEmptyDecodeErrors(t, i, "full", fullObj)
 	}
 }
+
+// isEncodableFieldDemoStructOmitEmptyForEncodeTest returns true if a struct field is encoded
+func isEncodableFieldDemoStructOmitEmptyForEncodeTest(f reflect.StructField) bool {
+	// Skip unexported fields
+	if f.PkgPath != "" {
+		return false
+	}
+
+	// Skip fields disabled with and enc:"- struct tag
+	tag := f.Tag.Get("enc")
+	return !strings.HasPrefix(tag, "-,") && tag != "-"
+}
+
+// numEncodableFieldsDemoStructOmitEmptyForEncodeTest returns the number of encoded fields of a struct
+func numEncodableFieldsDemoStructOmitEmptyForEncodeTest(obj interface{}) int {
+	v := reflect.ValueOf(obj)
+	switch v.Kind() {
+	case reflect.Ptr:
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+
+		n := 0
+		for i := 0; i < v.NumField(); i++ {
+			f := t.Field(i)
+			if !isEncodableFieldDemoStructOmitEmptyForEncodeTest(f) {
+				continue
+			}
+			n++
+		}
+		return n
+	default:
+		return 0
+	}
+}
+
+// hasOmitEmptyFieldDemoStructOmitEmptyForEncodeTest returns true if a struct has an omitempty field
+func hasOmitEmptyFieldDemoStructOmitEmptyForEncodeTest(obj interface{}) bool {
+	v := reflect.ValueOf(obj)
+	switch v.Kind() {
+	case reflect.Ptr:
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		// The omitempty field is the last encoded field, which is declared last unless the fields are ordered
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			f := t.Field(i)
+			tag := f.Tag.Get("enc")
+			if isEncodableFieldDemoStructOmitEmptyForEncodeTest(f) && strings.Contains(tag, ",omitempty") {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// omitEmptyLenDemoStructOmitEmptyForEncodeTest returns the number of bytes encoded by an omitempty field on a given object
+func omitEmptyLenDemoStructOmitEmptyForEncodeTest(obj interface{}) uint64 {
+	if !hasOmitEmptyFieldDemoStructOmitEmptyForEncodeTest(obj) {
+		return 0
+	}
+
+	v := reflect.ValueOf(obj)
+	switch v.Kind() {
+	case reflect.Ptr:
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			if !strings.Contains(t.Field(i).Tag.Get("enc"), ",omitempty") {
+				continue
+			}
+			f := v.Field(i)
+			if f.Len() == 0 {
+				return 0
+			}
+			return uint64(4 + f.Len())
+		}
+		return 0
+
+	default:
+		return 0
+	}
+}
@@ -62,62 +62,6 @@ func newRandomZeroLenNilMaxLenAllStruct2ForEncodeTest(t *testing.T, rand *mathra
 }
 
 func testSkyencoderMaxLenAllStruct2(t *testing.T, obj *MaxLenAllStruct2) {
-	isEncodableField := func(f reflect.StructField) bool {
-		// Skip unexported fields
-		if f.PkgPath != "" {
-			return false
-		}
-
-		// Skip fields disabled with and enc:"- struct tag
-		tag := f.Tag.Get("enc")
-		return !strings.HasPrefix(tag, "-,") && tag != "-"
-	}
-
-	hasOmitEmptyField := func(obj interface{}) bool {
-		v := reflect.ValueOf(obj)
-		switch v.Kind() {
-		case reflect.Ptr:
-			v = v.Elem()
-		}
-
-		switch v.Kind() {
-		case reflect.Struct:
-			t := v.Type()
-			n := v.NumField()
-			f := t.Field(n - 1)
-			tag := f.Tag.Get("enc")
-			return isEncodableField(f) && strings.Contains(tag, ",omitempty")
-		default:
-			return false
-		}
-	}
-
-	// returns the number of bytes encoded by an omitempty field on a given object
-	omitEmptyLen := func(obj interface{}) uint64 {
-		if !hasOmitEmptyField(obj) {
-			return 0
-		}
-
-		v := reflect.ValueOf(obj)
-		switch v.Kind() {
-		case reflect.Ptr:
-			v = v.Elem()
-		}
-
-		switch v.Kind() {
-		case reflect.Struct:
-			n := v.NumField()
-			f := v.Field(n - 1)
-			if f.Len() == 0 {
-				return 0
-			}
-			return uint64(4 + f.Len())
-
-		default:
-			return 0
-		}
-	}
-
 	// EncodeSize
 
 	n1 := encoder.Size(obj)
@@ -181,7 +125,7 @@ func testSkyencoderMaxLenAllStruct2(t *testing.T, obj *MaxLenAllStruct2) {
 		t.Fatalf("DecodeMaxLenAllStruct2 failed: %v", err)
 	}
 
-	if hasOmitEmptyField(&obj4) && omitEmptyLen(&obj4) == 0 {
+	if hasOmitEmptyFieldMaxLenAllStruct2ForEncodeTest(&obj4) && omitEmptyLenMaxLenAllStruct2ForEncodeTest(&obj4) == 0 {
 		// 4 bytes read for the omitEmpty length, which should be zero (see the 5 bytes added above)
 		if n != n2+4 {
 			t.Fatalf("DecodeMaxLenAllStruct2 bytes read length should be %d, is %d", n2+4, n)
@@ -205,7 +149,7 @@ func testSkyencoderMaxLenAllStruct2(t *testing.T, obj *MaxLenAllStruct2) {
 	}
 
 	// Check that the bytes read value is correct when providing an extended buffer
-	if !hasOmitEmptyField(&obj3) || omitEmptyLen(&obj3) > 0 {
+	if !hasOmitEmptyFieldMaxLenAllStruct2ForEncodeTest(&obj3) || omitEmptyLenMaxLenAllStruct2ForEncodeTest(&obj3) > 0 {
 		padding := []byte{0xFF, 0xFE, 0xFD, 0xFC}
 		data4 := append(data2[:], padding...)
 		if n, err := DecodeMaxLenAllStruct2(data4, &obj3); err != nil {
@@ -274,87 +218,6 @@ func decodeMaxLenAllStruct2ExactExpectError(t *testing.T, buf []byte, expectedEr
 }
 
 func testSkyencoderMaxLenAllStruct2DecodeErrors(t *testing.T, k int, tag string, obj *MaxLenAllStruct2) {
-	isEncodableField := func(f reflect.StructField) bool {
-		// Skip unexported fields
-		if f.PkgPath != "" {
-			return false
-		}
-
-		// Skip fields disabled with and enc:"- struct tag
-		tag := f.Tag.Get("enc")
-		return !strings.HasPrefix(tag, "-,") && tag != "-"
-	}
-
-	numEncodableFields := func(obj interface{}) int {
-		v := reflect.ValueOf(obj)
-		switch v.Kind() {
-		case reflect.Ptr:
-			v = v.Elem()
-		}
-
-		switch v.Kind() {
-		case reflect.Struct:
-			t := v.Type()
-
-			n := 0
-			for i := 0; i < v.NumField(); i++ {
-				f := t.Field(i)
-				if !isEncodableField(f) {
-					continue
-				}
-				n++
-			}
-			return n
-		default:
-			return 0
-		}
-	}
-
-	hasOmitEmptyField := func(obj interface{}) bool {
-		v := reflect.ValueOf(obj)
-		switch v.Kind() {
-		case reflect.Ptr:
-			v = v.Elem()
-		}
-
-		switch v.Kind() {
-		case reflect.Struct:
-			t := v.Type()
-			n := v.NumField()
-			f := t.Field(n - 1)
-			tag := f.Tag.Get("enc")
-			return isEncodableField(f) && strings.Contains(tag, ",omitempty")
-		default:
-			return false
-		}
-	}
-
-	// returns the number of bytes encoded by an omitempty field on a given object
-	omitEmptyLen := func(obj interface{}) uint64 {
-		if !hasOmitEmptyField(obj) {
-			return 0
-		}
-
-		v := reflect.ValueOf(obj)
-		switch v.Kind() {
-		case reflect.Ptr:
-			v = v.Elem()
-		}
-
-		switch v.Kind() {
-		case reflect.Struct:
-			n := v.NumField()
-			f := v.Field(n - 1)
-			if f.Len() == 0 {
-				return 0
-			}
-			return uint64(4 + f.Len())
-
-		default:
-			return 0
-		}
-	}
-
 	n := EncodeSizeMaxLenAllStruct2(obj)
 	buf, err := EncodeMaxLenAllStruct2(obj)
 	if err != nil {
@@ -362,7 +225,7 @@ func testSkyencoderMaxLenAllStruct2DecodeErrors(t *testing.T, k int, tag string,
 	}
 
 	// A nil buffer cannot decode, unless the object is a struct with a single omitempty field
-	if hasOmitEmptyField(obj) && numEncodableFields(obj) > 1 {
+	if hasOmitEmptyFieldMaxLenAllStruct2ForEncodeTest(obj) && numEncodableFieldsMaxLenAllStruct2ForEncodeTest(obj) > 1 {
 		t.Run(fmt.Sprintf("%d %s buffer underflow nil", k, tag), func(t *testing.T) {
 			decodeMaxLenAllStruct2ExpectError(t, nil, encoder.ErrBufferUnderflow)
 		})
@@ -374,7 +237,7 @@ func testSkyencoderMaxLenAllStruct2DecodeErrors(t *testing.T, k int, tag string,
 
 	// Test all possible truncations of the encoded byte array, but skip
 	// a truncation that would be valid where omitempty is removed
-	skipN := n - omitEmptyLen(obj)
+	skipN := n - omitEmptyLenMaxLenAllStruct2ForEncodeTest(obj)
 	for i := uint64(0); i < n; i++ {
 		if i == skipN {
 			continue
@@ -392,7 +255,7 @@ func testSkyencoderMaxLenAllStruct2DecodeErrors(t *testing.T, k int, tag string,
 	// Append 5 bytes for omit empty with a 0 length prefix, to cause an ErrRemainingBytes.
 	// If only 1 byte is appended, the decoder will try to read the 4-byte length prefix,
 	// and return an ErrBufferUnderflow instead
-	if hasOmitEmptyField(obj) {
+	if hasOmitEmptyFieldMaxLenAllStruct2ForEncodeTest(obj) {
 		buf = append(buf, []byte{0, 0, 0, 0, 0}...)
 	} else {
 		buf = append(buf, 0)
@@ -414,3 +277,98 @@ func TestSkyencoderMaxLenAllStruct2DecodeErrors(t *testing.T) {
 		testSkyencoderMaxLenAllStruct2DecodeErrors(t, i, "full", fullObj)
 	}
 }
+
+// isEncodableFieldMaxLenAllStruct2ForEncodeTest returns true if a struct field is encoded
+func isEncodableFieldMaxLenAllStruct2ForEncodeTest(f reflect.StructField) bool {
+	// Skip unexported fields
+	if f.PkgPath != "" {
+		return false
+	}
+
+	// Skip fields disabled with and enc:"- struct tag
+	tag := f.Tag.Get("enc")
+	return !strings.HasPrefix(tag, "-,") && tag != "-"
+}
+
+// numEncodableFieldsMaxLenAllStruct2ForEncodeTest returns the number of encoded fields of a struct
+func numEncodableFieldsMaxLenAllStruct2ForEncodeTest(obj interface{}) int {
+	v := reflect.ValueOf(obj)
+	switch v.Kind() {
+	case reflect.Ptr:
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+
+		n := 0
+		for i := 0; i < v.NumField(); i++ {
+			f := t.Field(i)
+			if !isEncodableFieldMaxLenAllStruct2ForEncodeTest(f) {
+				continue
+			}
+			n++
+		}
+		return n
+	default:
+		return 0
+	}
+}
+
+// hasOmitEmptyFieldMaxLenAllStruct2ForEncodeTest returns true if a struct has an omitempty field
+func hasOmitEmptyFieldMaxLenAllStruct2ForEncodeTest(obj interface{}) bool {
+	v := reflect.ValueOf(obj)
+	switch v.Kind() {
+	case reflect.Ptr:
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		// The omitempty field is the last encoded field, which is declared last unless the fields are ordered
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			f := t.Field(i)
+			tag := f.Tag.Get("enc")
+			if isEncodableFieldMaxLenAllStruct2ForEncodeTest(f) && strings.Contains(tag, ",omitempty") {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// omitEmptyLenMaxLenAllStruct2ForEncodeTest returns the number of bytes encoded by an omitempty field on a given object
+func omitEmptyLenMaxLenAllStruct2ForEncodeTest(obj interface{}) uint64 {
+	if !hasOmitEmptyFieldMaxLenAllStruct2ForEncodeTest(obj) {
+		return 0
+	}
+
+	v := reflect.ValueOf(obj)
+	switch v.Kind() {
+	case reflect.Ptr:
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			if !strings.Contains(t.Field(i).Tag.Get("enc"), ",omitempty") {
+				continue
+			}
+			f := v.Field(i)
+			if f.Len() == 0 {
+				return 0
+			}
+			return uint64(4 + f.Len())
+		}
+		return 0
+
+	default:
+		return 0
+	}
+}
@@ -4,7 +4,9 @@ package tests
 
 import (
 	"bytes"
+	"encoding/binary"
 	"fmt"
+	"io"
 	mathrand "math/rand"
 	"reflect"
 	"strings"
@@ -15,6 +17,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/skycoin/encodertest"
 	"github.com/skycoin/skycoin/src/cipher/encoder"
+	"github.com/skycoin/skyencoder/runtime"
 )
 
 func newEmptyOmitEmptyStructForEncodeTest() *OmitEmptyStruct {
@@ -63,62 +66,6 @@ func newRandomZeroLenNilOmitEmptyStructForEncodeTest(t *testing.T, rand *mathran
 }
 
 func testSkyencoderOmitEmptyStruct(t *testing.T, obj *OmitEmptyStruct) {
-	isEncodableField := func(f reflect.StructField) bool {
-		// Skip unexported fields
-		if f.PkgPath != "" {
-			return false
-		}
-
-		// Skip fields disabled with and enc:"- struct tag
-		tag := f.Tag.Get("enc")
-		return !strings.HasPrefix(tag, "-,") && tag != "-"
-	}
-
-	hasOmitEmptyField := func(obj interface{}) bool {
-		v := reflect.ValueOf(obj)
-		switch v.Kind() {
-		case reflect.Ptr:
-			v = v.Elem()
-		}
-
-		switch v.Kind() {
-		case reflect.Struct:
-			t := v.Type()
-			n := v.NumField()
-			f := t.Field(n - 1)
-			tag := f.Tag.Get("enc")
-			return isEncodableField(f) && strings.Contains(tag, ",omitempty")
-		default:
-			return false
-		}
-	}
-
-	// returns the number of bytes encoded by an omitempty field on a given object
-	omitEmptyLen := func(obj interface{}) uint64 {
-		if !hasOmitEmptyField(obj) {
-			return 0
-		}
-
-		v := reflect.ValueOf(obj)
-		switch v.Kind() {
-		case reflect.Ptr:
-			v = v.Elem()
-		}
-
-		switch v.Kind() {
-		case reflect.Struct:
-			n := v.NumField()
-			f := v.Field(n - 1)
-			if f.Len() == 0 {
-				return 0
-			}
-			return uint64(4 + f.Len())
-
-		default:
-			return 0
-		}
-	}
-
 	// EncodeSize
 
 	n1 := encoder.Size(obj)
@@ -186,7 +133,7 @@ func testSkyencoderOmitEmptyStruct(t *testing.T, obj *OmitEmptyStruct) {
 		t.Fatalf("DecodeOmitEmptyStruct failed: %v", err)
 	}
 
-	if hasOmitEmptyField(&obj4) && omitEmptyLen(&obj4) == 0 {
+	if hasOmitEmptyFieldOmitEmptyStructForEncodeTest(&obj4) && omitEmptyLenOmitEmptyStructForEncodeTest(&obj4) == 0 {
 		// 4 bytes read for the omitEmpty length, which should be zero (see the 5 bytes added above)
 		if n != n2+4 {
 			t.Fatalf("DecodeOmitEmptyStruct bytes read length should be %d, is %d", n2+4, n)
@@ -210,7 +157,7 @@ func testSkyencoderOmitEmptyStruct(t *testing.T, obj *OmitEmptyStruct) {
 	}
 
 	// Check that the bytes read value is correct when providing an extended buffer
-	if !hasOmitEmptyField(&obj3) || omitEmptyLen(&obj3) > 0 {
+	if !hasOmitEmptyFieldOmitEmptyStructForEncodeTest(&obj3) || omitEmptyLenOmitEmptyStructForEncodeTest(&obj3) > 0 {
 		padding := []byte{0xFF, 0xFE, 0xFD, 0xFC}
 		data4 := append(data2[:], padding...)
 		if n, err := DecodeOmitEmptyStruct(data4, &obj3); err != nil {
@@ -279,87 +226,6 @@ func decodeOmitEmptyStructExactExpectError(t *testing.T, buf []byte, expectedErr
 }
 
 func testSkyencoderOmitEmptyStructDecodeErrors(t *testing.T, k int, tag string, obj *OmitEmptyStruct) {
-	isEncodableField := func(f reflect.StructField) bool {
-		// Skip unexported fields
-		if f.PkgPath != "" {
-			return false
-		}
-
-		// Skip fields disabled with and enc:"- struct tag
-		tag := f.Tag.Get("enc")
-		return !strings.HasPrefix(tag, "-,") && tag != "-"
-	}
-
-	numEncodableFields := func(obj interface{}) int {
-		v := reflect.ValueOf(obj)
-		switch v.Kind() {
-		case reflect.Ptr:
-			v = v.Elem()
-		}
-
-		switch v.Kind() {
-		case reflect.Struct:
-			t := v.Type()
-
-			n := 0
-			for i := 0; i < v.NumField(); i++ {
-				f := t.Field(i)
-				if !isEncodableField(f) {
-					continue
-				}
-				n++
-			}
-			return n
-		default:
-			return 0
-		}
-	}
-
-	hasOmitEmptyField := func(obj interface{}) bool {
-		v := reflect.ValueOf(obj)
-		switch v.Kind() {
-		case reflect.Ptr:
-			v = v.Elem()
-		}
-
-		switch v.Kind() {
-		case reflect.Struct:
-			t := v.Type()
-			n := v.NumField()
-			f := t.Field(n - 1)
-			tag := f.Tag.Get("enc")
-			return isEncodableField(f) && strings.Contains(tag, ",omitempty")
-		default:
-			return false
-		}
-	}
-
-	// returns the number of bytes encoded by an omitempty field on a given object
-	omitEmptyLen := func(obj interface{}) uint64 {
-		if !hasOmitEmptyField(obj) {
-			return 0
-		}
-
-		v := reflect.ValueOf(obj)
-		switch v.Kind() {
-		case reflect.Ptr:
-			v = v.Elem()
-		}
-
-		switch v.Kind() {
-		case reflect.Struct:
-			n := v.NumField()
-			f := v.Field(n - 1)
-			if f.Len() == 0 {
-				return 0
-			}
-			return uint64(4 + f.Len())
-
-		default:
-			return 0
-		}
-	}
-
 	n := EncodeSizeOmitEmptyStruct(obj)
 	buf, err := EncodeOmitEmptyStruct(obj)
 	if err != nil {
@@ -367,7 +233,7 @@ func testSkyencoderOmitEmptyStructDecodeErrors(t *testing.T, k int, tag string,
 	}
 
 	// A nil buffer cannot decode, unless the object is a struct with a single omitempty field
-	if hasOmitEmptyField(obj) && numEncodableFields(obj) > 1 {
+	if hasOmitEmptyFieldOmitEmptyStructForEncodeTest(obj) && numEncodableFieldsOmitEmptyStructForEncodeTest(obj) > 1 {
 		t.Run(fmt.Sprintf("%d %s buffer underflow nil", k, tag), func(t *testing.T) {
 			decodeOmitEmptyStructExpectError(t, nil, encoder.ErrBufferUnderflow)
 		})
@@ -379,7 +245,7 @@ func testSkyencoderOmitEmptyStructDecodeErrors(t *testing.T, k int, tag string,
 
 	// Test all possible truncations of the encoded byte array, but skip
 	// a truncation that would be valid where omitempty is removed
-	skipN := n - omitEmptyLen(obj)
+	skipN := n - omitEmptyLenOmitEmptyStructForEncodeTest(obj)
 	for i := uint64(0); i < n; i++ {
 		if i == skipN {
 			continue
@@ -397,7 +263,7 @@ func testSkyencoderOmitEmptyStructDecodeErrors(t *testing.T, k int, tag string,
 	// Append 5 bytes for omit empty with a 0 length prefix, to cause an ErrRemainingBytes.
 	// If only 1 byte is appended, the decoder will try to read the 4-byte length prefix,
 	// and return an ErrBufferUnderflow instead
-	if hasOmitEmptyField(obj) {
+	if hasOmitEmptyFieldOmitEmptyStructForEncodeTest(obj) {
 		buf = append(buf, []byte{0, 0, 0, 0, 0}...)
 	} else {
 		buf = append(buf, 0)
@@ -419,3 +285,168 @@ func TestSkyencoderOmitEmptyStructDecodeErrors(t *testing.T) {
 		testSkyencoderOmitEmptyStructDecodeErrors(t, i, "full", fullObj)
 	}
 }
+
+// isEncodableFieldOmitEmptyStructForEncodeTest returns true if a struct field is encoded
+func isEncodableFieldOmitEmptyStructForEncodeTest(f reflect.StructField) bool {
+	// Skip unexported fields
+	if f.PkgPath != "" {
+		return false
+	}
+
+	// Skip fields disabled with and enc:"- struct tag
+	tag := f.Tag.Get("enc")
+	return !strings.HasPrefix(tag, "-,") && tag != "-"
+}
+
+// numEncodableFieldsOmitEmptyStructForEncodeTest returns the number of encoded fields of a struct
+func numEncodableFieldsOmitEmptyStructForEncodeTest(obj interface{}) int {
+	v := reflect.ValueOf(obj)
+	switch v.Kind() {
+	case reflect.Ptr:
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+
+		n := 0
+		for i := 0; i < v.NumField(); i++ {
+			f := t.Field(i)
+			if !isEncodableFieldOmitEmptyStructForEncodeTest(f) {
+				continue
+			}
+			n++
+		}
+		return n
+	default:
+		return 0
+	}
+}
+
+// hasOmitEmptyFieldOmitEmptyStructForEncodeTest returns true if a struct has an omitempty field
+func hasOmitEmptyFieldOmitEmptyStructForEncodeTest(obj interface{}) bool {
+	v := reflect.ValueOf(obj)
+	switch v.Kind() {
+	case reflect.Ptr:
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		// The omitempty field is the last encoded field, which is declared last unless the fields are ordered
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			f := t.Field(i)
+			tag := f.Tag.Get("enc")
+			if isEncodableFieldOmitEmptyStructForEncodeTest(f) && strings.Contains(tag, ",omitempty") {
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+}
+
+// omitEmptyLenOmitEmptyStructForEncodeTest returns the number of bytes encoded by an omitempty field on a given object
+func omitEmptyLenOmitEmptyStructForEncodeTest(obj interface{}) uint64 {
+	if !hasOmitEmptyFieldOmitEmptyStructForEncodeTest(obj) {
+		return 0
+	}
+
+	v := reflect.ValueOf(obj)
+	switch v.Kind() {
+	case reflect.Ptr:
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < v.NumField(); i++ {
+			if !strings.Contains(t.Field(i).Tag.Get("enc"), ",omitempty") {
+				continue
+			}
+			f := v.Field(i)
+			if f.Len() == 0 {
+				return 0
+			}
+			return uint64(4 + f.Len())
+		}
+		return 0
+
+	default:
+		return 0
+	}
+}
+
+func testSkyencoderOmitEmptyStructFramed(t *testing.T, obj *OmitEmptyStruct) {
+	data, err := EncodeOmitEmptyStruct(obj)
+	if err != nil {
+		t.Fatalf("EncodeOmitEmptyStruct failed: %v", err)
+	}
+
+	var w bytes.Buffer
+	if err := WriteFramedOmitEmptyStruct(&w, obj); err != nil {
+		t.Fatalf("WriteFramedOmitEmptyStruct failed: %v", err)
+	}
+	frame := w.Bytes()
+
+	if len(frame) < runtime.FrameHeaderSize || binary.LittleEndian.Uint32(frame) != uint32(len(data)) {
+		t.Fatalf("WriteFramedOmitEmptyStruct() length prefix != len(EncodeOmitEmptyStruct()) (%v != %d)", frame[:runtime.FrameHeaderSize], len(data))
+	}
+
+	if !bytes.Equal(frame[runtime.FrameHeaderSize:], data) {
+		t.Fatal("WriteFramedOmitEmptyStruct() payload != EncodeOmitEmptyStruct()")
+	}
+
+	// A stream of two frames is read one frame at a time
+	r := bytes.NewReader(append(append([]byte{}, frame...), frame...))
+	for i := 0; i < 2; i++ {
+		var obj2 OmitEmptyStruct
+		if err := ReadFramedOmitEmptyStruct(r, &obj2, uint32(len(data))); err != nil {
+			t.Fatalf("ReadFramedOmitEmptyStruct failed: %v", err)
+		}
+
+		if !cmp.Equal(*obj, obj2, cmpopts.EquateEmpty(), encodertest.IgnoreAllUnexported()) {
+			t.Fatal("ReadFramedOmitEmptyStruct(WriteFramedOmitEmptyStruct()) != obj")
+		}
+	}
+
+	var obj2 OmitEmptyStruct
+	if err := ReadFramedOmitEmptyStruct(r, &obj2, uint32(len(data))); err != io.EOF {
+		t.Fatalf("ReadFramedOmitEmptyStruct at the end of the stream expected error %v, got %v", io.EOF, err)
+	}
+
+	// The frame length is checked against the limit before the payload is read
+	if len(data) != 0 {
+		if err := ReadFramedOmitEmptyStruct(bytes.NewReader(frame[:runtime.FrameHeaderSize]), &obj2, uint32(len(data)-1)); err != runtime.ErrFrameTooLarge {
+			t.Fatalf("ReadFramedOmitEmptyStruct with a frame larger than maxFrame expected error %v, got %v", runtime.ErrFrameTooLarge, err)
+		}
+	}
+
+	// A frame ending early is unexpected
+	if err := ReadFramedOmitEmptyStruct(bytes.NewReader(frame[:len(frame)-1]), &obj2, uint32(len(data))); err != io.ErrUnexpectedEOF {
+		t.Fatalf("ReadFramedOmitEmptyStruct with a truncated frame expected error %v, got %v", io.ErrUnexpectedEOF, err)
+	}
+
+	// A frame with bytes after the object is rejected
+	padded := make([]byte, runtime.FrameHeaderSize, len(frame)+1)
+	binary.LittleEndian.PutUint32(padded, uint32(len(data)+1))
+	padded = append(append(padded, data...), 0)
+	if err := ReadFramedOmitEmptyStruct(bytes.NewReader(padded), &obj2, uint32(len(data)+1)); err == nil {
+		t.Fatal("ReadFramedOmitEmptyStruct with extra bytes in the frame succeeded")
+	}
+}
+
+func TestSkyencoderOmitEmptyStructFramed(t *testing.T) {
+	rand := mathrand.New(mathrand.NewSource(time.Now().Unix()))
+
+	testSkyencoderOmitEmptyStructFramed(t, newEmptyOmitEmptyStructForEncodeTest())
+
+	for i := 0; i < 10; i++ {
+		testSkyencoderOmitEmptyStructFramed(t, newRandomOmitEmptyStructForEncodeTest(t, rand))
+		testSkyencoderOmitEmptyStructFramed(t, newRandomZeroLenOmitEmptyStructForEncodeTest(t, rand))
+	}
+}
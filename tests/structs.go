@@ -168,3 +168,274 @@ type OmitEmptyMaxLenStruct2 struct {
 	Foo   string
 	Extra []byte `enc:",maxlen=4,omitempty"`
 }
+
+/* since tag tests */
+
+type VersionedStructV1 struct {
+	Foo uint32
+	Bar string
+}
+
+type VersionedStruct struct {
+	Foo   uint32
+	Bar   string
+	Baz   []uint64 `enc:",since=2"`
+	Hash  Hash     `enc:",since=3"`
+	Extra []byte   `enc:",since=3,omitempty"`
+}
+
+/* len tag tests */
+
+type FixedLengthStruct struct {
+	PubKey []byte   `enc:",len=33"`
+	Code   string   `enc:",len=4"`
+	Values []uint16 `enc:",len=3"`
+	Inner  []FixedLengthStructInner
+	Extra  []byte `enc:",omitempty"`
+}
+
+type FixedLengthStructInner struct {
+	Name   string   `enc:",len=2"`
+	Hashes []Hash   `enc:",len=2"`
+	Names  []string `enc:",len=2"`
+}
+
+/* byte order tests */
+
+type BigEndianFieldStruct struct {
+	Uint32  uint32  `enc:",be"`
+	Int16   int16   `enc:",be"`
+	Float64 float64 `enc:",be"`
+	Uint64  uint64
+	Strings []string         `enc:",be"`
+	Map     map[uint16]int64 `enc:",be"`
+	Static  StaticStruct     `enc:",be"`
+	Extra   []byte           `enc:",be,omitempty"`
+}
+
+//skyencoder:byteorder big
+type BigEndianStruct struct {
+	Uint16  uint16
+	Int64   int64
+	Float32 float32
+	Coins   Coins
+	Name    string
+	Dynamic []DynamicStruct
+	Hash    Hash
+}
+
+/* hash tests */
+
+// SignedStruct is hashed without its signatures, like coin.SignedBlock
+type SignedStruct struct {
+	Head  StaticStruct
+	Body  []string
+	Coins Coins             `enc:",be"`
+	Sigs  [][4]byte         `enc:",nohash"`
+	Meta  map[string]string `enc:",nohash"`
+}
+
+/* peek tests */
+
+type PeekStruct struct {
+	Uint8   uint8
+	Strings []string `enc:",maxlen=8"`
+	Map     map[string]DynamicStruct
+	Static  StaticStruct
+	Dynamic DynamicStruct
+	Fixed   string `enc:",len=3"`
+	Arrays  [2]DynamicStruct
+	Uint32  uint32 `enc:",be"`
+	Hashes  []Hash
+	Coins   Coins
+	Inner   PeekStructInner
+	Extra   []byte `enc:",omitempty"`
+}
+
+type PeekStructInner struct {
+	Bytes   []byte
+	Int64   int64
+	Hash    Hash
+	Ignored int64 `enc:"-"`
+	Bool    bool
+}
+
+/* reuse tests */
+
+type ReuseStruct struct {
+	String     string
+	Fixed      string `enc:",len=4"`
+	Bytes      []byte
+	FixedBytes []byte `enc:",len=3"`
+	Statics    []StaticStruct
+	Dynamics   []DynamicStruct
+	Nested     [][]uint16
+	Arrays     [2][]int32
+	Map        map[string][]string
+	Uint64     uint64 `enc:",be"`
+	Extra      []byte `enc:",omitempty"`
+}
+
+/* standalone tests */
+
+type StandaloneStruct struct {
+	Bool    bool
+	Int8    int8
+	Int16   int16 `enc:",be"`
+	Uint32s []uint32
+	Static  StaticStruct
+	Strings []string `enc:",maxlen=4"`
+	Map     map[string]DynamicStruct
+	Float   float64
+	Extra   []byte `enc:",omitempty"`
+}
+
+/* validate tests */
+
+type ValidateStruct struct {
+	Bool      bool
+	Bools     [3]bool
+	Static    StaticStruct
+	Strings   []string `enc:",maxlen=4"`
+	Fixed     string   `enc:",len=3"`
+	FloatMap  map[float64]uint32
+	StringMap map[string][]bool
+	ArrayMap  map[Hash]DynamicStruct `enc:",maxlen=8"`
+	NestedMap map[int32]map[uint16]string
+	Uint64    uint64 `enc:",be"`
+	Extra     []byte `enc:",omitempty"`
+}
+
+/* debug format tests */
+
+// Droplets has a String method, which is not used by FormatDebugStruct
+type Droplets uint64
+
+func (d Droplets) String() string {
+	return "droplets"
+}
+
+type DebugStruct struct {
+	Coins   Droplets
+	Hash    Hash
+	Hashes  []Hash
+	Bytes   []byte
+	Name    string
+	Ignored int64 `enc:"-"`
+	Statics []StaticStruct
+	Map     map[string]int32
+	Float   float32
+	Set     map[int32]struct{}
+	Empties [2]struct{}
+	Extra   []byte `enc:",omitempty"`
+}
+
+/* recursive type tests */
+
+// RecursiveTree contains itself in a slice
+type RecursiveTree struct {
+	Value    uint32
+	Children []RecursiveTree `enc:",maxdepth=64"`
+}
+
+// RecursiveNode and RecursiveDir contain each other, in a map and in a slice
+type RecursiveNode struct {
+	Name string
+	Dirs map[string]RecursiveDir `enc:",maxdepth=64"`
+}
+
+type RecursiveDir struct {
+	Nodes []RecursiveNode `enc:",maxdepth=64"`
+	Mode  uint16
+}
+
+type RecursiveStruct struct {
+	ID      uint64
+	Tree    RecursiveTree
+	Nodes   []RecursiveNode
+	Version uint32
+	Extra   []byte `enc:",omitempty"`
+}
+
+/* union tests */
+
+// Payload is encoded as a union of TxPayload and BlockPayload
+type Payload interface {
+	isPayload()
+}
+
+type TxPayload struct {
+	Amount uint64
+	To     [4]byte
+}
+
+func (TxPayload) isPayload() {}
+
+type BlockPayload struct {
+	Seq    uint64
+	Hashes [][4]byte `enc:",maxlen=8"`
+	Memo   string    `enc:",len=6"`
+}
+
+func (*BlockPayload) isPayload() {}
+
+//skyencoder:union Payload 1=TxPayload 2=*BlockPayload
+type UnionStruct struct {
+	ID       uint32
+	Payload  Payload
+	Payloads []Payload          `enc:",maxlen=4"`
+	ByName   map[string]Payload `enc:",nohash"`
+	Extra    []byte             `enc:",omitempty"`
+}
+
+/* field order tests */
+
+// OrderedStruct is encoded in the order given by its order tags, rather than in declaration order
+type OrderedStruct struct {
+	Name       string       `enc:",order=3"`
+	Extra      []byte       `enc:",order=5,omitempty"`
+	ID         uint64       `enc:",order=1"`
+	Ignored    uint32       `enc:"-"`
+	Inner      OrderedInner `enc:",order=2"`
+	Flags      uint8        `enc:",order=4"`
+	unexported int
+}
+
+type OrderedInner struct {
+	B uint16  `enc:",order=2"`
+	C [4]byte `enc:",order=3"`
+	A uint32  `enc:",order=1"`
+}
+
+/* const tests */
+
+type Magic [4]byte
+
+// ConstStruct starts with a magic header and a version, which must match exactly
+type ConstStruct struct {
+	Magic   Magic  `enc:",const=0x534b5943"`
+	Version uint16 `enc:",const=2"`
+	Offset  int32  `enc:",const=-1"`
+	Entries []ConstEntry
+	Name    string
+}
+
+type ConstEntry struct {
+	Kind  uint8 `enc:",const=0x7f"`
+	Value uint64
+}
+
+/* checksum tests */
+
+//skyencoder:checksum crc32c
+type ChecksumStruct struct {
+	ID     uint32
+	Name   string
+	Values []uint16 `enc:",maxlen=8"`
+	Inner  ChecksumInner
+}
+
+type ChecksumInner struct {
+	Flag  bool
+	Coins uint64
+}
@@ -1,9 +1,18 @@
 package tests
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"math"
+	"reflect"
 	"testing"
 
+	"github.com/skycoin/skycoin/src/cipher"
 	"github.com/skycoin/skycoin/src/cipher/encoder"
+	"github.com/skycoin/skyencoder/runtime"
 )
 
 func TestMaxLenStringStructExceeded(t *testing.T) {
@@ -253,3 +262,749 @@ func TestOmitEmptyMaxLenStructExceeded(t *testing.T) {
 		t.Fatal("DecodeOmitEmptyMaxLenStruct1 expected encoder.ErrMaxLenExceeded")
 	}
 }
+
+func TestVersionedStructCompatibility(t *testing.T) {
+	obj := VersionedStruct{
+		Foo:   1,
+		Bar:   "bar",
+		Baz:   []uint64{2, 3},
+		Hash:  Hash{4, 5, 6},
+		Extra: []byte{7, 8},
+	}
+
+	v1 := VersionedStructV1{
+		Foo: obj.Foo,
+		Bar: obj.Bar,
+	}
+
+	v1Data, err := EncodeVersionedStructV1(&v1)
+	if err != nil {
+		t.Fatalf("EncodeVersionedStructV1 unexpected error: %v", err)
+	}
+
+	// A version 1 encoding matches the encoding of the original struct
+	data, err := EncodeVersionedStructVersion(&obj, 1)
+	if err != nil {
+		t.Fatalf("EncodeVersionedStructVersion unexpected error: %v", err)
+	}
+	if !bytes.Equal(v1Data, data) {
+		t.Fatal("EncodeVersionedStructVersion(1) != EncodeVersionedStructV1")
+	}
+
+	// A new decoder accepts an old payload, leaving newer fields zero
+	obj2 := obj
+	if err := DecodeVersionedStructVersionExact(v1Data, &obj2, 1); err != nil {
+		t.Fatalf("DecodeVersionedStructVersionExact unexpected error: %v", err)
+	}
+	if obj2.Foo != obj.Foo || obj2.Bar != obj.Bar {
+		t.Fatal("DecodeVersionedStructVersionExact decoded wrong version 1 fields")
+	}
+	if obj2.Baz != nil || obj2.Hash != (Hash{}) || obj2.Extra != nil {
+		t.Fatal("DecodeVersionedStructVersionExact did not zero newer fields")
+	}
+
+	// An old decoder ignores newer trailing fields
+	fullData, err := EncodeVersionedStruct(&obj)
+	if err != nil {
+		t.Fatalf("EncodeVersionedStruct unexpected error: %v", err)
+	}
+
+	var v1Decoded VersionedStructV1
+	n, err := DecodeVersionedStructV1(fullData, &v1Decoded)
+	if err != nil {
+		t.Fatalf("DecodeVersionedStructV1 unexpected error: %v", err)
+	}
+	if n != uint64(len(v1Data)) {
+		t.Fatalf("DecodeVersionedStructV1 bytes read length should be %d, is %d", len(v1Data), n)
+	}
+	if v1Decoded != v1 {
+		t.Fatal("DecodeVersionedStructV1 decoded wrong fields")
+	}
+
+	// A version 2 payload lacks the version 3 fields
+	data, err = EncodeVersionedStructVersion(&obj, 2)
+	if err != nil {
+		t.Fatalf("EncodeVersionedStructVersion unexpected error: %v", err)
+	}
+	if uint64(len(data)) != EncodeSizeVersionedStructVersion(&obj, 2) {
+		t.Fatal("uint64(len(data)) != EncodeSizeVersionedStructVersion(&obj, 2)")
+	}
+
+	var obj3 VersionedStruct
+	if err := DecodeVersionedStructVersionExact(data, &obj3, 2); err != nil {
+		t.Fatalf("DecodeVersionedStructVersionExact unexpected error: %v", err)
+	}
+	if len(obj3.Baz) != 2 || obj3.Hash != (Hash{}) || obj3.Extra != nil {
+		t.Fatal("DecodeVersionedStructVersionExact decoded wrong version 2 fields")
+	}
+
+	if err := DecodeVersionedStructVersionExact(data, &obj3, 3); err != encoder.ErrBufferUnderflow {
+		t.Fatalf("DecodeVersionedStructVersionExact expected encoder.ErrBufferUnderflow, got %v", err)
+	}
+}
+
+func TestFixedLengthStruct(t *testing.T) {
+	obj := FixedLengthStruct{
+		PubKey: make([]byte, 33),
+		Code:   "abcd",
+		Values: []uint16{1, 2, 3},
+	}
+
+	data, err := EncodeFixedLengthStruct(&obj)
+	if err != nil {
+		t.Fatalf("EncodeFixedLengthStruct unexpected error: %v", err)
+	}
+
+	// Fixed length fields are encoded without a length prefix
+	if len(data) != 33+4+3*2+4 {
+		t.Fatalf("EncodeFixedLengthStruct encoded length should be %d, is %d", 33+4+3*2+4, len(data))
+	}
+	if !bytes.Equal(data[33:37], []byte("abcd")) {
+		t.Fatal("EncodeFixedLengthStruct encoded string field wrong")
+	}
+
+	var obj2 FixedLengthStruct
+	if err := DecodeFixedLengthStructExact(data, &obj2); err != nil {
+		t.Fatalf("DecodeFixedLengthStructExact unexpected error: %v", err)
+	}
+	if len(obj2.PubKey) != 33 || obj2.Code != obj.Code || len(obj2.Values) != 3 || obj2.Values[2] != 3 {
+		t.Fatal("DecodeFixedLengthStructExact decoded wrong fields")
+	}
+
+	obj.PubKey = make([]byte, 32)
+	if _, err := EncodeFixedLengthStruct(&obj); err == nil {
+		t.Fatal("EncodeFixedLengthStruct expected error for wrong length byte slice")
+	}
+
+	obj.PubKey = make([]byte, 33)
+	obj.Code = "abc"
+	if _, err := EncodeFixedLengthStruct(&obj); err == nil {
+		t.Fatal("EncodeFixedLengthStruct expected error for wrong length string")
+	}
+
+	obj.Code = "abcd"
+	obj.Inner = []FixedLengthStructInner{
+		{
+			Name:   "ab",
+			Hashes: make([]Hash, 2),
+			Names:  []string{"a"},
+		},
+	}
+	if _, err := EncodeFixedLengthStruct(&obj); err == nil {
+		t.Fatal("EncodeFixedLengthStruct expected error for wrong length nested slice")
+	}
+}
+
+func TestBigEndianStruct(t *testing.T) {
+	obj := BigEndianStruct{
+		Uint16: 0x0102,
+		Int64:  -2,
+		Name:   "ab",
+	}
+
+	data, err := EncodeBigEndianStruct(&obj)
+	if err != nil {
+		t.Fatalf("EncodeBigEndianStruct unexpected error: %v", err)
+	}
+
+	expected := []byte{
+		0x01, 0x02, // Uint16
+		0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFF, 0xFE, // Int64
+		0x00, 0x00, 0x00, 0x00, // Float32
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // Coins
+		0x00, 0x00, 0x00, 0x02, 'a', 'b', // Name
+		0x00, 0x00, 0x00, 0x00, // Dynamic
+	}
+	expected = append(expected, make([]byte, len(Hash{}))...)
+
+	if !bytes.Equal(data, expected) {
+		t.Fatalf("EncodeBigEndianStruct encoded bytes wrong\n%x\n%x", data, expected)
+	}
+
+	var obj2 BigEndianStruct
+	if err := DecodeBigEndianStructExact(data, &obj2); err != nil {
+		t.Fatalf("DecodeBigEndianStructExact unexpected error: %v", err)
+	}
+	if obj2.Uint16 != obj.Uint16 || obj2.Int64 != obj.Int64 || obj2.Name != obj.Name {
+		t.Fatal("DecodeBigEndianStructExact decoded wrong fields")
+	}
+}
+
+func TestBigEndianFieldStruct(t *testing.T) {
+	obj := BigEndianFieldStruct{
+		Uint32: 0x01020304,
+		Uint64: 0x05,
+	}
+
+	data, err := EncodeBigEndianFieldStruct(&obj)
+	if err != nil {
+		t.Fatalf("EncodeBigEndianFieldStruct unexpected error: %v", err)
+	}
+
+	// Only the fields tagged with be are big-endian
+	if !bytes.Equal(data[:4], []byte{0x01, 0x02, 0x03, 0x04}) {
+		t.Fatalf("EncodeBigEndianFieldStruct Uint32 field not big-endian: %x", data[:4])
+	}
+	if !bytes.Equal(data[14:22], []byte{0x05, 0, 0, 0, 0, 0, 0, 0}) {
+		t.Fatalf("EncodeBigEndianFieldStruct Uint64 field not little-endian: %x", data[14:22])
+	}
+}
+
+func TestSignedStructHash(t *testing.T) {
+	obj := SignedStruct{
+		Head: StaticStruct{
+			A: 1,
+			B: 2,
+		},
+		Body:  []string{"a"},
+		Coins: 3,
+		Sigs:  [][4]byte{{1, 2, 3, 4}},
+		Meta: map[string]string{
+			"k": "v",
+		},
+	}
+
+	expected := []byte{0x01, 0x02, 0x00, 0x00, 0x00} // Head.A, Head.B
+	expected = append(expected, make([]byte, len(Hash{}))...)
+	expected = append(expected, []byte{
+		0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 'a', // Body
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, // Coins
+	}...)
+
+	if h := HashSignedStruct(&obj); h != cipher.SumSHA256(expected) {
+		t.Fatalf("HashSignedStruct wrong: %s != %s", h.Hex(), cipher.SumSHA256(expected).Hex())
+	}
+
+	// The nohash fields are encoded, after the hashed fields
+	data, err := EncodeSignedStruct(&obj)
+	if err != nil {
+		t.Fatalf("EncodeSignedStruct unexpected error: %v", err)
+	}
+	if !bytes.HasPrefix(data, expected) || len(data) == len(expected) {
+		t.Fatalf("EncodeSignedStruct encoded bytes wrong: %x", data)
+	}
+}
+
+func TestValidateStructInvalid(t *testing.T) {
+	newObj := func() *ValidateStruct {
+		return &ValidateStruct{
+			Fixed: "abc",
+			FloatMap: map[float64]uint32{
+				0: 1,
+				2: 2,
+			},
+			StringMap: map[string][]bool{
+				"a": {true},
+				"b": {false},
+			},
+		}
+	}
+
+	encode := func(obj *ValidateStruct) []byte {
+		data, err := EncodeValidateStruct(obj)
+		if err != nil {
+			t.Fatalf("EncodeValidateStruct unexpected error: %v", err)
+		}
+		return data
+	}
+
+	replace := func(data, old, new []byte) []byte {
+		if bytes.Count(data, old) != 1 {
+			t.Fatalf("Expected a single occurrence of %x in %x", old, data)
+		}
+		return bytes.Replace(data, old, new, 1)
+	}
+
+	cases := []struct {
+		name string
+		data []byte
+		err  error
+	}{
+		{
+			name: "valid",
+			data: encode(newObj()),
+		},
+		{
+			name: "invalid bool",
+			data: append([]byte{2}, encode(newObj())[1:]...),
+			err:  encoder.ErrInvalidBool,
+		},
+		{
+			name: "duplicate string key",
+			data: replace(encode(newObj()), []byte{0x01, 0x00, 0x00, 0x00, 'b'}, []byte{0x01, 0x00, 0x00, 0x00, 'a'}),
+			err:  encoder.ErrMapDuplicateKeys,
+		},
+		{
+			// 0 and -0 are equal map keys
+			name: "duplicate float key",
+			data: replace(encode(newObj()), []byte{0, 0, 0, 0, 0, 0, 0, 0x40}, []byte{0, 0, 0, 0, 0, 0, 0, 0x80}),
+			err:  encoder.ErrMapDuplicateKeys,
+		},
+		{
+			// NaN keys are never equal, so a map can have several of them
+			name: "NaN float keys",
+			data: func() []byte {
+				obj := newObj()
+				obj.FloatMap[math.NaN()] = 3
+				obj.FloatMap[math.NaN()] = 4
+				return encode(obj)
+			}(),
+		},
+		{
+			// Duplicate keys are found without comparing each key to the keys preceding it
+			name: "large map",
+			data: func() []byte {
+				obj := newObj()
+				for i := 0; i < 200000; i++ {
+					obj.FloatMap[float64(i+10)] = uint32(i)
+				}
+				return encode(obj)
+			}(),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var obj ValidateStruct
+			if err := DecodeValidateStructExact(tc.data, &obj); err != tc.err {
+				t.Fatalf("DecodeValidateStructExact expected error %v, got %v", tc.err, err)
+			}
+
+			if err := ValidateValidateStructExact(tc.data); err != tc.err {
+				t.Fatalf("ValidateValidateStructExact expected error %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestReuseStructAllocs(t *testing.T) {
+	obj := ReuseStruct{
+		String:     "foo",
+		Fixed:      "abcd",
+		Bytes:      []byte{1, 2, 3},
+		FixedBytes: []byte{4, 5, 6},
+		Statics:    []StaticStruct{{A: 1}, {B: 2}},
+		Dynamics:   []DynamicStruct{{Foo: []string{"x"}, Bar: 1, Baz: "y"}},
+		Nested:     [][]uint16{{1, 2}, {3}},
+		Arrays:     [2][]int32{{1}, {2, 3}},
+		Map: map[string][]string{
+			"a": nil,
+		},
+		Uint64: 7,
+		Extra:  []byte{8, 9},
+	}
+
+	data, err := EncodeReuseStruct(&obj)
+	if err != nil {
+		t.Fatalf("EncodeReuseStruct unexpected error: %v", err)
+	}
+
+	var reused ReuseStruct
+	if err := DecodeReuseStructReuseExact(data, &reused); err != nil {
+		t.Fatalf("DecodeReuseStructReuseExact unexpected error: %v", err)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		if err := DecodeReuseStructReuseExact(data, &reused); err != nil {
+			t.Fatalf("DecodeReuseStructReuseExact unexpected error: %v", err)
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("DecodeReuseStructReuseExact allocated %v times", allocs)
+	}
+
+	// A shorter object reuses the backing arrays, and the omitted omitempty field is reset
+	bytesArray := &reused.Bytes[0]
+	obj.Bytes = obj.Bytes[:1]
+	obj.Map = nil
+	obj.Extra = nil
+	data, err = EncodeReuseStruct(&obj)
+	if err != nil {
+		t.Fatalf("EncodeReuseStruct unexpected error: %v", err)
+	}
+
+	if err := DecodeReuseStructReuseExact(data, &reused); err != nil {
+		t.Fatalf("DecodeReuseStructReuseExact unexpected error: %v", err)
+	}
+
+	if &reused.Bytes[0] != bytesArray {
+		t.Fatal("DecodeReuseStructReuseExact did not reuse the Bytes backing array")
+	}
+	if len(reused.Bytes) != 1 || len(reused.Map) != 0 || len(reused.Extra) != 0 {
+		t.Fatalf("DecodeReuseStructReuseExact result wrong: %+v", reused)
+	}
+}
+
+func TestDebugStructFormat(t *testing.T) {
+	obj := DebugStruct{
+		Coins:   1e6,
+		Hash:    Hash{0xab, 0xcd},
+		Hashes:  []Hash{{0x01}},
+		Bytes:   []byte{0xff, 0x00},
+		Name:    "foo",
+		Ignored: 9,
+		Statics: []StaticStruct{{A: 1, B: -2}},
+		Map: map[string]int32{
+			"a": 3,
+		},
+		Float: 0.5,
+		Set: map[int32]struct{}{
+			7: {},
+		},
+		Empties: [2]struct{}{},
+	}
+
+	expected := `DebugStruct{Coins:1000000 Hash:abcd000000000000000000000000000000000000 ` +
+		`Hashes:(len=1)[0100000000000000000000000000000000000000] Bytes:(len=2)ff00 Name:(len=3)"foo" ` +
+		`Statics:(len=1)[{A:1 B:-2 Hash:0000000000000000000000000000000000000000}] Map:(len=1)map[(len=1)"a":3] ` +
+		`Float:0.5 Set:(len=1)map[7:{}] Empties:[{} {}] Extra:(omitted)}`
+
+	if s := FormatDebugStruct(&obj); s != expected {
+		t.Fatalf("FormatDebugStruct() = %s, expected %s", s, expected)
+	}
+
+	if s := fmt.Sprintf("%#v", obj); s != expected {
+		t.Fatalf("GoString() = %s, expected %s", s, expected)
+	}
+}
+
+func TestRecursiveStructMaxDepth(t *testing.T) {
+	// newTree returns a tree with a single branch, nested depth levels deep
+	newTree := func(depth int) RecursiveTree {
+		var tree RecursiveTree
+		for i := 1; i < depth; i++ {
+			tree = RecursiveTree{
+				Value:    uint32(i),
+				Children: []RecursiveTree{tree},
+			}
+		}
+		return tree
+	}
+
+	cases := []struct {
+		name  string
+		depth int
+		err   error
+	}{
+		{
+			name:  "at maxdepth",
+			depth: 64,
+		},
+		{
+			name:  "deeper than maxdepth",
+			depth: 65,
+			err:   runtime.ErrMaxDepthExceeded,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			obj := RecursiveStruct{
+				Tree: newTree(tc.depth),
+			}
+
+			// Values deeper than maxdepth are encoded, but not decoded
+			data, err := EncodeRecursiveStruct(&obj)
+			if err != nil {
+				t.Fatalf("EncodeRecursiveStruct unexpected error: %v", err)
+			}
+
+			var obj2 RecursiveStruct
+			if err := DecodeRecursiveStructExact(data, &obj2); err != tc.err {
+				t.Fatalf("DecodeRecursiveStructExact expected error %v, got %v", tc.err, err)
+			}
+
+			if err := ValidateRecursiveStructExact(data); err != tc.err {
+				t.Fatalf("ValidateRecursiveStructExact expected error %v, got %v", tc.err, err)
+			}
+
+			if tc.err == nil && !reflect.DeepEqual(obj, obj2) {
+				t.Fatal("DecodeRecursiveStructExact result wrong")
+			}
+		})
+	}
+}
+
+// unknownPayload implements Payload, but is not in the union of UnionStruct
+type unknownPayload struct{}
+
+func (unknownPayload) isPayload() {}
+
+func TestUnionStruct(t *testing.T) {
+	obj := UnionStruct{
+		ID:      1,
+		Payload: TxPayload{Amount: 2, To: [4]byte{3}},
+		Payloads: []Payload{
+			&BlockPayload{Seq: 4, Memo: "abcdef"},
+			nil,
+		},
+	}
+
+	expected := []byte{
+		1, 0, 0, 0, // ID
+		1, 2, 0, 0, 0, 0, 0, 0, 0, 3, 0, 0, 0, // Payload
+		2, 0, 0, 0, // Payloads length
+		2, 4, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 'a', 'b', 'c', 'd', 'e', 'f', // Payloads[0]
+		0,          // Payloads[1]
+		0, 0, 0, 0, // ByName length
+	}
+
+	data, err := EncodeUnionStruct(&obj)
+	if err != nil {
+		t.Fatalf("EncodeUnionStruct failed: %v", err)
+	}
+	if !bytes.Equal(data, expected) {
+		t.Fatalf("EncodeUnionStruct result wrong: %v", data)
+	}
+
+	var obj2 UnionStruct
+	if err := DecodeUnionStructExact(data, &obj2); err != nil {
+		t.Fatalf("DecodeUnionStructExact failed: %v", err)
+	}
+	if !reflect.DeepEqual(obj, obj2) {
+		t.Fatalf("DecodeUnionStructExact result wrong: %+v", obj2)
+	}
+
+	expectedFormat := `UnionStruct{ID:1 Payload:TxPayload{Amount:2 To:03000000} ` +
+		`Payloads:(len=2)[*BlockPayload{Seq:4 Hashes:(len=0)[] Memo:(len=6)"abcdef"} nil] ByName:(len=0)map[] Extra:(omitted)}`
+	if s := FormatUnionStruct(&obj); s != expectedFormat {
+		t.Fatalf("FormatUnionStruct() = %s, expected %s", s, expectedFormat)
+	}
+}
+
+func TestUnionStructInvalid(t *testing.T) {
+	encodeCases := []struct {
+		name    string
+		payload Payload
+	}{
+		{
+			name:    "type not in union",
+			payload: unknownPayload{},
+		},
+		{
+			name:    "nil pointer",
+			payload: (*BlockPayload)(nil),
+		},
+	}
+
+	for _, tc := range encodeCases {
+		t.Run(tc.name, func(t *testing.T) {
+			obj := UnionStruct{
+				Payload: tc.payload,
+			}
+
+			if _, err := EncodeUnionStruct(&obj); err != runtime.ErrUnknownUnionType {
+				t.Fatalf("EncodeUnionStruct expected error %v, got %v", runtime.ErrUnknownUnionType, err)
+			}
+
+			if err := HashUnionStructToHash(sha256.New(), &obj); err != runtime.ErrUnknownUnionType {
+				t.Fatalf("HashUnionStructToHash expected error %v, got %v", runtime.ErrUnknownUnionType, err)
+			}
+		})
+	}
+
+	t.Run("unknown tag", func(t *testing.T) {
+		data := []byte{
+			1, 0, 0, 0, // ID
+			3, // Payload
+		}
+
+		var obj UnionStruct
+		if err := DecodeUnionStructExact(data, &obj); err != runtime.ErrUnknownUnionTag {
+			t.Fatalf("DecodeUnionStructExact expected error %v, got %v", runtime.ErrUnknownUnionTag, err)
+		}
+
+		if err := ValidateUnionStructExact(data); err != runtime.ErrUnknownUnionTag {
+			t.Fatalf("ValidateUnionStructExact expected error %v, got %v", runtime.ErrUnknownUnionTag, err)
+		}
+	})
+}
+
+func TestOrderedStruct(t *testing.T) {
+	obj := OrderedStruct{
+		Name:    "ab",
+		ID:      1,
+		Ignored: 2,
+		Inner: OrderedInner{
+			B: 3,
+			C: [4]byte{4, 5, 6, 7},
+			A: 8,
+		},
+		Flags: 9,
+		Extra: []byte{10},
+	}
+
+	expected := []byte{
+		1, 0, 0, 0, 0, 0, 0, 0, // ID
+		8, 0, 0, 0, // Inner.A
+		3, 0, // Inner.B
+		4, 5, 6, 7, // Inner.C
+		2, 0, 0, 0, 'a', 'b', // Name
+		9,          // Flags
+		1, 0, 0, 0, // Extra length
+		10, // Extra
+	}
+
+	data, err := EncodeOrderedStruct(&obj)
+	if err != nil {
+		t.Fatalf("EncodeOrderedStruct failed: %v", err)
+	}
+	if !bytes.Equal(data, expected) {
+		t.Fatalf("EncodeOrderedStruct result wrong: %v", data)
+	}
+
+	var obj2 OrderedStruct
+	if err := DecodeOrderedStructExact(data, &obj2); err != nil {
+		t.Fatalf("DecodeOrderedStructExact failed: %v", err)
+	}
+	obj.Ignored = 0
+	if !reflect.DeepEqual(obj, obj2) {
+		t.Fatalf("DecodeOrderedStructExact result wrong: %+v", obj2)
+	}
+
+	if n, err := PeekOrderedStructInnerB(data); err != nil {
+		t.Fatalf("PeekOrderedStructInnerB failed: %v", err)
+	} else if n != 3 {
+		t.Fatalf("PeekOrderedStructInnerB = %d, expected 3", n)
+	}
+
+	if n, err := PeekOrderedStructFlags(data); err != nil {
+		t.Fatalf("PeekOrderedStructFlags failed: %v", err)
+	} else if n != 9 {
+		t.Fatalf("PeekOrderedStructFlags = %d, expected 9", n)
+	}
+}
+
+func TestConstStruct(t *testing.T) {
+	expected := []byte{
+		'S', 'K', 'Y', 'C', // Magic
+		2, 0, // Version
+		0xff, 0xff, 0xff, 0xff, // Offset
+		1, 0, 0, 0, // Entries length
+		0x7f,                   // Entries[0].Kind
+		3, 0, 0, 0, 0, 0, 0, 0, // Entries[0].Value
+		0, 0, 0, 0, // Name
+	}
+
+	// Constants are written for zero values
+	obj := ConstStruct{
+		Entries: []ConstEntry{{Value: 3}},
+	}
+
+	data, err := EncodeConstStruct(&obj)
+	if err != nil {
+		t.Fatalf("EncodeConstStruct failed: %v", err)
+	}
+	if !bytes.Equal(data, expected) {
+		t.Fatalf("EncodeConstStruct result wrong: %v", data)
+	}
+
+	var obj2 ConstStruct
+	if err := DecodeConstStructExact(data, &obj2); err != nil {
+		t.Fatalf("DecodeConstStructExact failed: %v", err)
+	}
+	expectedObj := ConstStruct{
+		Magic:   Magic{'S', 'K', 'Y', 'C'},
+		Version: 2,
+		Offset:  -1,
+		Entries: []ConstEntry{{Kind: 0x7f, Value: 3}},
+	}
+	if !reflect.DeepEqual(obj2, expectedObj) {
+		t.Fatalf("DecodeConstStructExact result wrong: %+v", obj2)
+	}
+
+	if data2, err := EncodeConstStruct(&obj2); err != nil {
+		t.Fatalf("EncodeConstStruct failed: %v", err)
+	} else if !bytes.Equal(data2, expected) {
+		t.Fatalf("EncodeConstStruct result wrong: %v", data2)
+	}
+
+	// Values other than the constant can't be encoded
+	obj2.Entries[0].Kind = 1
+	if _, err := EncodeConstStruct(&obj2); err != runtime.ErrConstMismatch {
+		t.Fatalf("EncodeConstStruct expected error %v, got %v", runtime.ErrConstMismatch, err)
+	}
+
+	// Encodings of values other than the constant can't be decoded or validated
+	for _, i := range []int{0, 3, 4, 9, 14} {
+		bad := append([]byte{}, expected...)
+		bad[i]++
+
+		var obj3 ConstStruct
+		if err := DecodeConstStructExact(bad, &obj3); err != runtime.ErrConstMismatch {
+			t.Fatalf("DecodeConstStructExact byte %d expected error %v, got %v", i, runtime.ErrConstMismatch, err)
+		}
+		if err := ValidateConstStructExact(bad); err != runtime.ErrConstMismatch {
+			t.Fatalf("ValidateConstStructExact byte %d expected error %v, got %v", i, runtime.ErrConstMismatch, err)
+		}
+	}
+
+	if v, err := PeekConstStructVersion(expected); err != nil {
+		t.Fatalf("PeekConstStructVersion failed: %v", err)
+	} else if v != 2 {
+		t.Fatalf("PeekConstStructVersion = %d, expected 2", v)
+	}
+}
+
+func TestChecksumStruct(t *testing.T) {
+	obj := ChecksumStruct{
+		ID:     7,
+		Name:   "abc",
+		Values: []uint16{1, 2},
+		Inner: ChecksumInner{
+			Flag:  true,
+			Coins: 100,
+		},
+	}
+
+	fields := []byte{
+		7, 0, 0, 0, // ID
+		3, 0, 0, 0, 'a', 'b', 'c', // Name
+		2, 0, 0, 0, 1, 0, 2, 0, // Values
+		1,                        // Inner.Flag
+		100, 0, 0, 0, 0, 0, 0, 0, // Inner.Coins
+	}
+	sum := make([]byte, 4)
+	binary.LittleEndian.PutUint32(sum, crc32.Checksum(fields, crc32.MakeTable(crc32.Castagnoli)))
+	expected := append(append([]byte{}, fields...), sum...)
+
+	if n := EncodeSizeChecksumStruct(&obj); n != uint64(len(expected)) {
+		t.Fatalf("EncodeSizeChecksumStruct = %d, expected %d", n, len(expected))
+	}
+
+	data, err := EncodeChecksumStruct(&obj)
+	if err != nil {
+		t.Fatalf("EncodeChecksumStruct failed: %v", err)
+	}
+	if !bytes.Equal(data, expected) {
+		t.Fatalf("EncodeChecksumStruct result wrong: %v", data)
+	}
+
+	var obj2 ChecksumStruct
+	if err := DecodeChecksumStructExact(data, &obj2); err != nil {
+		t.Fatalf("DecodeChecksumStructExact failed: %v", err)
+	}
+	if !reflect.DeepEqual(obj2, obj) {
+		t.Fatalf("DecodeChecksumStructExact result wrong: %+v", obj2)
+	}
+
+	// Corrupted fields and checksums are detected
+	for _, i := range []int{0, 3, 8, 10, 15, 19, 20, 27, 28, 31} {
+		bad := append([]byte{}, expected...)
+		bad[i] ^= 0x01
+
+		var obj3 ChecksumStruct
+		if err := DecodeChecksumStructExact(bad, &obj3); err != runtime.ErrChecksumMismatch {
+			t.Fatalf("DecodeChecksumStructExact byte %d expected error %v, got %v", i, runtime.ErrChecksumMismatch, err)
+		}
+		if err := ValidateChecksumStructExact(bad); err != runtime.ErrChecksumMismatch {
+			t.Fatalf("ValidateChecksumStructExact byte %d expected error %v, got %v", i, runtime.ErrChecksumMismatch, err)
+		}
+	}
+
+	// A missing checksum is a short buffer
+	var obj3 ChecksumStruct
+	if err := DecodeChecksumStructExact(fields, &obj3); err != encoder.ErrBufferUnderflow {
+		t.Fatalf("DecodeChecksumStructExact expected error %v, got %v", encoder.ErrBufferUnderflow, err)
+	}
+}
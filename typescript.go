@@ -0,0 +1,1081 @@
+package skyencoder
+
+import (
+	"errors"
+	"fmt"
+	"go/types"
+	"strings"
+)
+
+// BuildStructTypeScript builds a TypeScript module with interfaces for a type and the types it references,
+// and functions for encoding and decoding it following the same rules as the Go code generated by BuildStructEncoder.
+// The module requires ES2020 (for bigint) and the TextEncoder and TextDecoder globals.
+func BuildStructTypeScript(s *StructInfo) ([]byte, error) {
+	options, err := parseDirectives(s.Directives)
+	if err != nil {
+		return nil, err
+	}
+
+	if options != nil && options.Checksum != "" {
+		return nil, errors.New("The checksum directive is not supported in TypeScript output")
+	}
+
+	// The TypeScript encoder inlines all types, which recursive types can't be
+	if rts, err := recursiveTypes(s.Type); err != nil {
+		return nil, err
+	} else if len(rts) != 0 {
+		return nil, fmt.Errorf("Recursive type %s is not supported in TypeScript output", rts[0])
+	}
+
+	if uts, err := unionTypes(s.Type); err != nil {
+		return nil, err
+	} else if len(uts) != 0 {
+		return nil, fmt.Errorf("Interface type %s is not supported in TypeScript output", uts[0])
+	}
+
+	decls, err := buildTSDeclarations(s)
+	if err != nil {
+		return nil, fmt.Errorf("buildTSDeclarations failed: %v", err)
+	}
+
+	encodeSection, err := buildTSEncode(s.Type, "obj", 0, options)
+	if err != nil {
+		return nil, fmt.Errorf("buildTSEncode failed: %v", err)
+	}
+
+	decodeSection, err := buildTSDecode(s.Type, "obj", s.Name, 0, options)
+	if err != nil {
+		return nil, fmt.Errorf("buildTSDecode failed: %v", err)
+	}
+
+	toJSONExpr, err := buildTSToJSON(s.Type, "obj", 0)
+	if err != nil {
+		return nil, fmt.Errorf("buildTSToJSON failed: %v", err)
+	}
+
+	return []byte(wrapTSModule(s.Name, decls, encodeSection, decodeSection, toJSONExpr)), nil
+}
+
+// BuildStructTypeScriptTest builds a TypeScript script which checks the module generated by BuildStructTypeScript
+// against the golden vectors generated by BuildStructVectors. `moduleName` is the import path of the module
+// relative to the script and `vectorsFilename` is the vectors file path relative to the script.
+func BuildStructTypeScriptTest(s *StructInfo, moduleName, vectorsFilename string) []byte {
+	return []byte(wrapTSTest(s.Name, moduleName, vectorsFilename))
+}
+
+// tsField is an encodable field of a struct
+type tsField struct {
+	Name    string
+	Type    types.Type
+	Options *Options
+}
+
+func tsFields(t *types.Struct) ([]tsField, error) {
+	order, err := encodedFields(t)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []tsField
+	for j, i := range order {
+		f := t.Field(i)
+
+		if !f.Exported() {
+			continue
+		}
+
+		ignore, options, err := parseTag(t.Tag(i))
+		if err != nil {
+			return nil, err
+		}
+
+		if ignore {
+			continue
+		}
+
+		if options != nil && options.OmitEmpty && j != len(order)-1 {
+			return nil, errors.New("omitempty option can only be used on the last field in a struct")
+		}
+
+		if options != nil && options.Const != "" {
+			return nil, fmt.Errorf("Field %s has a const option, which is not supported in TypeScript output", f.Name())
+		}
+
+		fields = append(fields, tsField{
+			Name:    f.Name(),
+			Type:    f.Type(),
+			Options: options,
+		})
+	}
+
+	return fields, nil
+}
+
+// buildTSDeclarations returns the interface declaration of the struct and the declarations
+// of the named types it references
+func buildTSDeclarations(s *StructInfo) (string, error) {
+	var named []*types.Named
+	seen := map[string]types.Type{}
+	var collect func(t types.Type) error
+	collect = func(t types.Type) error {
+		switch x := t.(type) {
+		case *types.Named:
+			name := x.Obj().Name()
+			if prev, ok := seen[name]; ok {
+				if !types.Identical(prev, x) {
+					return fmt.Errorf("TypeScript output has conflicting declarations of type %s", name)
+				}
+				return nil
+			}
+			seen[name] = x
+			named = append(named, x)
+			return collect(x.Underlying())
+		case *types.Array:
+			return collect(x.Elem())
+		case *types.Slice:
+			return collect(x.Elem())
+		case *types.Map:
+			if err := collect(x.Key()); err != nil {
+				return err
+			}
+			return collect(x.Elem())
+		case *types.Struct:
+			fields, err := tsFields(x)
+			if err != nil {
+				return err
+			}
+			for _, f := range fields {
+				if err := collect(f.Type); err != nil {
+					return err
+				}
+			}
+			return nil
+		default:
+			return nil
+		}
+	}
+
+	seen[s.Name] = s.Type
+	if err := collect(s.Type); err != nil {
+		return "", err
+	}
+
+	structDecl, err := buildTSInterface(s.Name, s.Type)
+	if err != nil {
+		return "", err
+	}
+
+	decls := []string{structDecl}
+	for _, x := range named {
+		var decl string
+		switch y := x.Underlying().(type) {
+		case *types.Struct:
+			decl, err = buildTSInterface(x.Obj().Name(), y)
+		default:
+			var typ string
+			typ, err = tsTypeName(y)
+			decl = fmt.Sprintf("export type %s = %s;\n", x.Obj().Name(), typ)
+		}
+		if err != nil {
+			return "", err
+		}
+
+		decls = append(decls, decl)
+	}
+
+	return strings.Join(decls, "\n"), nil
+}
+
+func buildTSInterface(name string, t *types.Struct) (string, error) {
+	fields, err := tsFields(t)
+	if err != nil {
+		return "", err
+	}
+
+	lines := make([]string, len(fields))
+	for i, f := range fields {
+		typ, err := tsTypeName(f.Type)
+		if err != nil {
+			return "", err
+		}
+		lines[i] = fmt.Sprintf("  %s: %s;\n", f.Name, typ)
+	}
+
+	return fmt.Sprintf("export interface %s {\n%s}\n", name, strings.Join(lines, "")), nil
+}
+
+// tsTypeName returns the TypeScript type of a Go type.
+// 64-bit integers are bigint, byte arrays and slices are Uint8Array and maps are Map
+func tsTypeName(t types.Type) (string, error) {
+	switch x := t.(type) {
+	case *types.Named:
+		return x.Obj().Name(), nil
+
+	case *types.Basic:
+		switch x.Kind() {
+		case types.Bool:
+			return "boolean", nil
+		case types.Int8, types.Int16, types.Int32, types.Uint8, types.Uint16, types.Uint32, types.Float32, types.Float64:
+			return "number", nil
+		case types.Int64, types.Uint64:
+			return "bigint", nil
+		case types.String:
+			return "string", nil
+		default:
+			return "", fmt.Errorf("Unhandled *types.Basic type %s", x.Name())
+		}
+
+	case *types.Array:
+		if isByte(x.Elem()) {
+			return "Uint8Array", nil
+		}
+		elem, err := tsTypeName(x.Elem())
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Array<%s>", elem), nil
+
+	case *types.Slice:
+		if isByte(x.Elem()) {
+			return "Uint8Array", nil
+		}
+		elem, err := tsTypeName(x.Elem())
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Array<%s>", elem), nil
+
+	case *types.Map:
+		key, err := tsTypeName(x.Key())
+		if err != nil {
+			return "", err
+		}
+		elem, err := tsTypeName(x.Elem())
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("Map<%s, %s>", key, elem), nil
+
+	case *types.Struct:
+		fields, err := tsFields(x)
+		if err != nil {
+			return "", err
+		}
+		parts := make([]string, len(fields))
+		for i, f := range fields {
+			typ, err := tsTypeName(f.Type)
+			if err != nil {
+				return "", err
+			}
+			parts[i] = fmt.Sprintf("%s: %s", f.Name, typ)
+		}
+		return fmt.Sprintf("{ %s }", strings.Join(parts, "; ")), nil
+
+	default:
+		return "", fmt.Errorf("Unhandled type %T", x)
+	}
+}
+
+// tsZeroValue returns the TypeScript expression for the empty value of a type which can be tagged omitempty
+func tsZeroValue(t types.Type) string {
+	switch x := t.(type) {
+	case *types.Named:
+		return tsZeroValue(x.Underlying())
+	case *types.Basic:
+		return `""`
+	case *types.Slice:
+		if isByte(x.Elem()) {
+			return "new Uint8Array(0)"
+		}
+		return "[]"
+	case *types.Map:
+		return "new Map()"
+	default:
+		panic(fmt.Sprintf("tsZeroValue unhandled type %T", x))
+	}
+}
+
+// tsMethods are the Encoder and Decoder methods of the TypeScript runtime for each basic type
+var tsMethods = map[types.BasicKind]string{
+	types.Bool:    "bool",
+	types.Int8:    "int8",
+	types.Int16:   "int16",
+	types.Int32:   "int32",
+	types.Int64:   "int64",
+	types.Uint8:   "uint8",
+	types.Uint16:  "uint16",
+	types.Uint32:  "uint32",
+	types.Uint64:  "uint64",
+	types.Float32: "float32",
+	types.Float64: "float64",
+}
+
+func tsByteOrder(options *Options) string {
+	if options != nil && options.BigEndian {
+		return "true"
+	}
+	return ""
+}
+
+/* TypeScript encode */
+
+func buildTSEncode(t types.Type, name string, depth int, options *Options) (string, error) {
+	if options != nil {
+		if options.OmitEmpty && !omitEmptyIsValid(t) {
+			return "", errors.New("omitempty is only valid for array, slice, map and string")
+		}
+		if options.MaxLength != 0 && !maxLenIsValid(t) {
+			return "", errors.New("maxlen is only valid for slice, string and map")
+		}
+		if options.Length != 0 && !lenIsValid(t) {
+			return "", errors.New("len is only valid for slice and string")
+		}
+	}
+
+	be := tsByteOrder(options)
+
+	switch x := t.(type) {
+	case *types.Named:
+		return buildTSEncode(x.Underlying(), name, depth, options)
+
+	case *types.Basic:
+		switch x.Kind() {
+		case types.Bool:
+			return fmt.Sprintf("e.bool(%s);\n", name), nil
+		case types.Int8:
+			return fmt.Sprintf("e.int8(%s);\n", name), nil
+		case types.Uint8:
+			return fmt.Sprintf("e.uint8(%s);\n", name), nil
+		case types.Int16, types.Int32, types.Int64, types.Uint16, types.Uint32, types.Uint64, types.Float32, types.Float64:
+			return fmt.Sprintf("e.%s(%s%s);\n", tsMethods[x.Kind()], name, tsByteOrderArg(be)), nil
+		case types.String:
+			return wrapTSEncodeOmitEmpty(name, "length", buildTSEncodeBytes(name, fmt.Sprintf("utf8Encode(%s)", name), be, options), options), nil
+		default:
+			return "", fmt.Errorf("Unhandled *types.Basic type %s for var %s", x.Name(), name)
+		}
+
+	case *types.Array:
+		body := fmt.Sprintf(`if (%[1]s.length !== %[2]d) {
+  throw new Error('%[1]s length must be %[2]d');
+}
+`, name, x.Len())
+
+		if isByte(x.Elem()) {
+			body += fmt.Sprintf("e.bytes(%s);\n", name)
+		} else {
+			elemVarName := fmt.Sprintf("x%d", depth+1)
+			elemSection, err := buildTSEncode(x.Elem(), elemVarName, depth+1, inheritOptions(options, nil))
+			if err != nil {
+				return "", err
+			}
+			body += buildTSEncodeLoop(name, elemVarName, elemSection)
+		}
+
+		return wrapTSEncodeOmitEmpty(name, "length", body, options), nil
+
+	case *types.Slice:
+		if empty, err := isEmptyStruct(x.Elem()); err != nil {
+			return "", err
+		} else if empty {
+			return "", fmt.Errorf("A slice of an empty encoded struct is not allowed (var=%q)", name)
+		}
+
+		if isByte(x.Elem()) {
+			return wrapTSEncodeOmitEmpty(name, "length", buildTSEncodeBytes(name, name, be, options), options), nil
+		}
+
+		elemVarName := fmt.Sprintf("x%d", depth+1)
+		elemSection, err := buildTSEncode(x.Elem(), elemVarName, depth+1, inheritOptions(options, nil))
+		if err != nil {
+			return "", err
+		}
+
+		body := buildTSEncodeLength(name, "length", be, options) + buildTSEncodeLoop(name, elemVarName, elemSection)
+		return wrapTSEncodeOmitEmpty(name, "length", body, options), nil
+
+	case *types.Map:
+		keyVarName := fmt.Sprintf("k%d", depth+1)
+		elemVarName := fmt.Sprintf("v%d", depth+1)
+
+		keySection, err := buildTSEncode(x.Key(), keyVarName, depth+1, inheritOptions(options, nil))
+		if err != nil {
+			return "", err
+		}
+
+		elemSection, err := buildTSEncode(x.Elem(), elemVarName, depth+1, inheritOptions(options, nil))
+		if err != nil {
+			return "", err
+		}
+
+		body := buildTSEncodeLength(name, "size", be, options) + fmt.Sprintf(`for (const [%[2]s, %[3]s] of %[1]s) {
+%[4]s
+%[5]s}
+`, name, keyVarName, elemVarName, indentTS(keySection), indentTS(elemSection))
+
+		return wrapTSEncodeOmitEmpty(name, "size", body, options), nil
+
+	case *types.Struct:
+		fields, err := tsFields(x)
+		if err != nil {
+			return "", err
+		}
+
+		sections := make([]string, len(fields))
+		for i, f := range fields {
+			section, err := buildTSEncode(f.Type, fmt.Sprintf("%s.%s", name, f.Name), depth, inheritOptions(options, f.Options))
+			if err != nil {
+				return "", err
+			}
+			sections[i] = fmt.Sprintf("// %s.%s\n%s", name, f.Name, section)
+		}
+
+		return strings.Join(sections, "\n"), nil
+
+	default:
+		return "", fmt.Errorf("Unhandled type %T for var %s", x, name)
+	}
+}
+
+func tsByteOrderArg(be string) string {
+	if be == "" {
+		return ""
+	}
+	return ", " + be
+}
+
+// buildTSEncodeBytes encodes a string or byte slice, given an expression of its bytes
+func buildTSEncodeBytes(name, bytesExpr, be string, options *Options) string {
+	if options != nil && options.Length > 0 {
+		return fmt.Sprintf(`{
+  const b = %[2]s;
+  if (b.length !== %[3]d) {
+    throw new Error('%[1]s length must be %[3]d');
+  }
+  e.bytes(b);
+}
+`, name, bytesExpr, options.Length)
+	}
+
+	maxLenCheck := ""
+	if options != nil && options.MaxLength > 0 {
+		maxLenCheck = fmt.Sprintf(`
+  if (b.length > %d) {
+    throw ErrMaxLenExceeded;
+  }`, options.MaxLength)
+	}
+
+	return fmt.Sprintf(`{
+  const b = %[1]s;%[2]s
+  e.uint32(b.length%[3]s);
+  e.bytes(b);
+}
+`, bytesExpr, maxLenCheck, tsByteOrderArg(be))
+}
+
+// buildTSEncodeLength encodes the length prefix of a slice or map, or checks the length of a fixed length slice
+func buildTSEncodeLength(name, lengthField, be string, options *Options) string {
+	if options != nil && options.Length > 0 {
+		return fmt.Sprintf(`if (%[1]s.%[2]s !== %[3]d) {
+  throw new Error('%[1]s length must be %[3]d');
+}
+`, name, lengthField, options.Length)
+	}
+
+	maxLenCheck := ""
+	if options != nil && options.MaxLength > 0 {
+		maxLenCheck = fmt.Sprintf(`if (%s.%s > %d) {
+  throw ErrMaxLenExceeded;
+}
+`, name, lengthField, options.MaxLength)
+	}
+
+	return fmt.Sprintf("%se.uint32(%s.%s%s);\n", maxLenCheck, name, lengthField, tsByteOrderArg(be))
+}
+
+func buildTSEncodeLoop(name, elemVarName, elemSection string) string {
+	return fmt.Sprintf(`for (const %[2]s of %[1]s) {
+%[3]s}
+`, name, elemVarName, indentTS(elemSection))
+}
+
+func wrapTSEncodeOmitEmpty(name, lengthField, body string, options *Options) string {
+	if options != nil && options.OmitEmpty {
+		return fmt.Sprintf(`// omitempty
+if (%s.%s !== 0) {
+%s}
+`, name, lengthField, indentTS(body))
+	}
+
+	return body
+}
+
+/* TypeScript decode */
+
+// buildTSDecode returns statements which decode a value of type t into the lvalue target.
+// typeName is the TypeScript type of target, if t is a struct
+func buildTSDecode(t types.Type, target, typeName string, depth int, options *Options) (string, error) {
+	be := tsByteOrder(options)
+
+	switch x := t.(type) {
+	case *types.Named:
+		return buildTSDecode(x.Underlying(), target, x.Obj().Name(), depth, options)
+
+	case *types.Basic:
+		switch x.Kind() {
+		case types.Bool, types.Int8, types.Uint8:
+			return fmt.Sprintf("%s = d.%s();\n", target, tsMethods[x.Kind()]), nil
+		case types.Int16, types.Int32, types.Int64, types.Uint16, types.Uint32, types.Uint64, types.Float32, types.Float64:
+			return fmt.Sprintf("%s = d.%s(%s);\n", target, tsMethods[x.Kind()], be), nil
+		case types.String:
+			if options != nil && options.Length > 0 {
+				return fmt.Sprintf("%s = utf8Decode(d.bytes(%d));\n", target, options.Length), nil
+			}
+			return wrapTSDecodeOmitEmpty(target, t, fmt.Sprintf(`{
+%[2]s  %[1]s = utf8Decode(d.bytes(length));
+}
+`, target, buildTSDecodeLength(be, options)), options), nil
+		default:
+			return "", fmt.Errorf("Unhandled *types.Basic type %s for var %s", x.Name(), target)
+		}
+
+	case *types.Array:
+		if isByte(x.Elem()) {
+			return fmt.Sprintf("%s = d.bytes(%d);\n", target, x.Len()), nil
+		}
+
+		return buildTSDecodeArray(x.Elem(), target, fmt.Sprintf("%d", x.Len()), "", depth, options)
+
+	case *types.Slice:
+		if isByte(x.Elem()) {
+			if options != nil && options.Length > 0 {
+				return fmt.Sprintf("%s = d.bytes(%d);\n", target, options.Length), nil
+			}
+			return wrapTSDecodeOmitEmpty(target, t, fmt.Sprintf(`{
+%[2]s  %[1]s = d.bytes(length);
+}
+`, target, buildTSDecodeLength(be, options)), options), nil
+		}
+
+		if options != nil && options.Length > 0 {
+			return buildTSDecodeArray(x.Elem(), target, fmt.Sprintf("%d", options.Length), "", depth, options)
+		}
+
+		section, err := buildTSDecodeArray(x.Elem(), target, "length", buildTSDecodeLength(be, options), depth, options)
+		if err != nil {
+			return "", err
+		}
+		return wrapTSDecodeOmitEmpty(target, t, section, options), nil
+
+	case *types.Map:
+		keyType, err := tsTypeName(x.Key())
+		if err != nil {
+			return "", err
+		}
+
+		elemType, err := tsTypeName(x.Elem())
+		if err != nil {
+			return "", err
+		}
+
+		keyVarName := fmt.Sprintf("k%d", depth+1)
+		elemVarName := fmt.Sprintf("v%d", depth+1)
+
+		keySection, err := buildTSDecode(x.Key(), keyVarName, keyType, depth+1, inheritOptions(options, nil))
+		if err != nil {
+			return "", err
+		}
+
+		elemSection, err := buildTSDecode(x.Elem(), elemVarName, elemType, depth+1, inheritOptions(options, nil))
+		if err != nil {
+			return "", err
+		}
+
+		return wrapTSDecodeOmitEmpty(target, t, fmt.Sprintf(`{
+%[9]s  const m%[2]d = new Map<%[3]s, %[4]s>();
+  const seen%[2]d = new Set<string>();
+  for (let i%[2]d = 0; i%[2]d < length; i%[2]d++) {
+    const start%[2]d = d.offset;
+    let %[5]s: %[3]s;
+%[7]s
+    const key%[2]d = d.consumedHex(start%[2]d);
+    if (seen%[2]d.has(key%[2]d)) {
+      throw ErrMapDuplicateKeys;
+    }
+    seen%[2]d.add(key%[2]d);
+
+    let %[6]s: %[4]s;
+%[8]s
+    m%[2]d.set(%[5]s, %[6]s);
+  }
+  %[1]s = m%[2]d;
+}
+`, target, depth+1, keyType, elemType, keyVarName, elemVarName, indentTS(indentTS(keySection)), indentTS(indentTS(elemSection)), buildTSDecodeLength(be, options)), options), nil
+
+	case *types.Struct:
+		if typeName == "" {
+			var err error
+			typeName, err = tsTypeName(x)
+			if err != nil {
+				return "", err
+			}
+		}
+
+		fields, err := tsFields(x)
+		if err != nil {
+			return "", err
+		}
+
+		sections := make([]string, len(fields)+1)
+		sections[0] = fmt.Sprintf("%s = {} as %s;\n", target, typeName)
+		for i, f := range fields {
+			section, err := buildTSDecode(f.Type, fmt.Sprintf("%s.%s", target, f.Name), "", depth, inheritOptions(options, f.Options))
+			if err != nil {
+				return "", err
+			}
+			sections[i+1] = fmt.Sprintf("// %s.%s\n%s", target, f.Name, section)
+		}
+
+		return strings.Join(sections, "\n"), nil
+
+	default:
+		return "", fmt.Errorf("Unhandled type %T for var %s", x, target)
+	}
+}
+
+// buildTSDecodeLength reads a length prefix into a "length" variable, checking it against maxlen
+func buildTSDecodeLength(be string, options *Options) string {
+	maxLenCheck := ""
+	if options != nil && options.MaxLength > 0 {
+		maxLenCheck = fmt.Sprintf(`  if (length > %d) {
+    throw ErrMaxLenExceeded;
+  }
+`, options.MaxLength)
+	}
+
+	return fmt.Sprintf("  const length = d.length(%s);\n%s", be, maxLenCheck)
+}
+
+// buildTSDecodeArray decodes lengthExpr elements into an array
+func buildTSDecodeArray(elem types.Type, target, lengthExpr, lengthSection string, depth int, options *Options) (string, error) {
+	elemType, err := tsTypeName(elem)
+	if err != nil {
+		return "", err
+	}
+
+	elemVarName := fmt.Sprintf("x%d", depth+1)
+	elemSection, err := buildTSDecode(elem, elemVarName, elemType, depth+1, inheritOptions(options, nil))
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(`{
+%[7]s  const a%[2]d: Array<%[3]s> = [];
+  for (let i%[2]d = 0; i%[2]d < %[6]s; i%[2]d++) {
+    let %[4]s: %[3]s;
+%[5]s
+    a%[2]d.push(%[4]s);
+  }
+  %[1]s = a%[2]d;
+}
+`, target, depth+1, elemType, elemVarName, indentTS(indentTS(elemSection)), lengthExpr, lengthSection), nil
+}
+
+func wrapTSDecodeOmitEmpty(target string, t types.Type, body string, options *Options) string {
+	if options != nil && options.OmitEmpty {
+		return fmt.Sprintf(`// omitempty
+%[1]s = %[2]s;
+if (d.remaining() !== 0) {
+%[3]s}
+`, target, tsZeroValue(t), indentTS(body))
+	}
+
+	return body
+}
+
+/* TypeScript canonical JSON */
+
+// buildTSToJSON returns an expression converting a value to the canonical JSON form used by the golden vectors
+func buildTSToJSON(t types.Type, expr string, depth int) (string, error) {
+	switch x := t.(type) {
+	case *types.Named:
+		return buildTSToJSON(x.Underlying(), expr, depth)
+
+	case *types.Basic:
+		switch x.Kind() {
+		case types.Int64, types.Uint64:
+			return fmt.Sprintf("%s.toString()", expr), nil
+		default:
+			return expr, nil
+		}
+
+	case *types.Array:
+		return buildTSToJSONArray(x.Elem(), expr, depth)
+
+	case *types.Slice:
+		return buildTSToJSONArray(x.Elem(), expr, depth)
+
+	case *types.Map:
+		keyVarName := fmt.Sprintf("k%d", depth+1)
+		elemVarName := fmt.Sprintf("v%d", depth+1)
+
+		key, err := buildTSToJSON(x.Key(), keyVarName, depth+1)
+		if err != nil {
+			return "", err
+		}
+
+		elem, err := buildTSToJSON(x.Elem(), elemVarName, depth+1)
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("Array.from(%s, ([%s, %s]) => ({ key: %s, value: %s }))", expr, keyVarName, elemVarName, key, elem), nil
+
+	case *types.Struct:
+		fields, err := tsFields(x)
+		if err != nil {
+			return "", err
+		}
+
+		parts := make([]string, len(fields))
+		for i, f := range fields {
+			value, err := buildTSToJSON(f.Type, fmt.Sprintf("%s.%s", expr, f.Name), depth)
+			if err != nil {
+				return "", err
+			}
+			parts[i] = fmt.Sprintf("%s: %s", f.Name, value)
+		}
+
+		return fmt.Sprintf("{ %s }", strings.Join(parts, ", ")), nil
+
+	default:
+		return "", fmt.Errorf("Unhandled type %T for var %s", x, expr)
+	}
+}
+
+func buildTSToJSONArray(elem types.Type, expr string, depth int) (string, error) {
+	if isByte(elem) {
+		return fmt.Sprintf("bytesToHex(%s)", expr), nil
+	}
+
+	elemVarName := fmt.Sprintf("x%d", depth+1)
+	value, err := buildTSToJSON(elem, elemVarName, depth+1)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s.map((%s) => (%s))", expr, elemVarName, value), nil
+}
+
+// indentTS indents each non-empty line of TypeScript code by two spaces
+func indentTS(code string) string {
+	lines := strings.Split(code, "\n")
+	for i, l := range lines {
+		if l != "" {
+			lines[i] = "  " + l
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+/* TypeScript templates */
+
+func wrapTSModule(typeName, decls, encodeSection, decodeSection, toJSONExpr string) string {
+	return fmt.Sprintf(`// Code generated by github.com/skycoin/skyencoder. DO NOT EDIT.
+
+// Encodes and decodes %[1]s in the Skycoin binary encoding.
+// Requires ES2020 (for bigint) and the TextEncoder and TextDecoder globals.
+
+%[2]s
+/* Encoding rules */
+
+export class EncoderError extends Error {
+  constructor(message: string) {
+    super(message);
+    this.name = 'EncoderError';
+  }
+}
+
+export const ErrBufferUnderflow = new EncoderError('Not enough buffer data to deserialize');
+export const ErrRemainingBytes = new EncoderError('Bytes remain in buffer after deserializing object');
+export const ErrMaxLenExceeded = new EncoderError('Maximum length exceeded for variable length field');
+export const ErrMapDuplicateKeys = new EncoderError('Duplicate keys encountered while decoding a map');
+export const ErrInvalidBool = new EncoderError('Invalid value for bool type');
+
+class Encoder {
+  private buf = new Uint8Array(64);
+  private view = new DataView(this.buf.buffer);
+  private offset = 0;
+
+  // reserve grows the buffer to fit n more bytes and returns the offset to write them at
+  private reserve(n: number): number {
+    if (this.offset + n > this.buf.length) {
+      let size = this.buf.length * 2;
+      while (size < this.offset + n) {
+        size *= 2;
+      }
+      const buf = new Uint8Array(size);
+      buf.set(this.buf.subarray(0, this.offset));
+      this.buf = buf;
+      this.view = new DataView(buf.buffer);
+    }
+    const offset = this.offset;
+    this.offset += n;
+    return offset;
+  }
+
+  bool(x: boolean): void {
+    this.uint8(x ? 1 : 0);
+  }
+
+  uint8(x: number): void {
+    const offset = this.reserve(1);
+    this.view.setUint8(offset, x);
+  }
+
+  int8(x: number): void {
+    const offset = this.reserve(1);
+    this.view.setInt8(offset, x);
+  }
+
+  uint16(x: number, be = false): void {
+    const offset = this.reserve(2);
+    this.view.setUint16(offset, x, !be);
+  }
+
+  int16(x: number, be = false): void {
+    const offset = this.reserve(2);
+    this.view.setInt16(offset, x, !be);
+  }
+
+  uint32(x: number, be = false): void {
+    const offset = this.reserve(4);
+    this.view.setUint32(offset, x, !be);
+  }
+
+  int32(x: number, be = false): void {
+    const offset = this.reserve(4);
+    this.view.setInt32(offset, x, !be);
+  }
+
+  uint64(x: bigint, be = false): void {
+    const offset = this.reserve(8);
+    this.view.setBigUint64(offset, x, !be);
+  }
+
+  int64(x: bigint, be = false): void {
+    const offset = this.reserve(8);
+    this.view.setBigInt64(offset, x, !be);
+  }
+
+  float32(x: number, be = false): void {
+    const offset = this.reserve(4);
+    this.view.setFloat32(offset, x, !be);
+  }
+
+  float64(x: number, be = false): void {
+    const offset = this.reserve(8);
+    this.view.setFloat64(offset, x, !be);
+  }
+
+  bytes(x: Uint8Array): void {
+    const offset = this.reserve(x.length);
+    this.buf.set(x, offset);
+  }
+
+  finish(): Uint8Array {
+    return this.buf.slice(0, this.offset);
+  }
+}
+
+class Decoder {
+  private view: DataView;
+  offset = 0;
+
+  constructor(private buf: Uint8Array) {
+    this.view = new DataView(buf.buffer, buf.byteOffset, buf.byteLength);
+  }
+
+  remaining(): number {
+    return this.buf.length - this.offset;
+  }
+
+  // take consumes n bytes and returns the offset to read them from
+  private take(n: number): number {
+    if (n > this.remaining()) {
+      throw ErrBufferUnderflow;
+    }
+    const offset = this.offset;
+    this.offset += n;
+    return offset;
+  }
+
+  bool(): boolean {
+    const x = this.uint8();
+    if (x > 1) {
+      throw ErrInvalidBool;
+    }
+    return x === 1;
+  }
+
+  uint8(): number {
+    return this.view.getUint8(this.take(1));
+  }
+
+  int8(): number {
+    return this.view.getInt8(this.take(1));
+  }
+
+  uint16(be = false): number {
+    return this.view.getUint16(this.take(2), !be);
+  }
+
+  int16(be = false): number {
+    return this.view.getInt16(this.take(2), !be);
+  }
+
+  uint32(be = false): number {
+    return this.view.getUint32(this.take(4), !be);
+  }
+
+  int32(be = false): number {
+    return this.view.getInt32(this.take(4), !be);
+  }
+
+  uint64(be = false): bigint {
+    return this.view.getBigUint64(this.take(8), !be);
+  }
+
+  int64(be = false): bigint {
+    return this.view.getBigInt64(this.take(8), !be);
+  }
+
+  float32(be = false): number {
+    return this.view.getFloat32(this.take(4), !be);
+  }
+
+  float64(be = false): number {
+    return this.view.getFloat64(this.take(8), !be);
+  }
+
+  // length reads a length prefix, which can not exceed the remaining bytes
+  length(be = false): number {
+    const n = this.uint32(be);
+    if (n > this.remaining()) {
+      throw ErrBufferUnderflow;
+    }
+    return n;
+  }
+
+  bytes(n: number): Uint8Array {
+    const offset = this.take(n);
+    return this.buf.slice(offset, offset + n);
+  }
+
+  // consumedHex returns the hex of the bytes read since offset start
+  consumedHex(start: number): string {
+    return bytesToHex(this.buf.subarray(start, this.offset));
+  }
+}
+
+function utf8Encode(s: string): Uint8Array {
+  return new TextEncoder().encode(s);
+}
+
+function utf8Decode(b: Uint8Array): string {
+  return new TextDecoder().decode(b);
+}
+
+export function bytesToHex(b: Uint8Array): string {
+  let s = '';
+  for (const x of b) {
+    s += x.toString(16).padStart(2, '0');
+  }
+  return s;
+}
+
+export function hexToBytes(s: string): Uint8Array {
+  if (s.length %% 2 !== 0) {
+    throw new Error('hex string has odd length');
+  }
+  const b = new Uint8Array(s.length / 2);
+  for (let i = 0; i < b.length; i++) {
+    const x = parseInt(s.slice(i * 2, i * 2 + 2), 16);
+    if (isNaN(x)) {
+      throw new Error('invalid hex string');
+    }
+    b[i] = x;
+  }
+  return b;
+}
+
+/* %[1]s */
+
+// encode%[6]s encodes an object of type %[1]s
+export function encode%[6]s(obj: %[1]s): Uint8Array {
+  const e = new Encoder();
+
+%[3]s
+  return e.finish();
+}
+
+// decode%[6]s decodes an object of type %[1]s from a buffer.
+// Returns the object and the number of bytes used from the buffer to decode it.
+export function decode%[6]s(buf: Uint8Array): [%[1]s, number] {
+  const d = new Decoder(buf);
+  let obj: %[1]s;
+
+%[4]s
+  return [obj, d.offset];
+}
+
+// decode%[6]sExact decodes an object of type %[1]s from a buffer.
+// Throws ErrRemainingBytes if not all bytes in the buffer are used to decode the object.
+export function decode%[6]sExact(buf: Uint8Array): %[1]s {
+  const [obj, n] = decode%[6]s(buf);
+  if (n !== buf.length) {
+    throw ErrRemainingBytes;
+  }
+  return obj;
+}
+
+// toJSON%[6]s converts an object of type %[1]s to the canonical JSON form used by the golden test vectors.
+// 64-bit integers are decimal strings, byte arrays are hex strings and maps are lists of key-value pairs.
+export function toJSON%[6]s(obj: %[1]s): unknown {
+  return %[5]s;
+}
+`, typeName, decls, indentTS(encodeSection), indentTS(decodeSection), toJSONExpr, strings.Title(typeName))
+}
+
+func wrapTSTest(typeName, moduleName, vectorsFilename string) string {
+	return fmt.Sprintf(`// Code generated by github.com/skycoin/skyencoder. DO NOT EDIT.
+
+// Checks the TypeScript codec of %[1]s against the golden vectors produced by the Go encoder.
+// Run with e.g. "ts-node", exits with an error on the first mismatch.
+
+import { readFileSync } from 'fs';
+import { join } from 'path';
+
+import { bytesToHex, decode%[4]sExact, encode%[4]s, hexToBytes, toJSON%[4]s } from '%[2]s';
+
+interface Vector {
+  value: unknown;
+  encoded: string;
+}
+
+const file = JSON.parse(readFileSync(join(__dirname, '%[3]s'), 'utf8')) as { vectors: Vector[] };
+
+file.vectors.forEach((v, i) => {
+  const obj = decode%[4]sExact(hexToBytes(v.encoded));
+
+  const value = JSON.stringify(toJSON%[4]s(obj));
+  if (value !== JSON.stringify(v.value)) {
+    throw new Error('vector ' + i + ': decode%[4]sExact() result wrong: ' + value);
+  }
+
+  const encoded = bytesToHex(encode%[4]s(obj));
+  if (encoded !== v.encoded) {
+    throw new Error('vector ' + i + ': encode%[4]s() result wrong: ' + encoded);
+  }
+});
+`, typeName, moduleName, vectorsFilename, strings.Title(typeName))
+}
@@ -0,0 +1,92 @@
+package skyencoder
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+// updateGolden rewrites the golden files of the tests with the current output, with go test -update
+var updateGolden = flag.Bool("update", false, "update the golden files in testdata")
+
+func TestBuildStructTypeScript(t *testing.T) {
+	program, err := LoadProgram([]string{"github.com/skycoin/skyencoder/tests"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The TypeScript output and its test script are compared exactly to the golden files in testdata/typescript,
+	// which make test-typescript runs against the golden test vectors written along with them
+	cases := []string{
+		"DemoStruct",
+		"OmitEmptyMaxLenStruct1",
+		"BigEndianStruct",
+		"FixedLengthStruct",
+	}
+
+	for _, name := range cases {
+		t.Run(name, func(t *testing.T) {
+			sInfo, err := FindStructInfoInProgram(program, name)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			src, err := BuildStructTypeScript(sInfo)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			base := fmt.Sprintf("%s_skyencoder", ToSnakeCase(name))
+			checkGolden(t, filepath.Join("testdata", "typescript", base+".ts"), src)
+			checkGolden(t, filepath.Join("testdata", "typescript", base+"_test.ts"), BuildStructTypeScriptTest(sInfo, "./"+base, base+".vectors.json"))
+
+			vectors, err := BuildStructVectors(sInfo, 1, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkGolden(t, filepath.Join("testdata", "typescript", base+".vectors.json"), vectors)
+		})
+	}
+}
+
+// checkGolden compares output to a golden file, or rewrites the golden file with -update
+func checkGolden(t *testing.T, fn string, output []byte) {
+	if *updateGolden {
+		if err := ioutil.WriteFile(fn, output, 0644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+
+	golden, err := ioutil.ReadFile(fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(output, golden) {
+		t.Fatalf("Output differs from golden file %s, update it with go test -run %s -update", fn, t.Name())
+	}
+}
+
+func TestBuildStructTypeScriptFails(t *testing.T) {
+	program, err := LoadProgram([]string{"."}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"OmitEmptyNotFinal", "EmptyStructSlice1", "LenInt", "ByteOrderInvalid"} {
+		t.Run(name, func(t *testing.T) {
+			sInfo, err := FindStructInfoInProgram(program, name)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := BuildStructTypeScript(sInfo); err == nil {
+				t.Fatal("Expected BuildStructTypeScript error")
+			}
+		})
+	}
+}
@@ -0,0 +1,479 @@
+package skyencoder
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/types"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+)
+
+// VectorFile is a set of golden test vectors for a struct
+type VectorFile struct {
+	Struct  string   `json:"struct"`
+	Seed    int64    `json:"seed"`
+	Vectors []Vector `json:"vectors"`
+}
+
+// Vector is a sample object in canonical JSON form and its encoding, in hex.
+// In the canonical JSON form, 64-bit integers are decimal strings, byte arrays and byte slices are hex strings,
+// maps are lists of {"key", "value"} objects in encoded order and structs are objects with fields in encoded order.
+// Values of interface types are {"type", "value"} objects with the name of the value's type, or null if nil.
+type Vector struct {
+	Value   json.RawMessage `json:"value"`
+	Encoded string          `json:"encoded"`
+}
+
+const (
+	// vectorMaxLen is the maximum length of sampled strings, slices and maps
+	vectorMaxLen = 3
+	// vectorMaxDepth is the maximum depth of sampled values of recursive types
+	vectorMaxDepth = 3
+	// vectorStringChars are the characters of sampled strings
+	vectorStringChars = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+)
+
+// BuildStructVectors builds a JSON VectorFile with count sample objects of a struct and their encodings.
+// The samples are deterministic for a given seed. The first sample has all variable length fields empty
+// and all numeric fields zero.
+func BuildStructVectors(s *StructInfo, seed int64, count int) ([]byte, error) {
+	options, err := parseDirectives(s.Directives)
+	if err != nil {
+		return nil, err
+	}
+
+	vf := VectorFile{
+		Struct:  s.Name,
+		Seed:    seed,
+		Vectors: make([]Vector, count),
+	}
+
+	c, err := findChecksum(s.Type, options)
+	if err != nil {
+		return nil, err
+	}
+
+	sm := &vectorSampler{
+		rand: rand.New(rand.NewSource(seed)),
+	}
+
+	for i := range vf.Vectors {
+		sm.empty = i == 0
+
+		value, encoded, err := sm.sample(s.Type, options)
+		if err != nil {
+			return nil, err
+		}
+
+		if c != nil {
+			sum := c.sum(encoded)
+			encoded = append(encoded, sum[:]...)
+		}
+
+		v, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+
+		vf.Vectors[i] = Vector{
+			Value:   v,
+			Encoded: hex.EncodeToString(encoded),
+		}
+	}
+
+	b, err := json.MarshalIndent(vf, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(b, '\n'), nil
+}
+
+// vectorSampler generates sample values of a type, in canonical JSON form, with their encodings
+type vectorSampler struct {
+	rand *rand.Rand
+	// empty makes all variable length values empty and all numeric values zero
+	empty bool
+	// depth is the depth of the sampled value in recursive types
+	depth int
+}
+
+// jsonObject is a JSON object which preserves the order of its fields
+type jsonObject []jsonField
+
+type jsonField struct {
+	Name  string
+	Value interface{}
+}
+
+// MarshalJSON implements json.Marshaler
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, f := range o {
+		if i != 0 {
+			buf.WriteByte(',')
+		}
+
+		k, err := json.Marshal(f.Name)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+
+		v, err := json.Marshal(f.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+type jsonMapEntry struct {
+	Key   interface{} `json:"key"`
+	Value interface{} `json:"value"`
+}
+
+type jsonUnion struct {
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+func (sm *vectorSampler) uint64() uint64 {
+	if sm.empty {
+		return 0
+	}
+	return sm.rand.Uint64()
+}
+
+func (sm *vectorSampler) float64() float64 {
+	if sm.empty {
+		return 0
+	}
+	return sm.rand.NormFloat64() * 1000
+}
+
+// length returns the length of a sampled string, slice or map
+func (sm *vectorSampler) length(options *Options) int {
+	if options != nil && options.Length > 0 {
+		return int(options.Length)
+	}
+
+	if sm.empty {
+		return 0
+	}
+
+	// Values nested deeper in recursive types are empty, so that the samples are finite and can be decoded
+	if sm.depth >= vectorMaxDepth || (options != nil && options.MaxDepth > 0 && uint64(sm.depth) >= options.MaxDepth) {
+		return 0
+	}
+
+	max := vectorMaxLen
+	if options != nil && options.MaxLength > 0 && options.MaxLength < uint64(max) {
+		max = int(options.MaxLength)
+	}
+
+	return sm.rand.Intn(max + 1)
+}
+
+func putVectorUint(buf []byte, size int, x uint64, options *Options) []byte {
+	b := make([]byte, 8)
+	if options != nil && options.BigEndian {
+		binary.BigEndian.PutUint64(b, x)
+		b = b[8-size:]
+	} else {
+		binary.LittleEndian.PutUint64(b, x)
+		b = b[:size]
+	}
+	return append(buf, b...)
+}
+
+// putVectorLength encodes the length prefix of a string, slice or map
+func putVectorLength(buf []byte, n int, options *Options) []byte {
+	if options != nil && options.Length > 0 {
+		return buf
+	}
+	return putVectorUint(buf, 4, uint64(n), options)
+}
+
+func (sm *vectorSampler) sample(t types.Type, options *Options) (interface{}, []byte, error) {
+	// The value of a const field is always its constant, which is converted to JSON by explaining its encoding
+	if options != nil && options.Const != "" {
+		encoded, _, err := constValue(t, nil, options)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		e := &explainer{
+			buf: encoded,
+		}
+		v, err := e.walk(t, "", options)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return v, encoded, nil
+	}
+
+	switch x := t.(type) {
+	case *types.Named:
+		if isRecursive(x) {
+			sm.depth++
+			defer func() {
+				sm.depth--
+			}()
+			return sm.sample(x.Underlying(), recursiveOptions(options))
+		}
+		if isUnion(x) {
+			members, err := findUnion(x, options)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			// A nil value is sampled as often as each member type
+			i := len(members)
+			if !sm.empty {
+				i = sm.rand.Intn(len(members) + 1)
+			}
+			if i == len(members) {
+				return nil, []byte{0}, nil
+			}
+
+			m := members[i]
+			v, b, err := sm.sample(m.t, inheritOptions(options, nil))
+			if err != nil {
+				return nil, nil, err
+			}
+
+			return &jsonUnion{
+				Type:  m.t.Obj().Name(),
+				Value: v,
+			}, append([]byte{m.tag}, b...), nil
+		}
+		return sm.sample(x.Underlying(), options)
+
+	case *types.Basic:
+		switch x.Kind() {
+		case types.Bool:
+			v := sm.uint64()&1 == 1
+			b := byte(0)
+			if v {
+				b = 1
+			}
+			return v, []byte{b}, nil
+		case types.Int8:
+			v := int8(sm.uint64())
+			return v, []byte{byte(v)}, nil
+		case types.Uint8:
+			v := uint8(sm.uint64())
+			return v, []byte{v}, nil
+		case types.Int16:
+			v := int16(sm.uint64())
+			return v, putVectorUint(nil, 2, uint64(uint16(v)), options), nil
+		case types.Uint16:
+			v := uint16(sm.uint64())
+			return v, putVectorUint(nil, 2, uint64(v), options), nil
+		case types.Int32:
+			v := int32(sm.uint64())
+			return v, putVectorUint(nil, 4, uint64(uint32(v)), options), nil
+		case types.Uint32:
+			v := uint32(sm.uint64())
+			return v, putVectorUint(nil, 4, uint64(v), options), nil
+		case types.Int64:
+			v := int64(sm.uint64())
+			return strconv.FormatInt(v, 10), putVectorUint(nil, 8, uint64(v), options), nil
+		case types.Uint64:
+			v := sm.uint64()
+			return strconv.FormatUint(v, 10), putVectorUint(nil, 8, v, options), nil
+		case types.Float32:
+			v := float32(sm.float64())
+			return float64(v), putVectorUint(nil, 4, uint64(math.Float32bits(v)), options), nil
+		case types.Float64:
+			v := sm.float64()
+			return v, putVectorUint(nil, 8, math.Float64bits(v), options), nil
+		case types.String:
+			n := sm.length(options)
+			v := make([]byte, n)
+			for i := range v {
+				v[i] = vectorStringChars[sm.rand.Intn(len(vectorStringChars))]
+			}
+			return string(v), append(putVectorLength(nil, n, options), v...), nil
+		default:
+			return nil, nil, fmt.Errorf("Unhandled *types.Basic type %s", x.Name())
+		}
+
+	case *types.Array:
+		if isByte(x.Elem()) {
+			v := make([]byte, x.Len())
+			if !sm.empty {
+				sm.rand.Read(v)
+			}
+			return hex.EncodeToString(v), v, nil
+		}
+
+		return sm.sampleList(x.Elem(), int(x.Len()), nil, options)
+
+	case *types.Slice:
+		if empty, err := isEmptyStruct(x.Elem()); err != nil {
+			return nil, nil, err
+		} else if empty {
+			return nil, nil, errors.New("A slice of an empty encoded struct is not allowed")
+		}
+
+		n := sm.length(options)
+
+		if isByte(x.Elem()) {
+			v := make([]byte, n)
+			sm.rand.Read(v)
+			return hex.EncodeToString(v), append(putVectorLength(nil, n, options), v...), nil
+		}
+
+		return sm.sampleList(x.Elem(), n, putVectorLength(nil, n, options), options)
+
+	case *types.Map:
+		n := sm.length(options)
+
+		type entry struct {
+			key     []byte
+			value   jsonMapEntry
+			encoded []byte
+		}
+
+		// Sample keys until there are n unique keys, giving up eventually for key types with few values
+		var entries []entry
+		seen := make(map[string]struct{}, n)
+		for attempts := 0; len(entries) < n && attempts < 10*n; attempts++ {
+			k, kb, err := sm.sample(x.Key(), inheritOptions(options, nil))
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if _, ok := seen[string(kb)]; ok {
+				continue
+			}
+			seen[string(kb)] = struct{}{}
+
+			v, vb, err := sm.sample(x.Elem(), inheritOptions(options, nil))
+			if err != nil {
+				return nil, nil, err
+			}
+
+			entries = append(entries, entry{
+				key: kb,
+				value: jsonMapEntry{
+					Key:   k,
+					Value: v,
+				},
+				encoded: append(append([]byte{}, kb...), vb...),
+			})
+		}
+
+		// Map iteration order is random, so the samples are encoded in order of their encoded keys
+		sort.Slice(entries, func(i, j int) bool {
+			return bytes.Compare(entries[i].key, entries[j].key) < 0
+		})
+
+		values := make([]jsonMapEntry, len(entries))
+		encoded := putVectorLength(nil, len(entries), options)
+		for i, e := range entries {
+			values[i] = e.value
+			encoded = append(encoded, e.encoded...)
+		}
+
+		return values, encoded, nil
+
+	case *types.Struct:
+		var obj jsonObject
+		var encoded []byte
+		order, err := encodedFields(x)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for _, i := range order {
+			f := x.Field(i)
+
+			if !f.Exported() {
+				continue
+			}
+
+			ignore, fieldOptions, err := parseTag(x.Tag(i))
+			if err != nil {
+				return nil, nil, err
+			}
+
+			if ignore {
+				continue
+			}
+
+			fieldOptions = inheritOptions(options, fieldOptions)
+
+			v, b, err := sm.sample(f.Type(), fieldOptions)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			obj = append(obj, jsonField{
+				Name:  f.Name(),
+				Value: v,
+			})
+
+			// An empty omitempty field is not encoded
+			if fieldOptions != nil && fieldOptions.OmitEmpty && isEmptyVectorValue(v) {
+				continue
+			}
+
+			encoded = append(encoded, b...)
+		}
+
+		if obj == nil {
+			obj = jsonObject{}
+		}
+
+		return obj, encoded, nil
+
+	default:
+		return nil, nil, fmt.Errorf("Unhandled type %T", x)
+	}
+}
+
+// isEmptyVectorValue returns true if a sampled string, slice or map value is empty
+func isEmptyVectorValue(v interface{}) bool {
+	switch x := v.(type) {
+	case string:
+		return x == ""
+	case []interface{}:
+		return len(x) == 0
+	case []jsonMapEntry:
+		return len(x) == 0
+	default:
+		return false
+	}
+}
+
+// sampleList samples n elements of an array or slice, appending their encodings to prefix
+func (sm *vectorSampler) sampleList(elem types.Type, n int, prefix []byte, options *Options) (interface{}, []byte, error) {
+	values := make([]interface{}, n)
+	encoded := prefix
+	for i := range values {
+		v, b, err := sm.sample(elem, inheritOptions(options, nil))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		values[i] = v
+		encoded = append(encoded, b...)
+	}
+
+	return values, encoded, nil
+}
@@ -0,0 +1,97 @@
+package skyencoder
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+func TestBuildStructVectors(t *testing.T) {
+	program, err := LoadProgram([]string{"github.com/skycoin/skyencoder/tests"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sInfo, err := FindStructInfoInProgram(program, "StaticStruct")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := BuildStructVectors(sInfo, 1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var vf VectorFile
+	if err := json.Unmarshal(b, &vf); err != nil {
+		t.Fatal(err)
+	}
+
+	if vf.Struct != "StaticStruct" || vf.Seed != 1 || len(vf.Vectors) != 3 {
+		t.Fatalf("BuildStructVectors VectorFile wrong: %+v", vf)
+	}
+
+	// The first vector is the empty object
+	var value bytes.Buffer
+	if err := json.Compact(&value, vf.Vectors[0].Value); err != nil {
+		t.Fatal(err)
+	}
+	if value.String() != `{"A":0,"B":0,"Hash":"0000000000000000000000000000000000000000"}` {
+		t.Fatalf("BuildStructVectors first vector value wrong: %s", value.String())
+	}
+	if vf.Vectors[0].Encoded != "00"+"00000000"+"0000000000000000000000000000000000000000" {
+		t.Fatalf("BuildStructVectors first vector encoding wrong: %s", vf.Vectors[0].Encoded)
+	}
+
+	for _, v := range vf.Vectors {
+		if len(v.Encoded) != 2*(1+4+20) {
+			t.Fatalf("BuildStructVectors vector encoding has wrong length: %s", v.Encoded)
+		}
+	}
+
+	// Vectors are deterministic for a seed
+	b2, err := BuildStructVectors(sInfo, 1, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(b, b2) {
+		t.Fatal("BuildStructVectors is not deterministic")
+	}
+
+	b3, err := BuildStructVectors(sInfo, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Equal(b, b3) {
+		t.Fatal("BuildStructVectors did not change with the seed")
+	}
+}
+
+func TestBuildStructVectorsTest(t *testing.T) {
+	program, err := LoadProgram([]string{"github.com/skycoin/skyencoder/tests"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sInfo, err := FindStructInfoInProgram(program, "StaticStruct")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	src, err := BuildStructVectorsTest(sInfo, "", "./foo123123123123999.go", "testdata/StaticStruct.vectors.json", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, x := range []string{
+		"func TestSkyencoderStaticStructVectors(t *testing.T) {",
+		`filepath.FromSlash("testdata/StaticStruct.vectors.json")`,
+		"decodeStaticStructExact(data, &obj)",
+		"encodeStaticStruct(&obj)",
+		"!bytes.Equal(data, data2)",
+	} {
+		if !bytes.Contains(src, []byte(x)) {
+			t.Fatalf("BuildStructVectorsTest output does not contain %q", x)
+		}
+	}
+}
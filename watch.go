@@ -0,0 +1,115 @@
+package skyencoder
+
+import (
+	"fmt"
+	"go/types"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/loader"
+)
+
+// StructFingerprint returns a description of everything about a struct which affects its generated code:
+// its name, directives, fields, field tags and the definitions and directives of the named types it references.
+// The code only needs to be regenerated when the fingerprint changes, not on changes to unrelated code.
+func StructFingerprint(p *loader.Program, s *StructInfo) string {
+	f := newTypeFingerprinter(p)
+	fmt.Fprintf(&f.b, "%s %v %q\n", s.Name, s.Exported, s.Directives)
+	f.walk(s.Type)
+	return f.b.String()
+}
+
+// StructSourceFiles returns the source files of the packages declaring the struct and the named types it references
+func StructSourceFiles(p *loader.Program, s *StructInfo) []string {
+	f := newTypeFingerprinter(p)
+	f.pkgs[s.Package] = struct{}{}
+	f.walk(s.Type)
+
+	var files []string
+	for pkg := range f.pkgs {
+		info := p.AllPackages[pkg]
+		if info == nil {
+			continue
+		}
+		for _, file := range info.Files {
+			if tf := p.Fset.File(file.Pos()); tf != nil {
+				files = append(files, tf.Name())
+			}
+		}
+	}
+
+	sort.Strings(files)
+	return files
+}
+
+// typeFingerprinter walks a type, describing it and collecting the packages of the named types it references.
+// The directives of the named types are looked up in the program's source files.
+type typeFingerprinter struct {
+	b       strings.Builder
+	program *loader.Program
+	seen    map[*types.TypeName]struct{}
+	pkgs    map[*types.Package]struct{}
+}
+
+func newTypeFingerprinter(p *loader.Program) *typeFingerprinter {
+	return &typeFingerprinter{
+		program: p,
+		seen:    make(map[*types.TypeName]struct{}),
+		pkgs:    make(map[*types.Package]struct{}),
+	}
+}
+
+func (f *typeFingerprinter) walk(t types.Type) {
+	switch x := t.(type) {
+	case *types.Named:
+		obj := x.Obj()
+		f.b.WriteString(types.TypeString(x, nil))
+
+		// Named types are described once, which also stops recursive types
+		if _, ok := f.seen[obj]; ok {
+			return
+		}
+		f.seen[obj] = struct{}{}
+		if obj.Pkg() != nil {
+			f.pkgs[obj.Pkg()] = struct{}{}
+
+			if info := f.program.AllPackages[obj.Pkg()]; info != nil {
+				fmt.Fprintf(&f.b, "%q", findDirectives(info, obj.Name()))
+			}
+		}
+
+		f.b.WriteString("=")
+		f.walk(x.Underlying())
+
+	case *types.Struct:
+		f.b.WriteString("struct{")
+		for i := 0; i < x.NumFields(); i++ {
+			field := x.Field(i)
+			fmt.Fprintf(&f.b, "%s %q ", field.Name(), x.Tag(i))
+			f.walk(field.Type())
+			f.b.WriteString(";")
+		}
+		f.b.WriteString("}")
+
+	case *types.Array:
+		fmt.Fprintf(&f.b, "[%d]", x.Len())
+		f.walk(x.Elem())
+
+	case *types.Slice:
+		f.b.WriteString("[]")
+		f.walk(x.Elem())
+
+	case *types.Map:
+		f.b.WriteString("map[")
+		f.walk(x.Key())
+		f.b.WriteString("]")
+		f.walk(x.Elem())
+
+	case *types.Pointer:
+		f.b.WriteString("*")
+		f.walk(x.Elem())
+
+	default:
+		f.b.WriteString(t.String())
+	}
+}
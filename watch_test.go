@@ -0,0 +1,214 @@
+package skyencoder
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// tempDir creates a temporary directory, returning it with a function which removes it
+func tempDir(t *testing.T) (string, func()) {
+	t.Helper()
+
+	dir, err := ioutil.TempDir("", "skyencoder")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return dir, func() {
+		os.RemoveAll(dir)
+	}
+}
+
+func TestStructFingerprint(t *testing.T) {
+	dir, cleanup := tempDir(t)
+	defer cleanup()
+	fn := filepath.Join(dir, "foo.go")
+
+	fingerprint := func(src string) string {
+		t.Helper()
+
+		if err := ioutil.WriteFile(fn, []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		program, err := LoadProgram([]string{fn}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		sInfo, err := FindStructInfoInProgram(program, "Foo")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		files := StructSourceFiles(program, sInfo)
+		if len(files) != 1 || files[0] != fn {
+			t.Fatalf("StructSourceFiles = %v, expected [%s]", files, fn)
+		}
+
+		return StructFingerprint(program, sInfo)
+	}
+
+	base := fingerprint(`package foo
+
+type Foo struct {
+	A Bar
+	B []string ` + "`enc:\",maxlen=4\"`" + `
+}
+
+type Bar int32
+
+type Baz struct{}
+
+func F() int { return 1 }
+`)
+
+	cases := []struct {
+		name    string
+		src     string
+		changed bool
+	}{
+		{
+			name: "unrelated code changed",
+			src: `package foo
+
+// Foo is documented
+type Foo struct {
+	A Bar
+	B []string ` + "`enc:\",maxlen=4\"`" + `
+}
+
+type Bar int32
+
+type Baz struct {
+	X int64
+}
+
+func F() int { return 2 }
+
+func G() {}
+`,
+		},
+		{
+			name: "referenced type changed",
+			src: `package foo
+
+type Foo struct {
+	A Bar
+	B []string ` + "`enc:\",maxlen=4\"`" + `
+}
+
+type Bar int64
+`,
+			changed: true,
+		},
+		{
+			name: "tag changed",
+			src: `package foo
+
+type Foo struct {
+	A Bar
+	B []string ` + "`enc:\",maxlen=5\"`" + `
+}
+
+type Bar int32
+`,
+			changed: true,
+		},
+		{
+			name: "field renamed",
+			src: `package foo
+
+type Foo struct {
+	A Bar
+	C []string ` + "`enc:\",maxlen=4\"`" + `
+}
+
+type Bar int32
+`,
+			changed: true,
+		},
+		{
+			name: "directive added",
+			src: `package foo
+
+//skyencoder:byteorder big
+type Foo struct {
+	A Bar
+	B []string ` + "`enc:\",maxlen=4\"`" + `
+}
+
+type Bar int32
+`,
+			changed: true,
+		},
+		{
+			name: "referenced type directive added",
+			src: `package foo
+
+type Foo struct {
+	A Bar
+	B []string ` + "`enc:\",maxlen=4\"`" + `
+}
+
+//skyencoder:byteorder big
+type Bar int32
+`,
+			changed: true,
+		},
+		{
+			name: "unreferenced type directive added",
+			src: `package foo
+
+type Foo struct {
+	A Bar
+	B []string ` + "`enc:\",maxlen=4\"`" + `
+}
+
+type Bar int32
+
+//skyencoder:byteorder big
+type Baz struct{}
+`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			changed := fingerprint(tc.src) != base
+			if changed != tc.changed {
+				t.Fatalf("StructFingerprint changed=%v, expected %v", changed, tc.changed)
+			}
+		})
+	}
+}
+
+func TestStructSourceFiles(t *testing.T) {
+	program, err := LoadProgram([]string{"github.com/skycoin/skyencoder/benchmark"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sInfo, err := FindStructInfoInProgram(program, "NumericStruct")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// NumericStruct references cipher.SHA256, so the files of the cipher package are included
+	hasStructs := false
+	hasCipher := false
+	for _, fn := range StructSourceFiles(program, sInfo) {
+		switch {
+		case filepath.Base(fn) == "benchmark.go":
+			hasStructs = true
+		case filepath.Base(filepath.Dir(fn)) == "cipher":
+			hasCipher = true
+		}
+	}
+
+	if !hasStructs || !hasCipher {
+		t.Fatalf("StructSourceFiles is missing the files of the struct's package (%v) or of package cipher (%v)", hasStructs, hasCipher)
+	}
+}